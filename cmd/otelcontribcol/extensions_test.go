@@ -36,6 +36,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/sigv4authextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/memorystorage"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
 )
 
@@ -186,6 +187,14 @@ func TestDefaultExtensions(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			extension: "memory_storage",
+			getConfigFn: func() component.Config {
+				cfg := extFactories["memory_storage"].CreateDefaultConfig().(*memorystorage.Config)
+				cfg.Snapshot.Directory = t.TempDir()
+				return cfg
+			},
+		},
 		{
 			extension: "host_observer",
 			getConfigFn: func() component.Config {
@@ -27,6 +27,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/chronyreceiver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/datadogreceiver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/filelogreceiver"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httplogreceiver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/jmxreceiver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mongodbatlasreceiver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/namedpipereceiver"
@@ -210,6 +211,14 @@ func TestDefaultReceivers(t *testing.T) {
 		{
 			receiver: "httpcheck",
 		},
+		{
+			receiver: "httplog",
+			getConfigFn: func() component.Config {
+				cfg := rcvrFactories["httplog"].CreateDefaultConfig().(*httplogreceiver.HTTPLogConfig)
+				cfg.InputConfig.ListenAddress = "0.0.0.0:0"
+				return cfg
+			},
+		},
 		{
 			receiver: "influxdb",
 		},
@@ -4,6 +4,7 @@
 package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
 
 import (
+	"errors"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/servicediscovery/types"
@@ -19,11 +20,37 @@ const (
 	resourceRouting
 )
 
+var (
+	errInvalidLoadFactor     = errors.New("load_factor must be 0 to disable the bounded load, or at least 1")
+	errInvalidErrorThreshold = errors.New("ejection error_threshold must not be negative")
+	errInvalidEjectionPeriod = errors.New("ejection duration must be positive when ejection is enabled")
+)
+
 // Config defines configuration for the exporter.
 type Config struct {
 	Protocol   Protocol         `mapstructure:"protocol"`
 	Resolver   ResolverSettings `mapstructure:"resolver"`
 	RoutingKey string           `mapstructure:"routing_key"`
+
+	// RoutingAttributes are the names of the attributes whose values route the records
+	// when the routing key is "attributes". Each attribute is looked up in the record,
+	// then in its scope, then in its resource.
+	RoutingAttributes []string `mapstructure:"routing_attributes"`
+
+	// LoadFactor bounds the share of the hash ring of each backend to LoadFactor times
+	// its average share. Zero disables the bound.
+	LoadFactor float64 `mapstructure:"load_factor"`
+	// Ejection configures the temporary removal of failing backends from the hash ring.
+	Ejection EjectionSettings `mapstructure:"ejection"`
+}
+
+// EjectionSettings defines when backends are ejected from the hash ring, and for how long
+type EjectionSettings struct {
+	// ErrorThreshold is the number of consecutive failed exports after which a backend
+	// is ejected. Zero disables the ejection.
+	ErrorThreshold int `mapstructure:"error_threshold"`
+	// Duration is the time a backend stays out of the ring before it is added back.
+	Duration time.Duration `mapstructure:"duration"`
 }
 
 // Protocol holds the individual protocol-specific settings. Only OTLP is supported at the moment.
@@ -33,10 +60,11 @@ type Protocol struct {
 
 // ResolverSettings defines the configurations for the backend resolver
 type ResolverSettings struct {
-	Static      *StaticResolver      `mapstructure:"static"`
-	DNS         *DNSResolver         `mapstructure:"dns"`
-	K8sSvc      *K8sSvcResolver      `mapstructure:"k8s"`
-	AWSCloudMap *AWSCloudMapResolver `mapstructure:"awsCloudMap"`
+	Static           *StaticResolver           `mapstructure:"static"`
+	DNS              *DNSResolver              `mapstructure:"dns"`
+	K8sSvc           *K8sSvcResolver           `mapstructure:"k8s"`
+	K8sEndpointSlice *K8sEndpointSliceResolver `mapstructure:"k8s_endpointslice"`
+	AWSCloudMap      *AWSCloudMapResolver      `mapstructure:"awsCloudMap"`
 }
 
 // StaticResolver defines the configuration for the resolver providing a fixed list of backends
@@ -59,6 +87,20 @@ type K8sSvcResolver struct {
 	Timeout time.Duration `mapstructure:"timeout"`
 }
 
+// K8sEndpointSliceResolver defines the configuration for the resolver based on Kubernetes EndpointSlices
+type K8sEndpointSliceResolver struct {
+	Service string        `mapstructure:"service"`
+	Ports   []int32       `mapstructure:"ports"`
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// Zone is the topology zone of this collector. When set, the backends in other
+	// zones get fewer points in the hash ring than the backends in the same zone.
+	Zone string `mapstructure:"zone"`
+	// CrossZoneWeight is the number of points in the hash ring of the backends in
+	// other zones, out of the 100 points of the backends in the same zone.
+	CrossZoneWeight int `mapstructure:"cross_zone_weight"`
+}
+
 type AWSCloudMapResolver struct {
 	NamespaceName string                   `mapstructure:"namespace"`
 	ServiceName   string                   `mapstructure:"serviceName"`
@@ -67,3 +109,23 @@ type AWSCloudMapResolver struct {
 	Timeout       time.Duration            `mapstructure:"timeout"`
 	Port          *uint16                  `mapstructure:"port"`
 }
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.RoutingKey == attributesRoutingKey && len(cfg.RoutingAttributes) == 0 {
+		return errNoRoutingAttributes
+	}
+	if len(cfg.RoutingAttributes) > 0 && cfg.RoutingKey != "" && cfg.RoutingKey != attributesRoutingKey {
+		return errRoutingAttributesUnused
+	}
+	if cfg.LoadFactor != 0 && cfg.LoadFactor < 1 {
+		return errInvalidLoadFactor
+	}
+	if cfg.Ejection.ErrorThreshold < 0 {
+		return errInvalidErrorThreshold
+	}
+	if cfg.Ejection.ErrorThreshold > 0 && cfg.Ejection.Duration <= 0 {
+		return errInvalidEjectionPeriod
+	}
+	return nil
+}
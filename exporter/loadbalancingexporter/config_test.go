@@ -6,7 +6,9 @@ package loadbalancingexporter
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
@@ -24,4 +26,77 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, component.UnmarshalConfig(sub, cfg))
 	require.NotNil(t, cfg)
+
+	cfg = factory.CreateDefaultConfig()
+	sub, err = cm.Sub(component.NewIDWithName(metadata.Type, "5").String())
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+	assert.Equal(t, &K8sEndpointSliceResolver{
+		Service:         "lb-svc.lb-ns",
+		Ports:           []int32{4317},
+		Zone:            "us-east-1a",
+		CrossZoneWeight: 20,
+	}, cfg.(*Config).Resolver.K8sEndpointSlice)
+
+	cfg = factory.CreateDefaultConfig()
+	sub, err = cm.Sub(component.NewIDWithName(metadata.Type, "7").String())
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+	assert.Equal(t, "attributes", cfg.(*Config).RoutingKey)
+	assert.Equal(t, []string{"tenant.id", "k8s.pod.uid"}, cfg.(*Config).RoutingAttributes)
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestValidateConfig(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		cfg  *Config
+		err  error
+	}{
+		{
+			name: "defaults",
+			cfg:  createDefaultConfig().(*Config),
+		},
+		{
+			name: "bounded load and ejection",
+			cfg:  &Config{LoadFactor: 1.25, Ejection: EjectionSettings{ErrorThreshold: 5, Duration: time.Minute}},
+		},
+		{
+			name: "load factor below 1",
+			cfg:  &Config{LoadFactor: 0.5},
+			err:  errInvalidLoadFactor,
+		},
+		{
+			name: "negative error threshold",
+			cfg:  &Config{Ejection: EjectionSettings{ErrorThreshold: -1}},
+			err:  errInvalidErrorThreshold,
+		},
+		{
+			name: "ejection without duration",
+			cfg:  &Config{Ejection: EjectionSettings{ErrorThreshold: 5}},
+			err:  errInvalidEjectionPeriod,
+		},
+		{
+			name: "routing attributes",
+			cfg:  &Config{RoutingKey: "attributes", RoutingAttributes: []string{"tenant.id"}},
+		},
+		{
+			name: "routing attributes without routing key",
+			cfg:  &Config{RoutingAttributes: []string{"tenant.id"}},
+		},
+		{
+			name: "attributes routing key without attributes",
+			cfg:  &Config{RoutingKey: "attributes"},
+			err:  errNoRoutingAttributes,
+		},
+		{
+			name: "routing attributes with another routing key",
+			cfg:  &Config{RoutingKey: "service", RoutingAttributes: []string{"tenant.id"}},
+			err:  errRoutingAttributesUnused,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.cfg.Validate(), tt.err)
+		})
+	}
 }
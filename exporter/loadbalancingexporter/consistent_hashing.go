@@ -35,6 +35,18 @@ func newHashRing(endpoints []string) *hashRing {
 	}
 }
 
+// newWeightedHashRing builds a new immutable consistent hash ring based on the given endpoints,
+// where the weights are the number of points in the ring for each endpoint. Endpoints without
+// a weight get the default weight.
+func newWeightedHashRing(endpoints []string, weights map[string]int) *hashRing {
+	items := positionsForWeightedEndpoints(endpoints, func(endpoint string) int {
+		return weightOf(endpoint, weights)
+	})
+	return &hashRing{
+		items: items,
+	}
+}
+
 // endpointFor calculates which backend is responsible for the given traceID
 func (h *hashRing) endpointFor(identifier []byte) string {
 	if h == nil {
@@ -49,6 +61,14 @@ func (h *hashRing) endpointFor(identifier []byte) string {
 	return h.findEndpoint(position(pos))
 }
 
+// weightOf returns the weight of the endpoint, or the default weight if it has none
+func weightOf(endpoint string, weights map[string]int) int {
+	if weight, ok := weights[endpoint]; ok {
+		return weight
+	}
+	return defaultWeight
+}
+
 // findEndpoint returns the "next" endpoint starting from the given position, or an empty string in case no endpoints are available
 func (h *hashRing) findEndpoint(pos position) string {
 	ringSize := len(h.items)
@@ -115,11 +135,16 @@ func positionsFor(endpoint string, numPoints int) []position {
 
 // positionsForEndpoints calculates all the positions for all the given endpoints
 func positionsForEndpoints(endpoints []string, weight int) []ringItem {
+	return positionsForWeightedEndpoints(endpoints, func(string) int { return weight })
+}
+
+// positionsForWeightedEndpoints calculates all the positions for all the given endpoints,
+// with the number of positions of each endpoint returned by weightFor
+func positionsForWeightedEndpoints(endpoints []string, weightFor func(endpoint string) int) []ringItem {
 	var items []ringItem
 	positions := map[position]bool{} // tracking the used positions
 	for _, endpoint := range endpoints {
-		// for this initial implementation, we don't allow endpoints to have custom weights
-		for _, pos := range positionsFor(endpoint, weight) {
+		for _, pos := range positionsFor(endpoint, weightFor(endpoint)) {
 			// if this position is occupied already, skip this item
 			if _, found := positions[pos]; found {
 				continue
@@ -141,6 +166,65 @@ func positionsForEndpoints(endpoints []string, weight int) []ringItem {
 	return items
 }
 
+// withBoundedLoad returns a new ring in which no endpoint owns more than loadFactor times its
+// fair share of the ring, based on its weight. The positions exceeding the bound are handed
+// over to the next endpoints in the ring that are under their bound, as in consistent hashing
+// with bounded loads, where the load is the share of the identifiers routed to each endpoint.
+func (h *hashRing) withBoundedLoad(loadFactor float64, weights map[string]int) *hashRing {
+	if h == nil || len(h.items) == 0 {
+		return h
+	}
+
+	totalWeight := 0
+	for endpoint := range h.ownership() {
+		totalWeight += weightOf(endpoint, weights)
+	}
+	bound := func(endpoint string) float64 {
+		return loadFactor * float64(maxPositions) * float64(weightOf(endpoint, weights)) / float64(totalWeight)
+	}
+
+	owned := map[string]uint32{}
+	items := make([]ringItem, len(h.items))
+	for i, item := range h.items {
+		arc := h.arc(i)
+		endpoint := item.endpoint
+		// walk the ring for the first endpoint that can take this position
+		for j := 0; j < len(h.items); j++ {
+			candidate := h.items[(i+j)%len(h.items)].endpoint
+			if float64(owned[candidate]+arc) <= bound(candidate) {
+				endpoint = candidate
+				break
+			}
+		}
+		owned[endpoint] += arc
+		items[i] = ringItem{pos: item.pos, endpoint: endpoint}
+	}
+	return &hashRing{
+		items: items,
+	}
+}
+
+// arc returns the number of positions owned by the item at the given index, which
+// are the positions after the previous item, up to and including its own position.
+func (h *hashRing) arc(i int) uint32 {
+	if i == 0 {
+		return uint32(h.items[0].pos) + maxPositions - uint32(h.items[len(h.items)-1].pos)
+	}
+	return uint32(h.items[i].pos - h.items[i-1].pos)
+}
+
+// ownership returns the share of the ring owned by each endpoint, between 0 and 1
+func (h *hashRing) ownership() map[string]float64 {
+	shares := map[string]float64{}
+	if h == nil {
+		return shares
+	}
+	for i, item := range h.items {
+		shares[item.endpoint] += float64(h.arc(i)) / float64(maxPositions)
+	}
+	return shares
+}
+
 func (h *hashRing) equal(candidate *hashRing) bool {
 	if candidate == nil {
 		return false
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewHashRing(t *testing.T) {
@@ -219,3 +220,94 @@ func TestEqual(t *testing.T) {
 		})
 	}
 }
+
+func TestNewWeightedHashRing(t *testing.T) {
+	// prepare
+	endpoints := []string{"endpoint-1", "endpoint-2"}
+
+	// test
+	ring := newWeightedHashRing(endpoints, map[string]int{"endpoint-2": 10})
+
+	// verify
+	counts := map[string]int{}
+	for _, item := range ring.items {
+		counts[item.endpoint]++
+	}
+	assert.LessOrEqual(t, counts["endpoint-2"], 10)
+	assert.Greater(t, counts["endpoint-1"], 5*counts["endpoint-2"])
+	assert.True(t, newHashRing(endpoints).equal(newWeightedHashRing(endpoints, nil)))
+}
+
+func TestOwnership(t *testing.T) {
+	// prepare
+	ring := newHashRing([]string{"endpoint-1", "endpoint-2", "endpoint-3"})
+
+	// test
+	ownership := ring.ownership()
+
+	// verify
+	assert.Len(t, ownership, 3)
+	total := 0.0
+	for _, share := range ownership {
+		total += share
+	}
+	assert.InDelta(t, 1.0, total, 1e-9)
+	assert.Empty(t, (*hashRing)(nil).ownership())
+}
+
+func TestWithBoundedLoad(t *testing.T) {
+	endpoints := []string{"endpoint-1", "endpoint-2", "endpoint-3", "endpoint-4", "endpoint-5"}
+	for _, tt := range []struct {
+		name       string
+		loadFactor float64
+		weights    map[string]int
+	}{
+		{
+			name:       "tight bound",
+			loadFactor: 1.05,
+		},
+		{
+			name:       "loose bound",
+			loadFactor: 1.5,
+		},
+		{
+			name:       "weighted endpoints",
+			loadFactor: 1.1,
+			weights:    map[string]int{"endpoint-1": 50, "endpoint-2": 50},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// prepare
+			ring := newWeightedHashRing(endpoints, tt.weights)
+			totalWeight := 0
+			for _, endpoint := range endpoints {
+				totalWeight += weightOf(endpoint, tt.weights)
+			}
+
+			// test
+			bounded := ring.withBoundedLoad(tt.loadFactor, tt.weights)
+
+			// verify
+			require.Len(t, bounded.items, len(ring.items))
+			total := 0.0
+			for endpoint, share := range bounded.ownership() {
+				fair := float64(weightOf(endpoint, tt.weights)) / float64(totalWeight)
+				assert.LessOrEqual(t, share, tt.loadFactor*fair, "Endpoint %s owns more than its bound", endpoint)
+				total += share
+			}
+			assert.InDelta(t, 1.0, total, 1e-9)
+			assert.True(t, bounded.equal(ring.withBoundedLoad(tt.loadFactor, tt.weights)), "Must be deterministic")
+		})
+	}
+}
+
+func TestWithBoundedLoadKeepsBalancedRing(t *testing.T) {
+	// prepare
+	ring := newHashRing([]string{"endpoint-1"})
+
+	// test
+	bounded := ring.withBoundedLoad(1, nil)
+
+	// verify
+	assert.True(t, ring.equal(bounded))
+}
@@ -7,9 +7,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/exporter"
 	"go.uber.org/zap"
@@ -33,11 +37,24 @@ type loadBalancer struct {
 	res  resolver
 	ring *hashRing
 
+	// the last endpoints from the resolver, and their weights in the ring
+	resolved []string
+	weights  map[string]int
+
+	loadFactor float64
+	ejection   EjectionSettings
+	// the endpoints ejected from the ring, with the timers adding them back
+	ejected map[string]*time.Timer
+
 	componentFactory componentFactory
 	exporters        map[string]*wrappedExporter
 
 	stopped    bool
 	updateLock sync.RWMutex
+
+	// the number of consecutive failed exports of each endpoint
+	failures   map[string]int
+	healthLock sync.Mutex
 }
 
 // Create new load balancer
@@ -57,6 +74,9 @@ func newLoadBalancer(params exporter.CreateSettings, cfg component.Config, facto
 	if oCfg.Resolver.K8sSvc != nil {
 		count++
 	}
+	if oCfg.Resolver.K8sEndpointSlice != nil {
+		count++
+	}
 	if count > 1 {
 		return nil, errMultipleResolversProvided
 	}
@@ -91,6 +111,19 @@ func newLoadBalancer(params exporter.CreateSettings, cfg component.Config, facto
 		}
 	}
 
+	if oCfg.Resolver.K8sEndpointSlice != nil {
+		k8sLogger := params.Logger.With(zap.String("resolver", "k8s endpointslice"))
+
+		clt, err := newInClusterClient()
+		if err != nil {
+			return nil, err
+		}
+		res, err = newK8sEndpointSliceResolver(clt, k8sLogger, *oCfg.Resolver.K8sEndpointSlice)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if oCfg.Resolver.AWSCloudMap != nil {
 		awsCloudMapLogger := params.Logger.With(zap.String("resolver", "awsCloudMap"))
 		var err error
@@ -107,8 +140,12 @@ func newLoadBalancer(params exporter.CreateSettings, cfg component.Config, facto
 	return &loadBalancer{
 		logger:           params.Logger,
 		res:              res,
+		loadFactor:       oCfg.LoadFactor,
+		ejection:         oCfg.Ejection,
+		ejected:          map[string]*time.Timer{},
 		componentFactory: factory,
 		exporters:        map[string]*wrappedExporter{},
+		failures:         map[string]int{},
 	}, nil
 }
 
@@ -119,14 +156,27 @@ func (lb *loadBalancer) Start(ctx context.Context, host component.Host) error {
 }
 
 func (lb *loadBalancer) onBackendChanges(resolved []string) {
-	newRing := newHashRing(resolved)
+	var weights map[string]int
+	if wr, ok := lb.res.(weightedResolver); ok {
+		weights = wr.weights()
+	}
 
-	if !newRing.equal(lb.ring) {
-		lb.updateLock.Lock()
-		defer lb.updateLock.Unlock()
+	lb.updateLock.Lock()
+	defer lb.updateLock.Unlock()
 
-		lb.ring = newRing
+	changed := !slices.Equal(lb.resolved, resolved)
+	lb.resolved = resolved
+	lb.weights = weights
 
+	// the backends that are gone don't need to be added back
+	for endpoint, timer := range lb.ejected {
+		if !endpointFound(endpoint, resolved) {
+			timer.Stop()
+			delete(lb.ejected, endpoint)
+		}
+	}
+
+	if lb.updateRing() || changed {
 		// TODO: set a timeout?
 		ctx := context.Background()
 
@@ -136,6 +186,108 @@ func (lb *loadBalancer) onBackendChanges(resolved []string) {
 	}
 }
 
+// updateRing builds the ring of the resolved endpoints that aren't ejected,
+// returning whether it changed. The caller must hold the update lock.
+func (lb *loadBalancer) updateRing() bool {
+	endpoints := make([]string, 0, len(lb.resolved))
+	for _, endpoint := range lb.resolved {
+		if _, ejected := lb.ejected[endpoint]; !ejected {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	newRing := newWeightedHashRing(endpoints, lb.weights)
+	if lb.loadFactor > 0 {
+		newRing = newRing.withBoundedLoad(lb.loadFactor, lb.weights)
+	}
+	if newRing.equal(lb.ring) {
+		return false
+	}
+
+	// the endpoints that left the ring don't own any part of it anymore
+	ownership := newRing.ownership()
+	for endpoint := range lb.ring.ownership() {
+		if _, found := ownership[endpoint]; !found {
+			ownership[endpoint] = 0
+		}
+	}
+	for endpoint, share := range ownership {
+		_ = stats.RecordWithTags(context.Background(),
+			[]tag.Mutator{tag.Upsert(endpointTagKey, endpoint)},
+			mBackendRingOwnership.M(share))
+	}
+
+	lb.ring = newRing
+	return true
+}
+
+// onExportResult keeps track of the consecutive failed exports of the endpoint, ejecting
+// it from the ring once they reach the error threshold.
+func (lb *loadBalancer) onExportResult(endpoint string, err error) {
+	if lb.ejection.ErrorThreshold <= 0 {
+		return
+	}
+
+	lb.healthLock.Lock()
+	if err == nil {
+		delete(lb.failures, endpoint)
+		lb.healthLock.Unlock()
+		return
+	}
+	lb.failures[endpoint]++
+	eject := lb.failures[endpoint] >= lb.ejection.ErrorThreshold
+	if eject {
+		delete(lb.failures, endpoint)
+	}
+	lb.healthLock.Unlock()
+
+	if eject {
+		lb.eject(endpoint)
+	}
+}
+
+// eject takes the endpoint out of the ring for the ejection duration, so that its share
+// of the ring is rehashed to the other endpoints. Its exporter is kept, so that the data
+// already queued can still be sent.
+func (lb *loadBalancer) eject(endpoint string) {
+	lb.updateLock.Lock()
+	defer lb.updateLock.Unlock()
+
+	if _, ejected := lb.ejected[endpoint]; ejected || lb.stopped || !endpointFound(endpoint, lb.resolved) {
+		return
+	}
+	// the data would have nowhere to go without any endpoint in the ring
+	if len(lb.resolved)-len(lb.ejected) <= 1 {
+		lb.logger.Warn("not ejecting the failing backend, as it is the last one in the ring", zap.String("endpoint", endpoint))
+		return
+	}
+
+	lb.logger.Warn("ejecting the failing backend from the ring",
+		zap.String("endpoint", endpoint),
+		zap.Duration("duration", lb.ejection.Duration))
+	_ = stats.RecordWithTags(context.Background(),
+		[]tag.Mutator{tag.Upsert(endpointTagKey, endpoint)},
+		mBackendEjections.M(1))
+
+	lb.ejected[endpoint] = time.AfterFunc(lb.ejection.Duration, func() {
+		lb.readmit(endpoint)
+	})
+	lb.updateRing()
+}
+
+// readmit adds an ejected endpoint back to the ring
+func (lb *loadBalancer) readmit(endpoint string) {
+	lb.updateLock.Lock()
+	defer lb.updateLock.Unlock()
+
+	if _, ejected := lb.ejected[endpoint]; !ejected {
+		return
+	}
+	delete(lb.ejected, endpoint)
+
+	lb.logger.Info("adding the ejected backend back to the ring", zap.String("endpoint", endpoint))
+	lb.updateRing()
+}
+
 func (lb *loadBalancer) addMissingExporters(ctx context.Context, endpoints []string) {
 	for _, endpoint := range endpoints {
 		endpoint = endpointWithPort(endpoint)
@@ -192,7 +344,14 @@ func endpointFound(endpoint string, endpoints []string) bool {
 
 func (lb *loadBalancer) Shutdown(ctx context.Context) error {
 	err := lb.res.shutdown(ctx)
+
+	lb.updateLock.Lock()
 	lb.stopped = true
+	for endpoint, timer := range lb.ejected {
+		timer.Stop()
+		delete(lb.ejected, endpoint)
+	}
+	lb.updateLock.Unlock()
 	return err
 }
 
@@ -6,7 +6,9 @@ package loadbalancingexporter
 import (
 	"context"
 	"errors"
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -220,6 +222,94 @@ func TestOnBackendChanges(t *testing.T) {
 	assert.Len(t, p.ring.items, 2*defaultWeight)
 }
 
+func TestOnBackendChangesWeighted(t *testing.T) {
+	// prepare
+	cfg := simpleConfig()
+	componentFactory := func(_ context.Context, _ string) (component.Component, error) {
+		return newNopMockExporter(), nil
+	}
+	p, err := newLoadBalancer(exportertest.NewNopCreateSettings(), cfg, componentFactory)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+	p.res = &mockWeightedResolver{endpointWeights: map[string]int{"endpoint-2": 10}}
+
+	// test
+	p.onBackendChanges([]string{"endpoint-1", "endpoint-2"})
+
+	// verify
+	assert.True(t, p.ring.equal(newWeightedHashRing([]string{"endpoint-1", "endpoint-2"}, map[string]int{"endpoint-2": 10})))
+	assert.Len(t, p.exporters, 2)
+}
+
+func TestEjectFailingBackend(t *testing.T) {
+	// prepare
+	cfg := simpleConfig()
+	cfg.Ejection = EjectionSettings{ErrorThreshold: 2, Duration: 100 * time.Millisecond}
+	componentFactory := func(_ context.Context, _ string) (component.Component, error) {
+		return newNopMockExporter(), nil
+	}
+	p, err := newLoadBalancer(exportertest.NewNopCreateSettings(), cfg, componentFactory)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+	p.onBackendChanges([]string{"endpoint-1", "endpoint-2"})
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	ringEndpoints := func() []string {
+		p.updateLock.RLock()
+		defer p.updateLock.RUnlock()
+		var endpoints []string
+		for endpoint := range p.ring.ownership() {
+			endpoints = append(endpoints, endpoint)
+		}
+		sort.Strings(endpoints)
+		return endpoints
+	}
+
+	// test
+	p.onExportResult("endpoint-1", errors.New("failed"))
+	p.onExportResult("endpoint-1", nil)
+	p.onExportResult("endpoint-1", errors.New("failed"))
+	assert.Equal(t, []string{"endpoint-1", "endpoint-2"}, ringEndpoints(), "Must only eject after consecutive failures")
+
+	p.onExportResult("endpoint-1", errors.New("failed"))
+	assert.Equal(t, []string{"endpoint-2"}, ringEndpoints())
+	assert.Len(t, p.exporters, 2, "Must keep the exporter of the ejected backend")
+	for _, id := range [][]byte{{1, 2, 0, 0}, {128, 128, 0, 0}, []byte("ad-service-7")} {
+		_, endpoint, err := p.exporterAndEndpoint(id)
+		require.NoError(t, err)
+		assert.Equal(t, "endpoint-2", endpoint)
+	}
+
+	// the last backend of the ring is never ejected
+	p.onExportResult("endpoint-2", errors.New("failed"))
+	p.onExportResult("endpoint-2", errors.New("failed"))
+	assert.Equal(t, []string{"endpoint-2"}, ringEndpoints())
+
+	// verify
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"endpoint-1", "endpoint-2"}, ringEndpoints())
+	}, time.Second, 10*time.Millisecond, "Must add the backend back after the ejection duration")
+}
+
+func TestNewLoadBalancerMultipleK8sResolvers(t *testing.T) {
+	// prepare
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			K8sSvc:           &K8sSvcResolver{Service: "lb"},
+			K8sEndpointSlice: &K8sEndpointSliceResolver{Service: "lb"},
+		},
+	}
+
+	// test
+	p, err := newLoadBalancer(exportertest.NewNopCreateSettings(), cfg, nil)
+
+	// verify
+	assert.Nil(t, p)
+	assert.Equal(t, errMultipleResolversProvided, err)
+}
+
 func TestRemoveExtraExporters(t *testing.T) {
 	// prepare
 	cfg := simpleConfig()
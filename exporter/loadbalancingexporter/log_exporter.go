@@ -20,12 +20,18 @@ import (
 	"go.uber.org/multierr"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
 )
 
 var _ exporter.Logs = (*logExporterImp)(nil)
 
+type exporterLogs map[*wrappedExporter]plog.Logs
+
 type logExporterImp struct {
 	loadBalancer *loadBalancer
+	// router routes each log record on its own, when the routing key is not the trace ID
+	router *recordRouter[ottllog.TransformContext]
 
 	started    bool
 	shutdownWg sync.WaitGroup
@@ -43,9 +49,25 @@ func newLogsExporter(params exporter.CreateSettings, cfg component.Config) (*log
 		return nil, err
 	}
 
-	return &logExporterImp{
-		loadBalancer: lb,
-	}, nil
+	logExporter := logExporterImp{loadBalancer: lb}
+
+	switch cfg.(*Config).RoutingKey {
+	// the built-in routing keys of the other signals are kept routing the logs by trace ID,
+	// as the configuration might be shared by the exporters of all the signals
+	case "traceID", "service", "resource", "metric", "":
+		if len(cfg.(*Config).RoutingAttributes) == 0 {
+			break
+		}
+		fallthrough
+	default:
+		logExporter.router, err = newRecordRouter(cfg.(*Config), func(functions map[string]ottl.Factory[ottllog.TransformContext]) (ottl.Parser[ottllog.TransformContext], error) {
+			return ottllog.NewParser(functions, params.TelemetrySettings)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &logExporter, nil
 }
 
 func (e *logExporterImp) Capabilities() consumer.Capabilities {
@@ -68,6 +90,10 @@ func (e *logExporterImp) Shutdown(ctx context.Context) error {
 }
 
 func (e *logExporterImp) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	if e.router != nil {
+		return e.consumeLogsByRecord(ctx, ld)
+	}
+
 	var errs error
 	batches := batchpersignal.SplitLogs(ld)
 	for _, batch := range batches {
@@ -98,6 +124,7 @@ func (e *logExporterImp) consumeLog(ctx context.Context, ld plog.Logs) error {
 	start := time.Now()
 	err = le.ConsumeLogs(ctx, ld)
 	duration := time.Since(start)
+	e.loadBalancer.onExportResult(endpoint, err)
 	if err == nil {
 		_ = stats.RecordWithTags(
 			ctx,
@@ -113,6 +140,84 @@ func (e *logExporterImp) consumeLog(ctx context.Context, ld plog.Logs) error {
 	return err
 }
 
+// consumeLogsByRecord sends each log record to the backend of its routing key. The records
+// without a value for the routing key are routed by their trace ID, or all together to a
+// random backend when they have no trace ID either.
+func (e *logExporterImp) consumeLogsByRecord(ctx context.Context, ld plog.Logs) error {
+	batches := newRecordBatches[plog.Logs, plog.ResourceLogs, plog.ScopeLogs](plog.NewLogs)
+	randomKey := random()
+
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		batches.nextResource(func(logs plog.Logs) plog.ResourceLogs {
+			dest := logs.ResourceLogs().AppendEmpty()
+			rl.Resource().CopyTo(dest.Resource())
+			dest.SetSchemaUrl(rl.SchemaUrl())
+			return dest
+		})
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			batches.nextScope(func(rlDest plog.ResourceLogs) plog.ScopeLogs {
+				dest := rlDest.ScopeLogs().AppendEmpty()
+				sl.Scope().CopyTo(dest.Scope())
+				dest.SetSchemaUrl(sl.SchemaUrl())
+				return dest
+			})
+			logs := sl.LogRecords()
+			for k := 0; k < logs.Len(); k++ {
+				log := logs.At(k)
+				key, err := e.router.key(ctx, ottllog.NewTransformContext(log, sl.Scope(), rl.Resource()),
+					log.Attributes(), sl.Scope().Attributes(), rl.Resource().Attributes())
+				if err != nil {
+					batches.release()
+					return err
+				}
+				if key == "" {
+					tid := log.TraceID()
+					if tid.IsEmpty() {
+						tid = randomKey
+					}
+					key = string(tid[:])
+				}
+
+				exp, endpoint, err := e.loadBalancer.exporterAndEndpoint([]byte(key))
+				if err != nil {
+					batches.release()
+					return err
+				}
+				log.CopyTo(batches.scope(exp, endpoint).LogRecords().AppendEmpty())
+			}
+		}
+	}
+
+	var errs error
+	endpoints := batches.endpoints
+	for exp, ld := range batches.batches {
+		start := time.Now()
+		err := exp.ConsumeLogs(ctx, ld)
+		exp.consumeWG.Done()
+		duration := time.Since(start)
+		e.loadBalancer.onExportResult(endpoints[exp], err)
+		errs = multierr.Append(errs, err)
+
+		if err == nil {
+			_ = stats.RecordWithTags(
+				ctx,
+				[]tag.Mutator{tag.Upsert(endpointTagKey, endpoints[exp]), successTrueMutator},
+				mBackendLatency.M(duration.Milliseconds()))
+		} else {
+			_ = stats.RecordWithTags(
+				ctx,
+				[]tag.Mutator{tag.Upsert(endpointTagKey, endpoints[exp]), successFalseMutator},
+				mBackendLatency.M(duration.Milliseconds()))
+		}
+	}
+
+	return errs
+}
+
 func traceIDFromLogs(ld plog.Logs) pcommon.TraceID {
 	rl := ld.ResourceLogs()
 	if rl.Len() == 0 {
@@ -288,6 +288,73 @@ func TestLogsWithoutTraceID(t *testing.T) {
 }
 
 // this test validates that exporter is can concurrently change the endpoints while consuming logs.
+func TestConsumeLogsRecordBased(t *testing.T) {
+	for name, cfg := range recordBasedRoutingConfigs() {
+		t.Run(name, func(t *testing.T) {
+			var mu sync.Mutex
+			tenantEndpoints := map[string]map[string]bool{}
+			records := 0
+			componentFactory := func(_ context.Context, endpoint string) (component.Component, error) {
+				return newMockLogsExporter(func(_ context.Context, ld plog.Logs) error {
+					mu.Lock()
+					defer mu.Unlock()
+					sl := ld.ResourceLogs().At(0).ScopeLogs().At(0)
+					assert.Equal(t, "scope-1", sl.Scope().Name())
+					for i := 0; i < sl.LogRecords().Len(); i++ {
+						tenant, _ := sl.LogRecords().At(i).Attributes().Get("tenant.id")
+						if tenantEndpoints[tenant.Str()] == nil {
+							tenantEndpoints[tenant.Str()] = map[string]bool{}
+						}
+						tenantEndpoints[tenant.Str()][endpoint] = true
+						records++
+					}
+					return nil
+				}), nil
+			}
+			lb, err := newLoadBalancer(exportertest.NewNopCreateSettings(), cfg, componentFactory)
+			require.NoError(t, err)
+			lb.res = &mockResolver{
+				triggerCallbacks: true,
+				onResolve: func(_ context.Context) ([]string, error) {
+					return []string{"endpoint-1", "endpoint-2", "endpoint-3", "endpoint-4"}, nil
+				},
+			}
+
+			p, err := newLogsExporter(exportertest.NewNopCreateSettings(), cfg)
+			require.NoError(t, err)
+			require.NotNil(t, p.router)
+			p.loadBalancer = lb
+
+			require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() {
+				require.NoError(t, p.Shutdown(context.Background()))
+			}()
+
+			ld := plog.NewLogs()
+			sl := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+			sl.Scope().SetName("scope-1")
+			for i := 0; i < 100; i++ {
+				sl.LogRecords().AppendEmpty().Attributes().PutStr("tenant.id", fmt.Sprintf("tenant-%d", i%10))
+			}
+
+			// test
+			require.NoError(t, p.ConsumeLogs(context.Background(), ld))
+
+			// verify
+			endpoints := map[string]bool{}
+			for tenant, tenantEndpoint := range tenantEndpoints {
+				assert.Len(t, tenantEndpoint, 1, "the logs of %s were sent to more than one backend", tenant)
+				for endpoint := range tenantEndpoint {
+					endpoints[endpoint] = true
+				}
+			}
+			assert.Len(t, tenantEndpoints, 10)
+			assert.Greater(t, len(endpoints), 1)
+			assert.Equal(t, 100, records)
+		})
+	}
+}
+
 func TestConsumeLogs_ConcurrentResolverChange(t *testing.T) {
 	consumeStarted := make(chan struct{})
 	consumeDone := make(chan struct{})
@@ -14,6 +14,9 @@ var (
 	mNumBackends    = stats.Int64("loadbalancer_num_backends", "Current number of backends in use", stats.UnitDimensionless)
 	mBackendLatency = stats.Int64("loadbalancer_backend_latency", "Response latency in ms for the backends", stats.UnitMilliseconds)
 
+	mBackendRingOwnership = stats.Float64("loadbalancer_backend_ring_ownership", "Share of the hash ring owned by the backends", stats.UnitDimensionless)
+	mBackendEjections     = stats.Int64("loadbalancer_backend_ejections", "Number of times the backends were ejected from the hash ring", stats.UnitDimensionless)
+
 	endpointTagKey      = tag.MustNewKey("endpoint")
 	successTrueMutator  = tag.Upsert(tag.MustNewKey("success"), "true")
 	successFalseMutator = tag.Upsert(tag.MustNewKey("success"), "false")
@@ -69,5 +72,23 @@ func metricViews() []*view.View {
 			},
 			Aggregation: view.Count(),
 		},
+		{
+			Name:        mBackendRingOwnership.Name(),
+			Measure:     mBackendRingOwnership,
+			Description: mBackendRingOwnership.Description(),
+			TagKeys: []tag.Key{
+				tag.MustNewKey("endpoint"),
+			},
+			Aggregation: view.LastValue(),
+		},
+		{
+			Name:        mBackendEjections.Name(),
+			Measure:     mBackendEjections,
+			Description: mBackendEjections.Description(),
+			TagKeys: []tag.Key{
+				tag.MustNewKey("endpoint"),
+			},
+			Aggregation: view.Count(),
+		},
 	}
 }
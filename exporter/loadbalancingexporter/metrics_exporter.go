@@ -6,7 +6,6 @@ package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry
 import (
 	"context"
 	"errors"
-	"fmt"
 	"sort"
 	"strings"
 	"sync"
@@ -24,6 +23,8 @@ import (
 	"go.uber.org/multierr"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
 )
 
 var _ exporter.Metrics = (*metricExporterImp)(nil)
@@ -33,6 +34,8 @@ type exporterMetrics map[*wrappedExporter]pmetric.Metrics
 type metricExporterImp struct {
 	loadBalancer *loadBalancer
 	routingKey   routingKey
+	// router routes each data point on its own, when the routing key is not one of the built-in keys
+	router *recordRouter[ottldatapoint.TransformContext]
 
 	stopped    bool
 	shutdownWg sync.WaitGroup
@@ -52,15 +55,24 @@ func newMetricsExporter(params exporter.CreateSettings, cfg component.Config) (*
 	metricExporter := metricExporterImp{loadBalancer: lb, routingKey: svcRouting}
 
 	switch cfg.(*Config).RoutingKey {
-	case "service", "":
-		// default case for empty routing key
-		metricExporter.routingKey = svcRouting
 	case "resource":
 		metricExporter.routingKey = resourceRouting
 	case "metric":
 		metricExporter.routingKey = metricNameRouting
+	case "service", "":
+		// default case for empty routing key
+		if len(cfg.(*Config).RoutingAttributes) == 0 {
+			metricExporter.routingKey = svcRouting
+			break
+		}
+		fallthrough
 	default:
-		return nil, fmt.Errorf("unsupported routing_key: %q", cfg.(*Config).RoutingKey)
+		metricExporter.router, err = newRecordRouter(cfg.(*Config), func(functions map[string]ottl.Factory[ottldatapoint.TransformContext]) (ottl.Parser[ottldatapoint.TransformContext], error) {
+			return ottldatapoint.NewParser(functions, params.TelemetrySettings)
+		})
+		if err != nil {
+			return nil, err
+		}
 	}
 	return &metricExporter, nil
 
@@ -82,6 +94,10 @@ func (e *metricExporterImp) Shutdown(ctx context.Context) error {
 }
 
 func (e *metricExporterImp) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if e.router != nil {
+		return e.consumeMetricsByRecord(ctx, md)
+	}
+
 	batches := batchpersignal.SplitMetrics(md)
 
 	exporterSegregatedMetrics := make(exporterMetrics)
@@ -110,6 +126,124 @@ func (e *metricExporterImp) ConsumeMetrics(ctx context.Context, md pmetric.Metri
 		}
 	}
 
+	return e.exportMetrics(ctx, exporterSegregatedMetrics, endpoints)
+}
+
+// consumeMetricsByRecord sends each data point to the backend of its routing key, falling
+// back to the resource routing key when the data point has no value for the routing key.
+func (e *metricExporterImp) consumeMetricsByRecord(ctx context.Context, md pmetric.Metrics) error {
+	batches := newRecordBatches[pmetric.Metrics, pmetric.ResourceMetrics, pmetric.ScopeMetrics](pmetric.NewMetrics)
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		batches.nextResource(func(metrics pmetric.Metrics) pmetric.ResourceMetrics {
+			dest := metrics.ResourceMetrics().AppendEmpty()
+			rm.Resource().CopyTo(dest.Resource())
+			dest.SetSchemaUrl(rm.SchemaUrl())
+			return dest
+		})
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			batches.nextScope(func(rmDest pmetric.ResourceMetrics) pmetric.ScopeMetrics {
+				dest := rmDest.ScopeMetrics().AppendEmpty()
+				sm.Scope().CopyTo(dest.Scope())
+				dest.SetSchemaUrl(sm.SchemaUrl())
+				return dest
+			})
+			metrics := sm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				dests := make(map[*wrappedExporter]pmetric.Metric)
+
+				// destination returns the copy of the metric for the backend of the data point
+				destination := func(dp any, attrs pcommon.Map) (pmetric.Metric, error) {
+					key, err := e.router.key(ctx, ottldatapoint.NewTransformContext(dp, metric, metrics, sm.Scope(), rm.Resource()),
+						attrs, sm.Scope().Attributes(), rm.Resource().Attributes())
+					if err != nil {
+						return pmetric.Metric{}, err
+					}
+					if key == "" {
+						key = resourceRoutingKey(metric, rm.Resource().Attributes())
+					}
+
+					exp, endpoint, err := e.loadBalancer.exporterAndEndpoint([]byte(key))
+					if err != nil {
+						return pmetric.Metric{}, err
+					}
+
+					dest, ok := dests[exp]
+					if ok {
+						return dest, nil
+					}
+					dest = batches.scope(exp, endpoint).Metrics().AppendEmpty()
+					copyMetricDescriptor(metric, dest)
+					dests[exp] = dest
+					return dest, nil
+				}
+
+				switch metric.Type() {
+				case pmetric.MetricTypeGauge:
+					dps := metric.Gauge().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dest, err := destination(dps.At(l), dps.At(l).Attributes())
+						if err != nil {
+							batches.release()
+							return err
+						}
+						dps.At(l).CopyTo(dest.Gauge().DataPoints().AppendEmpty())
+					}
+				case pmetric.MetricTypeSum:
+					dps := metric.Sum().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dest, err := destination(dps.At(l), dps.At(l).Attributes())
+						if err != nil {
+							batches.release()
+							return err
+						}
+						dps.At(l).CopyTo(dest.Sum().DataPoints().AppendEmpty())
+					}
+				case pmetric.MetricTypeHistogram:
+					dps := metric.Histogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dest, err := destination(dps.At(l), dps.At(l).Attributes())
+						if err != nil {
+							batches.release()
+							return err
+						}
+						dps.At(l).CopyTo(dest.Histogram().DataPoints().AppendEmpty())
+					}
+				case pmetric.MetricTypeExponentialHistogram:
+					dps := metric.ExponentialHistogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dest, err := destination(dps.At(l), dps.At(l).Attributes())
+						if err != nil {
+							batches.release()
+							return err
+						}
+						dps.At(l).CopyTo(dest.ExponentialHistogram().DataPoints().AppendEmpty())
+					}
+				case pmetric.MetricTypeSummary:
+					dps := metric.Summary().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dest, err := destination(dps.At(l), dps.At(l).Attributes())
+						if err != nil {
+							batches.release()
+							return err
+						}
+						dps.At(l).CopyTo(dest.Summary().DataPoints().AppendEmpty())
+					}
+				}
+			}
+		}
+	}
+
+	return e.exportMetrics(ctx, batches.batches, batches.endpoints)
+}
+
+// exportMetrics sends the metrics to their exporters, which must have been added to their consumeWG
+func (e *metricExporterImp) exportMetrics(ctx context.Context, exporterSegregatedMetrics exporterMetrics, endpoints map[*wrappedExporter]string) error {
 	var errs error
 
 	for exp, metrics := range exporterSegregatedMetrics {
@@ -117,6 +251,7 @@ func (e *metricExporterImp) ConsumeMetrics(ctx context.Context, md pmetric.Metri
 		err := exp.ConsumeMetrics(ctx, metrics)
 		exp.consumeWG.Done()
 		duration := time.Since(start)
+		e.loadBalancer.onExportResult(endpoints[exp], err)
 		errs = multierr.Append(errs, err)
 
 		if err == nil {
@@ -222,3 +357,24 @@ func resourceRoutingKey(md pmetric.Metric, attrs pcommon.Map) string {
 func metricRoutingKey(md pmetric.Metric) string {
 	return md.Name()
 }
+
+// copyMetricDescriptor copies everything from the metric but its data points
+func copyMetricDescriptor(src pmetric.Metric, dest pmetric.Metric) {
+	dest.SetName(src.Name())
+	dest.SetDescription(src.Description())
+	dest.SetUnit(src.Unit())
+
+	switch src.Type() {
+	case pmetric.MetricTypeGauge:
+		dest.SetEmptyGauge()
+	case pmetric.MetricTypeSum:
+		dest.SetEmptySum().SetAggregationTemporality(src.Sum().AggregationTemporality())
+		dest.Sum().SetIsMonotonic(src.Sum().IsMonotonic())
+	case pmetric.MetricTypeHistogram:
+		dest.SetEmptyHistogram().SetAggregationTemporality(src.Histogram().AggregationTemporality())
+	case pmetric.MetricTypeExponentialHistogram:
+		dest.SetEmptyExponentialHistogram().SetAggregationTemporality(src.ExponentialHistogram().AggregationTemporality())
+	case pmetric.MetricTypeSummary:
+		dest.SetEmptySummary()
+	}
+}
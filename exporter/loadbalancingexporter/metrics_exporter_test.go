@@ -359,6 +359,82 @@ func TestConsumeMetricsMetricNameBased(t *testing.T) {
 	assert.Nil(t, res)
 }
 
+func TestConsumeMetricsRecordBased(t *testing.T) {
+	for name, cfg := range recordBasedRoutingConfigs() {
+		t.Run(name, func(t *testing.T) {
+			var mu sync.Mutex
+			tenantEndpoints := map[string]map[string]bool{}
+			dataPoints := 0
+			componentFactory := func(_ context.Context, endpoint string) (component.Component, error) {
+				return newMockMetricsExporter(func(_ context.Context, md pmetric.Metrics) error {
+					mu.Lock()
+					defer mu.Unlock()
+					metric := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+					assert.Equal(t, signal1Name, metric.Name())
+					assert.Equal(t, "By", metric.Unit())
+					assert.True(t, metric.Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, metric.Sum().AggregationTemporality())
+					for i := 0; i < metric.Sum().DataPoints().Len(); i++ {
+						tenant, _ := metric.Sum().DataPoints().At(i).Attributes().Get("tenant.id")
+						if tenantEndpoints[tenant.Str()] == nil {
+							tenantEndpoints[tenant.Str()] = map[string]bool{}
+						}
+						tenantEndpoints[tenant.Str()][endpoint] = true
+						dataPoints++
+					}
+					return nil
+				}), nil
+			}
+			lb, err := newLoadBalancer(exportertest.NewNopCreateSettings(), cfg, componentFactory)
+			require.NoError(t, err)
+			lb.res = &mockResolver{
+				triggerCallbacks: true,
+				onResolve: func(_ context.Context) ([]string, error) {
+					return []string{"endpoint-1", "endpoint-2", "endpoint-3", "endpoint-4"}, nil
+				},
+			}
+
+			p, err := newMetricsExporter(exportertest.NewNopCreateSettings(), cfg)
+			require.NoError(t, err)
+			require.NotNil(t, p.router)
+			p.loadBalancer = lb
+
+			require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() {
+				require.NoError(t, p.Shutdown(context.Background()))
+			}()
+
+			md := pmetric.NewMetrics()
+			metric := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+			metric.SetName(signal1Name)
+			metric.SetUnit("By")
+			sum := metric.SetEmptySum()
+			sum.SetIsMonotonic(true)
+			sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+			for i := 0; i < 100; i++ {
+				dp := sum.DataPoints().AppendEmpty()
+				dp.SetIntValue(int64(i))
+				dp.Attributes().PutStr("tenant.id", fmt.Sprintf("tenant-%d", i%10))
+			}
+
+			// test
+			require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+
+			// verify
+			endpoints := map[string]bool{}
+			for tenant, tenantEndpoint := range tenantEndpoints {
+				assert.Len(t, tenantEndpoint, 1, "the data points of %s were sent to more than one backend", tenant)
+				for endpoint := range tenantEndpoint {
+					endpoints[endpoint] = true
+				}
+			}
+			assert.Len(t, tenantEndpoints, 10)
+			assert.Greater(t, len(endpoints), 1)
+			assert.Equal(t, 100, dataPoints)
+		})
+	}
+}
+
 func TestServiceBasedRoutingForSameMetricName(t *testing.T) {
 
 	for _, tt := range []struct {
@@ -15,6 +15,9 @@ func TestProcessorMetrics(t *testing.T) {
 		"loadbalancer_num_backends",
 		"loadbalancer_num_backend_updates",
 		"loadbalancer_backend_latency",
+		"loadbalancer_backend_outcome",
+		"loadbalancer_backend_ring_ownership",
+		"loadbalancer_backend_ejections",
 	}
 
 	views := metricViews()
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+// recordBatches regroups the records routed one by one into a batch B per exporter,
+// under copies of their resource R and of their scope S.
+type recordBatches[B, R, S any] struct {
+	batches   map[*wrappedExporter]B
+	endpoints map[*wrappedExporter]string
+
+	newBatch       func() B
+	appendResource func(B) R
+	appendScope    func(R) S
+
+	// the copies of the current resource and scope in the batch of each exporter
+	resources map[*wrappedExporter]R
+	scopes    map[*wrappedExporter]S
+}
+
+func newRecordBatches[B, R, S any](newBatch func() B) *recordBatches[B, R, S] {
+	return &recordBatches[B, R, S]{
+		batches:   make(map[*wrappedExporter]B),
+		endpoints: make(map[*wrappedExporter]string),
+		newBatch:  newBatch,
+	}
+}
+
+// nextResource starts the records of a resource, which appendResource copies into a batch.
+func (b *recordBatches[B, R, S]) nextResource(appendResource func(B) R) {
+	b.appendResource = appendResource
+	b.resources = make(map[*wrappedExporter]R)
+}
+
+// nextScope starts the records of a scope, which appendScope copies into a resource.
+func (b *recordBatches[B, R, S]) nextScope(appendScope func(R) S) {
+	b.appendScope = appendScope
+	b.scopes = make(map[*wrappedExporter]S)
+}
+
+// scope returns the copy of the current scope in the batch of the exporter. The exporter is
+// added to its consumeWG with its first batch, so that it isn't shut down before the batch is
+// exported. The batches must then be either exported or released.
+func (b *recordBatches[B, R, S]) scope(exp *wrappedExporter, endpoint string) S {
+	if dest, ok := b.scopes[exp]; ok {
+		return dest
+	}
+	resource, ok := b.resources[exp]
+	if !ok {
+		batch, ok := b.batches[exp]
+		if !ok {
+			exp.consumeWG.Add(1)
+			batch = b.newBatch()
+			b.batches[exp] = batch
+			b.endpoints[exp] = endpoint
+		}
+		resource = b.appendResource(batch)
+		b.resources[exp] = resource
+	}
+	dest := b.appendScope(resource)
+	b.scopes[exp] = dest
+	return dest
+}
+
+// release releases the exporters of batches which won't be exported.
+func (b *recordBatches[B, R, S]) release() {
+	for exp := range b.batches {
+		exp.consumeWG.Done()
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newSpanBatches(rs ptrace.ResourceSpans, ss ptrace.ScopeSpans) *recordBatches[ptrace.Traces, ptrace.ResourceSpans, ptrace.ScopeSpans] {
+	batches := newRecordBatches[ptrace.Traces, ptrace.ResourceSpans, ptrace.ScopeSpans](ptrace.NewTraces)
+	batches.nextResource(func(traces ptrace.Traces) ptrace.ResourceSpans {
+		dest := traces.ResourceSpans().AppendEmpty()
+		rs.Resource().CopyTo(dest.Resource())
+		return dest
+	})
+	batches.nextScope(func(rsDest ptrace.ResourceSpans) ptrace.ScopeSpans {
+		dest := rsDest.ScopeSpans().AppendEmpty()
+		ss.Scope().CopyTo(dest.Scope())
+		return dest
+	})
+	return batches
+}
+
+func TestRecordBatches(t *testing.T) {
+	rs := ptrace.NewResourceSpans()
+	rs.Resource().Attributes().PutStr("service.name", "svc")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("scope")
+
+	exp := newWrappedExporter(newNopMockTracesExporter())
+	batches := newSpanBatches(rs, ss)
+
+	// the records of the same scope share the copies of their resource and scope
+	batches.scope(exp, "endpoint-1").Spans().AppendEmpty().SetName("first")
+	batches.scope(exp, "endpoint-1").Spans().AppendEmpty().SetName("second")
+
+	require.Len(t, batches.batches, 1)
+	assert.Equal(t, "endpoint-1", batches.endpoints[exp])
+	rsDest := batches.batches[exp].ResourceSpans()
+	require.Equal(t, 1, rsDest.Len())
+	assert.Equal(t, map[string]any{"service.name": "svc"}, rsDest.At(0).Resource().Attributes().AsRaw())
+	require.Equal(t, 1, rsDest.At(0).ScopeSpans().Len())
+	assert.Equal(t, "scope", rsDest.At(0).ScopeSpans().At(0).Scope().Name())
+	assert.Equal(t, 2, rsDest.At(0).ScopeSpans().At(0).Spans().Len())
+
+	// the exporter waits for its batch from the routing of its first record
+	done := make(chan struct{})
+	go func() {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+		close(done)
+	}()
+	select {
+	case <-done:
+		require.FailNow(t, "the exporter was shut down before its batch was released")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	batches.release()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.FailNow(t, "the exporter was not shut down after its batch was released")
+	}
+}
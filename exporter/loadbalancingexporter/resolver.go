@@ -23,3 +23,11 @@ type resolver interface {
 	// Make sure to register the callbacks before starting the exporter.
 	onChange(func([]string))
 }
+
+// weightedResolver is implemented by the resolvers that give some of their endpoints
+// more points in the hash ring than others.
+type weightedResolver interface {
+	// weights returns the number of points in the ring for the endpoints that
+	// don't have the default weight, matching the last list of endpoints.
+	weights() map[string]int
+}
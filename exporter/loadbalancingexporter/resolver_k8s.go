@@ -77,19 +77,7 @@ func newK8sResolver(clt kubernetes.Interface,
 		timeout = defaultListWatchTimeout
 	}
 
-	nAddr := strings.SplitN(service, ".", 2)
-	name, namespace := nAddr[0], "default"
-	if len(nAddr) > 1 {
-		namespace = nAddr[1]
-	} else {
-		logger.Info("the namespace for the Kubernetes service wasn't provided, trying to determine the current namespace", zap.String("name", name))
-		if ns, err := getInClusterNamespace(); err == nil {
-			namespace = ns
-			logger.Info("namespace for the Collector determined", zap.String("namespace", namespace))
-		} else {
-			logger.Warn(`could not determine the namespace for this collector, will use "default" as the namespace`, zap.Error(err))
-		}
-	}
+	name, namespace := splitServiceName(logger, service)
 
 	epsSelector := fmt.Sprintf("metadata.name=%s", name)
 	epsListWatcher := &cache.ListWatch{
@@ -219,6 +207,25 @@ func (r *k8sResolver) Endpoints() []string {
 	return r.endpoints
 }
 
+// splitServiceName returns the name and the namespace of a service given as
+// `name.namespace`, determining the namespace of the collector when it is missing.
+func splitServiceName(logger *zap.Logger, service string) (string, string) {
+	nAddr := strings.SplitN(service, ".", 2)
+	name, namespace := nAddr[0], "default"
+	if len(nAddr) > 1 {
+		namespace = nAddr[1]
+	} else {
+		logger.Info("the namespace for the Kubernetes service wasn't provided, trying to determine the current namespace", zap.String("name", name))
+		if ns, err := getInClusterNamespace(); err == nil {
+			namespace = ns
+			logger.Info("namespace for the Collector determined", zap.String("namespace", namespace))
+		} else {
+			logger.Warn(`could not determine the namespace for this collector, will use "default" as the namespace`, zap.Error(err))
+		}
+	}
+	return name, namespace
+}
+
 const inClusterNamespacePath = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
 
 func getInClusterNamespace() (string, error) {
@@ -0,0 +1,240 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+	"k8s.io/utils/strings/slices"
+)
+
+var _ resolver = (*k8sEndpointSliceResolver)(nil)
+var _ weightedResolver = (*k8sEndpointSliceResolver)(nil)
+
+var (
+	errInvalidCrossZoneWeight = fmt.Errorf("the cross zone weight must be between 1 and %d", defaultWeight)
+
+	k8sEndpointSliceResolverMutator              = tag.Upsert(tag.MustNewKey("resolver"), "k8s_endpointslice")
+	k8sEndpointSliceResolverSuccessTrueMutators  = []tag.Mutator{k8sEndpointSliceResolverMutator, successTrueMutator}
+	k8sEndpointSliceResolverSuccessFalseMutators = []tag.Mutator{k8sEndpointSliceResolverMutator, successFalseMutator}
+)
+
+const defaultCrossZoneWeight = 10
+
+type k8sEndpointSliceResolver struct {
+	logger  *zap.Logger
+	svcName string
+	svcNs   string
+	port    []int32
+
+	zone            string
+	crossZoneWeight int
+
+	handler           *endpointSliceHandler
+	once              *sync.Once
+	slicesListWatcher cache.ListerWatcher
+
+	lwTimeout time.Duration
+
+	endpoints         []string
+	endpointWeights   map[string]int
+	onChangeCallbacks []func([]string)
+
+	stopCh             chan struct{}
+	updateLock         sync.RWMutex
+	shutdownWg         sync.WaitGroup
+	changeCallbackLock sync.RWMutex
+}
+
+func newK8sEndpointSliceResolver(clt kubernetes.Interface, logger *zap.Logger, cfg K8sEndpointSliceResolver) (*k8sEndpointSliceResolver, error) {
+	if len(cfg.Service) == 0 {
+		return nil, errNoSvc
+	}
+
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = defaultListWatchTimeout
+	}
+
+	crossZoneWeight := cfg.CrossZoneWeight
+	if crossZoneWeight == 0 {
+		crossZoneWeight = defaultCrossZoneWeight
+	}
+	if crossZoneWeight < 1 || crossZoneWeight > defaultWeight {
+		return nil, errInvalidCrossZoneWeight
+	}
+
+	name, namespace := splitServiceName(logger, cfg.Service)
+
+	// the endpoint slices of a service are labeled with its name
+	slicesSelector := fmt.Sprintf("%s=%s", discoveryv1.LabelServiceName, name)
+	slicesListWatcher := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.LabelSelector = slicesSelector
+			options.TimeoutSeconds = ptr.To[int64](int64(timeout.Seconds()))
+			return clt.DiscoveryV1().EndpointSlices(namespace).List(context.Background(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.LabelSelector = slicesSelector
+			options.TimeoutSeconds = ptr.To[int64](int64(timeout.Seconds()))
+			return clt.DiscoveryV1().EndpointSlices(namespace).Watch(context.Background(), options)
+		},
+	}
+
+	h := &endpointSliceHandler{slices: map[string][]sliceEndpoint{}, logger: logger}
+	r := &k8sEndpointSliceResolver{
+		logger:            logger,
+		svcName:           name,
+		svcNs:             namespace,
+		port:              cfg.Ports,
+		zone:              cfg.Zone,
+		crossZoneWeight:   crossZoneWeight,
+		once:              &sync.Once{},
+		slicesListWatcher: slicesListWatcher,
+		handler:           h,
+		stopCh:            make(chan struct{}),
+		lwTimeout:         timeout,
+	}
+	h.callback = r.resolve
+
+	return r, nil
+}
+
+func (r *k8sEndpointSliceResolver) start(_ context.Context) error {
+	var initErr error
+	r.once.Do(func() {
+		if r.slicesListWatcher != nil {
+			r.logger.Debug("creating and starting endpoint slices informer")
+			slicesInformer := cache.NewSharedInformer(r.slicesListWatcher, &discoveryv1.EndpointSlice{}, 0)
+			if _, err := slicesInformer.AddEventHandler(r.handler); err != nil {
+				r.logger.Error("unable to start watching for changes to the specified service names", zap.Error(err))
+			}
+			go slicesInformer.Run(r.stopCh)
+			if !cache.WaitForCacheSync(r.stopCh, slicesInformer.HasSynced) {
+				initErr = errors.New("endpoint slices informer not sync")
+			}
+		}
+	})
+	if initErr != nil {
+		return initErr
+	}
+
+	r.logger.Debug("K8s endpoint slices resolver started",
+		zap.String("service", r.svcName),
+		zap.String("namespace", r.svcNs),
+		zap.Int32s("ports", r.port),
+		zap.String("zone", r.zone),
+		zap.Duration("timeout", r.lwTimeout))
+	return nil
+}
+
+func (r *k8sEndpointSliceResolver) shutdown(_ context.Context) error {
+	r.changeCallbackLock.Lock()
+	r.onChangeCallbacks = nil
+	r.changeCallbackLock.Unlock()
+
+	close(r.stopCh)
+	r.shutdownWg.Wait()
+	return nil
+}
+
+func (r *k8sEndpointSliceResolver) resolve(ctx context.Context) ([]string, error) {
+	r.shutdownWg.Add(1)
+	defer r.shutdownWg.Done()
+
+	// the same endpoint might be part of more than one slice while they are being updated
+	zones := map[string]string{}
+	for _, ep := range r.handler.endpoints() {
+		zones[ep.address] = ep.zone
+	}
+
+	var backends []string
+	backendZones := map[string]string{}
+	sameZone := false
+	for addr, zone := range zones {
+		if r.zone != "" && zone == r.zone {
+			sameZone = true
+		}
+		if len(r.port) == 0 {
+			backends = append(backends, addr)
+			backendZones[addr] = zone
+			continue
+		}
+		for _, port := range r.port {
+			backend := net.JoinHostPort(addr, strconv.FormatInt(int64(port), 10))
+			backends = append(backends, backend)
+			backendZones[backend] = zone
+		}
+	}
+	_ = stats.RecordWithTags(ctx, k8sEndpointSliceResolverSuccessTrueMutators, mNumResolutions.M(1))
+
+	// keep it always in the same order
+	sort.Strings(backends)
+
+	// the backends in other zones only get less weight when there are backends in the
+	// same zone, as otherwise all the backends would have the same, reduced, weight
+	weights := map[string]int{}
+	if sameZone {
+		for backend, zone := range backendZones {
+			if zone != r.zone {
+				weights[backend] = r.crossZoneWeight
+			}
+		}
+	}
+
+	if slices.Equal(r.Endpoints(), backends) && maps.Equal(r.weights(), weights) {
+		return r.Endpoints(), nil
+	}
+
+	// the list has changed!
+	r.updateLock.Lock()
+	r.endpoints = backends
+	r.endpointWeights = weights
+	r.updateLock.Unlock()
+	_ = stats.RecordWithTags(ctx, k8sEndpointSliceResolverSuccessTrueMutators, mNumBackends.M(int64(len(backends))))
+
+	// propagate the change
+	r.changeCallbackLock.RLock()
+	for _, callback := range r.onChangeCallbacks {
+		callback(r.Endpoints())
+	}
+	r.changeCallbackLock.RUnlock()
+	return r.Endpoints(), nil
+}
+
+func (r *k8sEndpointSliceResolver) onChange(f func([]string)) {
+	r.changeCallbackLock.Lock()
+	defer r.changeCallbackLock.Unlock()
+	r.onChangeCallbacks = append(r.onChangeCallbacks, f)
+}
+
+func (r *k8sEndpointSliceResolver) Endpoints() []string {
+	r.updateLock.RLock()
+	defer r.updateLock.RUnlock()
+	return r.endpoints
+}
+
+func (r *k8sEndpointSliceResolver) weights() map[string]int {
+	r.updateLock.RLock()
+	defer r.updateLock.RUnlock()
+	return r.endpointWeights
+}
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"go.opencensus.io/stats"
+	"go.uber.org/zap"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+var _ cache.ResourceEventHandler = (*endpointSliceHandler)(nil)
+
+// sliceEndpoint is an address of an endpoint slice, with the zone of its endpoint
+type sliceEndpoint struct {
+	address string
+	zone    string
+}
+
+type endpointSliceHandler struct {
+	mu       sync.Mutex
+	slices   map[string][]sliceEndpoint // the ready endpoints, by name of their slice
+	callback func(ctx context.Context) ([]string, error)
+	logger   *zap.Logger
+}
+
+func (h *endpointSliceHandler) OnAdd(obj any, _ bool) {
+	slice, ok := obj.(*discoveryv1.EndpointSlice)
+	if !ok {
+		h.logger.Warn("Got an unexpected Kubernetes data type during the inclusion of a new endpoint slice for the service", zap.Any("obj", obj))
+		_ = stats.RecordWithTags(context.Background(), k8sEndpointSliceResolverSuccessFalseMutators, mNumResolutions.M(1))
+		return
+	}
+	h.update(slice)
+}
+
+func (h *endpointSliceHandler) OnUpdate(_, newObj any) {
+	slice, ok := newObj.(*discoveryv1.EndpointSlice)
+	if !ok {
+		h.logger.Warn("Got an unexpected Kubernetes data type during the update of an endpoint slice for the service", zap.Any("obj", newObj))
+		_ = stats.RecordWithTags(context.Background(), k8sEndpointSliceResolverSuccessFalseMutators, mNumResolutions.M(1))
+		return
+	}
+	h.update(slice)
+}
+
+func (h *endpointSliceHandler) OnDelete(obj any) {
+	var slice *discoveryv1.EndpointSlice
+	switch object := obj.(type) {
+	case cache.DeletedFinalStateUnknown:
+		h.OnDelete(object.Obj)
+		return
+	case *cache.DeletedFinalStateUnknown:
+		h.OnDelete(object.Obj)
+		return
+	case *discoveryv1.EndpointSlice:
+		slice = object
+	default: // unsupported
+		h.logger.Warn("Got an unexpected Kubernetes data type during the removal of an endpoint slice for the service", zap.Any("obj", obj))
+		_ = stats.RecordWithTags(context.Background(), k8sEndpointSliceResolverSuccessFalseMutators, mNumResolutions.M(1))
+		return
+	}
+	if slice == nil {
+		return
+	}
+
+	h.mu.Lock()
+	_, found := h.slices[slice.Name]
+	delete(h.slices, slice.Name)
+	h.mu.Unlock()
+
+	if found {
+		_, _ = h.callback(context.Background())
+	}
+}
+
+// update replaces the endpoints of the slice, resolving the backends again if they changed
+func (h *endpointSliceHandler) update(slice *discoveryv1.EndpointSlice) {
+	endpoints := convertSliceToEndpoints(slice)
+
+	h.mu.Lock()
+	existing, found := h.slices[slice.Name]
+	changed := !found || !slices.Equal(existing, endpoints)
+	h.slices[slice.Name] = endpoints
+	h.mu.Unlock()
+
+	if changed {
+		_, _ = h.callback(context.Background())
+	}
+}
+
+// endpoints returns the endpoints of all the slices
+func (h *endpointSliceHandler) endpoints() []sliceEndpoint {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var endpoints []sliceEndpoint
+	for _, eps := range h.slices {
+		endpoints = append(endpoints, eps...)
+	}
+	return endpoints
+}
+
+// convertSliceToEndpoints returns the addresses of the endpoints of a slice that
+// can receive data. Terminating endpoints are left out even while they are
+// still serving, so that they leave the ring before they are stopped.
+func convertSliceToEndpoints(slice *discoveryv1.EndpointSlice) []sliceEndpoint {
+	var endpoints []sliceEndpoint
+	for _, ep := range slice.Endpoints {
+		if ep.Conditions.Terminating != nil && *ep.Conditions.Terminating {
+			continue
+		}
+		// an unknown readiness has to be interpreted as ready
+		if ep.Conditions.Ready != nil && !*ep.Conditions.Ready {
+			continue
+		}
+		var zone string
+		if ep.Zone != nil {
+			zone = *ep.Zone
+		}
+		for _, addr := range ep.Addresses {
+			endpoints = append(endpoints, sliceEndpoint{address: addr, zone: zone})
+		}
+	}
+	return endpoints
+}
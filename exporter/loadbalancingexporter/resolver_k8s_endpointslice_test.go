@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+func newTestEndpointSlice(name string, endpoints ...discoveryv1.Endpoint) *discoveryv1.EndpointSlice {
+	return &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: "lb"},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints:   endpoints,
+	}
+}
+
+func newTestEndpoint(ip, zone string, ready, terminating bool) discoveryv1.Endpoint {
+	return discoveryv1.Endpoint{
+		Addresses: []string{ip},
+		Conditions: discoveryv1.EndpointConditions{
+			Ready:       ptr.To(ready),
+			Terminating: ptr.To(terminating),
+		},
+		Zone: ptr.To(zone),
+	}
+}
+
+func TestK8sEndpointSliceResolve(t *testing.T) {
+	slice := newTestEndpointSlice("lb-abc",
+		newTestEndpoint("10.0.0.1", "zone-a", true, false),
+		newTestEndpoint("10.0.0.2", "zone-b", true, false),
+		newTestEndpoint("10.0.0.3", "zone-a", false, false),
+		newTestEndpoint("10.0.0.4", "zone-a", false, true),
+	)
+	other := newTestEndpointSlice("lb-other", newTestEndpoint("10.0.1.1", "zone-a", true, false))
+	other.Labels[discoveryv1.LabelServiceName] = "other"
+
+	cl := fake.NewSimpleClientset(slice, other)
+	res, err := newK8sEndpointSliceResolver(cl, zap.NewNop(), K8sEndpointSliceResolver{
+		Service: "lb.default",
+		Ports:   []int32{4317},
+	})
+	require.NoError(t, err)
+
+	var (
+		mu        sync.Mutex
+		callbacks [][]string
+	)
+	res.onChange(func(endpoints []string) {
+		mu.Lock()
+		defer mu.Unlock()
+		callbacks = append(callbacks, endpoints)
+	})
+	require.NoError(t, res.start(context.Background()))
+	defer func() {
+		require.NoError(t, res.shutdown(context.Background()))
+	}()
+
+	// only the ready endpoints of the service are backends
+	assert.Equal(t, []string{"10.0.0.1:4317", "10.0.0.2:4317"}, res.Endpoints())
+	assert.Empty(t, res.weights(), "Must not weigh the backends without a zone")
+
+	// a terminating endpoint leaves the ring, even while it is still serving
+	slice = slice.DeepCopy()
+	slice.Endpoints[1] = discoveryv1.Endpoint{
+		Addresses: []string{"10.0.0.2"},
+		Conditions: discoveryv1.EndpointConditions{
+			Ready:       ptr.To(false),
+			Serving:     ptr.To(true),
+			Terminating: ptr.To(true),
+		},
+	}
+	_, err = cl.DiscoveryV1().EndpointSlices("default").Update(context.Background(), slice, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"10.0.0.1:4317"}, res.Endpoints())
+	}, time.Second, 20*time.Millisecond)
+
+	// the endpoints of all the slices of the service are backends
+	_, err = cl.DiscoveryV1().EndpointSlices("default").Create(context.Background(),
+		newTestEndpointSlice("lb-def", newTestEndpoint("10.0.0.5", "zone-b", true, false)), metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"10.0.0.1:4317", "10.0.0.5:4317"}, res.Endpoints())
+	}, time.Second, 20*time.Millisecond)
+
+	require.NoError(t, cl.DiscoveryV1().EndpointSlices("default").Delete(context.Background(), "lb-abc", metav1.DeleteOptions{}))
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"10.0.0.5:4317"}, res.Endpoints())
+	}, time.Second, 20*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.NotEmpty(t, callbacks)
+	assert.Equal(t, []string{"10.0.0.5:4317"}, callbacks[len(callbacks)-1])
+}
+
+func TestK8sEndpointSliceResolveZones(t *testing.T) {
+	tests := []struct {
+		name      string
+		slice     *discoveryv1.EndpointSlice
+		endpoints []string
+		weights   map[string]int
+	}{
+		{
+			name: "backends in other zones get less weight",
+			slice: newTestEndpointSlice("lb-abc",
+				newTestEndpoint("10.0.0.1", "zone-a", true, false),
+				newTestEndpoint("10.0.0.2", "zone-b", true, false),
+				newTestEndpoint("10.0.0.3", "zone-c", true, false),
+			),
+			endpoints: []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"},
+			weights:   map[string]int{"10.0.0.2": 20, "10.0.0.3": 20},
+		},
+		{
+			name: "all backends have the same weight without backends in the same zone",
+			slice: newTestEndpointSlice("lb-abc",
+				newTestEndpoint("10.0.0.2", "zone-b", true, false),
+				newTestEndpoint("10.0.0.3", "zone-c", true, false),
+			),
+			endpoints: []string{"10.0.0.2", "10.0.0.3"},
+			weights:   map[string]int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := newK8sEndpointSliceResolver(fake.NewSimpleClientset(tt.slice), zap.NewNop(), K8sEndpointSliceResolver{
+				Service:         "lb.default",
+				Zone:            "zone-a",
+				CrossZoneWeight: 20,
+			})
+			require.NoError(t, err)
+			require.NoError(t, res.start(context.Background()))
+			defer func() {
+				require.NoError(t, res.shutdown(context.Background()))
+			}()
+
+			assert.Equal(t, tt.endpoints, res.Endpoints())
+			assert.Equal(t, tt.weights, res.weights())
+		})
+	}
+}
+
+func Test_newK8sEndpointSliceResolver(t *testing.T) {
+	tests := []struct {
+		name          string
+		cfg           K8sEndpointSliceResolver
+		wantErr       error
+		wantService   string
+		wantNamespace string
+		wantWeight    int
+	}{
+		{
+			name:    "invalid name of k8s service",
+			cfg:     K8sEndpointSliceResolver{},
+			wantErr: errNoSvc,
+		},
+		{
+			name:    "invalid cross zone weight",
+			cfg:     K8sEndpointSliceResolver{Service: "lb", CrossZoneWeight: 101},
+			wantErr: errInvalidCrossZoneWeight,
+		},
+		{
+			name:          "use specified namespace and default weight",
+			cfg:           K8sEndpointSliceResolver{Service: "lb.kube-public"},
+			wantService:   "lb",
+			wantNamespace: "kube-public",
+			wantWeight:    defaultCrossZoneWeight,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newK8sEndpointSliceResolver(fake.NewSimpleClientset(), zap.NewNop(), tt.cfg)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantService, got.svcName)
+			assert.Equal(t, tt.wantNamespace, got.svcNs)
+			assert.Equal(t, tt.wantWeight, got.crossZoneWeight)
+		})
+	}
+}
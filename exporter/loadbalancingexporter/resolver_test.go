@@ -55,3 +55,14 @@ func (m *mockResolver) onChange(f func([]string)) {
 }
 
 var _ resolver = (*mockResolver)(nil)
+
+type mockWeightedResolver struct {
+	mockResolver
+	endpointWeights map[string]int
+}
+
+func (m *mockWeightedResolver) weights() map[string]int {
+	return m.endpointWeights
+}
+
+var _ weightedResolver = (*mockWeightedResolver)(nil)
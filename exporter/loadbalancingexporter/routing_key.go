@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+const attributesRoutingKey = "attributes"
+
+var (
+	errNoRoutingAttributes     = errors.New("routing_attributes must be set when the routing_key is \"attributes\"")
+	errRoutingAttributesUnused = errors.New("routing_attributes can only be set when the routing_key is \"attributes\"")
+)
+
+// recordRouter computes the routing keys of individual records, either from a list of
+// attributes or from an OTTL value expression evaluated in the context K of the records.
+type recordRouter[K any] struct {
+	attributes []string
+	expression *ottl.Statement[K]
+}
+
+// newRecordRouter returns the router for the routing key of the configuration, which
+// is either "attributes" or an OTTL value expression parsed with the given parser.
+func newRecordRouter[K any](cfg *Config, newParser func(map[string]ottl.Factory[K]) (ottl.Parser[K], error)) (*recordRouter[K], error) {
+	if cfg.RoutingKey == attributesRoutingKey || (cfg.RoutingKey == "" && len(cfg.RoutingAttributes) > 0) {
+		return &recordRouter[K]{attributes: cfg.RoutingAttributes}, nil
+	}
+
+	parser, err := newParser(keyFunctions[K]())
+	if err != nil {
+		return nil, err
+	}
+	// OTTL only parses statements, so the expression is evaluated as the argument of an editor
+	statement, err := parser.ParseStatement(fmt.Sprintf("%s(%s)", keyFunctionName, cfg.RoutingKey))
+	if err != nil {
+		return nil, fmt.Errorf("unsupported routing_key %q, it is not a valid OTTL value expression: %w", cfg.RoutingKey, err)
+	}
+	return &recordRouter[K]{expression: statement}, nil
+}
+
+// key returns the routing key of a record, or an empty string if the record has no value
+// for it. The attributes are looked up in the given maps in order, which are the attributes
+// of the record, of its scope and of its resource.
+func (r *recordRouter[K]) key(ctx context.Context, tCtx K, attrs ...pcommon.Map) (string, error) {
+	if r.expression != nil {
+		val, _, err := r.expression.Execute(ctx, tCtx)
+		if err != nil {
+			return "", err
+		}
+		return keyFromValue(val)
+	}
+
+	found := false
+	values := make([]string, len(r.attributes))
+	for i, name := range r.attributes {
+		for _, m := range attrs {
+			if v, ok := m.Get(name); ok {
+				values[i] = v.AsString()
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		return "", nil
+	}
+	return strings.Join(values, "\x00"), nil
+}
+
+// keyFromValue converts the result of an OTTL value expression into a routing key.
+// Only scalars, maps and slices have a stable representation to route the records by.
+func keyFromValue(val any) (string, error) {
+	switch v := val.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case bool, int64, float64:
+		return fmt.Sprint(v), nil
+	case pcommon.Value:
+		return v.AsString(), nil
+	case pcommon.Map:
+		return fmt.Sprint(v.AsRaw()), nil
+	case pcommon.Slice:
+		return fmt.Sprint(v.AsRaw()), nil
+	default:
+		return "", fmt.Errorf("unsupported routing key value of type %T", v)
+	}
+}
+
+const keyFunctionName = "key"
+
+type keyArguments[K any] struct {
+	Value ottl.Getter[K]
+}
+
+func createKeyFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*keyArguments[K])
+	if !ok {
+		return nil, fmt.Errorf("KeyFactory args must be of type *keyArguments[K]")
+	}
+	return args.Value.Get, nil
+}
+
+// keyFunctions are the functions available to the routing key expressions:
+// the standard converters, and the editor returning the value of the expression.
+func keyFunctions[K any]() map[string]ottl.Factory[K] {
+	functions := ottlfuncs.StandardConverters[K]()
+	functions[keyFunctionName] = ottl.NewFactory(keyFunctionName, &keyArguments[K]{}, createKeyFunction[K])
+	return functions
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+)
+
+func newSpanParser(functions map[string]ottl.Factory[ottlspan.TransformContext]) (ottl.Parser[ottlspan.TransformContext], error) {
+	return ottlspan.NewParser(functions, componenttest.NewNopTelemetrySettings())
+}
+
+func TestRecordRouterKey(t *testing.T) {
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("tenant.id", "resource-tenant")
+	resource.Attributes().PutStr("k8s.pod.uid", "pod-1")
+	scope := pcommon.NewInstrumentationScope()
+	scope.Attributes().PutStr("tenant.id", "scope-tenant")
+	span := ptrace.NewSpan()
+	span.SetName("span-1")
+	span.Attributes().PutStr("tenant.id", "span-tenant")
+	span.Attributes().PutInt("http.status_code", 200)
+
+	for _, tt := range []struct {
+		name string
+		cfg  *Config
+		span func() ptrace.Span
+		key  string
+	}{
+		{
+			name: "record attribute first",
+			cfg:  &Config{RoutingAttributes: []string{"tenant.id"}},
+			key:  "span-tenant",
+		},
+		{
+			name: "scope attribute before resource attribute",
+			cfg:  &Config{RoutingKey: "attributes", RoutingAttributes: []string{"tenant.id"}},
+			span: func() ptrace.Span {
+				s := ptrace.NewSpan()
+				span.CopyTo(s)
+				s.Attributes().Remove("tenant.id")
+				return s
+			},
+			key: "scope-tenant",
+		},
+		{
+			name: "several attributes",
+			cfg:  &Config{RoutingAttributes: []string{"tenant.id", "k8s.pod.uid", "http.status_code"}},
+			key:  "span-tenant\x00pod-1\x00200",
+		},
+		{
+			name: "missing attributes",
+			cfg:  &Config{RoutingAttributes: []string{"tenant"}},
+			key:  "",
+		},
+		{
+			name: "resource attribute expression",
+			cfg:  &Config{RoutingKey: `resource.attributes["tenant.id"]`},
+			key:  "resource-tenant",
+		},
+		{
+			name: "converter expression",
+			cfg:  &Config{RoutingKey: `Concat([name, attributes["http.status_code"]], "/")`},
+			key:  "span-1/200",
+		},
+		{
+			name: "missing value expression",
+			cfg:  &Config{RoutingKey: `attributes["tenant"]`},
+			key:  "",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			router, err := newRecordRouter(tt.cfg, newSpanParser)
+			require.NoError(t, err)
+
+			s := span
+			if tt.span != nil {
+				s = tt.span()
+			}
+			key, err := router.key(context.Background(), ottlspan.NewTransformContext(s, scope, resource),
+				s.Attributes(), scope.Attributes(), resource.Attributes())
+			require.NoError(t, err)
+			assert.Equal(t, tt.key, key)
+		})
+	}
+}
+
+func TestRecordRouterUnsupportedValue(t *testing.T) {
+	router, err := newRecordRouter(&Config{RoutingKey: "resource"}, newSpanParser)
+	require.NoError(t, err)
+
+	span := ptrace.NewSpan()
+	scope := pcommon.NewInstrumentationScope()
+	resource := pcommon.NewResource()
+	_, err = router.key(context.Background(), ottlspan.NewTransformContext(span, scope, resource),
+		span.Attributes(), scope.Attributes(), resource.Attributes())
+	assert.ErrorContains(t, err, "unsupported routing key value of type pcommon.Resource")
+}
+
+func TestRecordRouterInvalidExpression(t *testing.T) {
+	_, err := newRecordRouter(&Config{RoutingKey: "pod"}, newSpanParser)
+	assert.ErrorContains(t, err, `unsupported routing_key "pod"`)
+}
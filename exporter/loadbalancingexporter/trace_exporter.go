@@ -6,7 +6,6 @@ package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry
 import (
 	"context"
 	"errors"
-	"fmt"
 	"sync"
 	"time"
 
@@ -20,6 +19,8 @@ import (
 	"go.uber.org/multierr"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 )
 
 var _ exporter.Traces = (*traceExporterImp)(nil)
@@ -29,6 +30,8 @@ type exporterTraces map[*wrappedExporter]ptrace.Traces
 type traceExporterImp struct {
 	loadBalancer *loadBalancer
 	routingKey   routingKey
+	// router routes each span on its own, when the routing key is not one of the built-in keys
+	router *recordRouter[ottlspan.TransformContext]
 
 	stopped    bool
 	shutdownWg sync.WaitGroup
@@ -51,9 +54,20 @@ func newTracesExporter(params exporter.CreateSettings, cfg component.Config) (*t
 	switch cfg.(*Config).RoutingKey {
 	case "service":
 		traceExporter.routingKey = svcRouting
-	case "traceID", "":
+	// the built-in routing keys of the other signals are kept routing the spans by trace ID,
+	// as the configuration might be shared by the exporters of all the signals
+	case "traceID", "resource", "metric", "":
+		if len(cfg.(*Config).RoutingAttributes) == 0 {
+			break
+		}
+		fallthrough
 	default:
-		return nil, fmt.Errorf("unsupported routing_key: %s", cfg.(*Config).RoutingKey)
+		traceExporter.router, err = newRecordRouter(cfg.(*Config), func(functions map[string]ottl.Factory[ottlspan.TransformContext]) (ottl.Parser[ottlspan.TransformContext], error) {
+			return ottlspan.NewParser(functions, params.TelemetrySettings)
+		})
+		if err != nil {
+			return nil, err
+		}
 	}
 	return &traceExporter, nil
 }
@@ -80,6 +94,10 @@ func (e *traceExporterImp) Shutdown(ctx context.Context) error {
 }
 
 func (e *traceExporterImp) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	if e.router != nil {
+		return e.consumeTracesByRecord(ctx, td)
+	}
+
 	batches := batchpersignal.SplitTraces(td)
 
 	exporterSegregatedTraces := make(exporterTraces)
@@ -107,6 +125,61 @@ func (e *traceExporterImp) ConsumeTraces(ctx context.Context, td ptrace.Traces)
 		}
 	}
 
+	return e.exportTraces(ctx, exporterSegregatedTraces, endpoints)
+}
+
+// consumeTracesByRecord sends each span to the backend of its routing key, falling back
+// to its trace ID when the span has no value for the routing key.
+func (e *traceExporterImp) consumeTracesByRecord(ctx context.Context, td ptrace.Traces) error {
+	batches := newRecordBatches[ptrace.Traces, ptrace.ResourceSpans, ptrace.ScopeSpans](ptrace.NewTraces)
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		batches.nextResource(func(traces ptrace.Traces) ptrace.ResourceSpans {
+			dest := traces.ResourceSpans().AppendEmpty()
+			rs.Resource().CopyTo(dest.Resource())
+			dest.SetSchemaUrl(rs.SchemaUrl())
+			return dest
+		})
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			ss := sss.At(j)
+			batches.nextScope(func(rsDest ptrace.ResourceSpans) ptrace.ScopeSpans {
+				dest := rsDest.ScopeSpans().AppendEmpty()
+				ss.Scope().CopyTo(dest.Scope())
+				dest.SetSchemaUrl(ss.SchemaUrl())
+				return dest
+			})
+			spans := ss.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				key, err := e.router.key(ctx, ottlspan.NewTransformContext(span, ss.Scope(), rs.Resource()),
+					span.Attributes(), ss.Scope().Attributes(), rs.Resource().Attributes())
+				if err != nil {
+					batches.release()
+					return err
+				}
+				if key == "" {
+					tid := span.TraceID()
+					key = string(tid[:])
+				}
+
+				exp, endpoint, err := e.loadBalancer.exporterAndEndpoint([]byte(key))
+				if err != nil {
+					batches.release()
+					return err
+				}
+				span.CopyTo(batches.scope(exp, endpoint).Spans().AppendEmpty())
+			}
+		}
+	}
+
+	return e.exportTraces(ctx, batches.batches, batches.endpoints)
+}
+
+// exportTraces sends the traces to their exporters, which must have been added to their consumeWG
+func (e *traceExporterImp) exportTraces(ctx context.Context, exporterSegregatedTraces exporterTraces, endpoints map[*wrappedExporter]string) error {
 	var errs error
 
 	for exp, td := range exporterSegregatedTraces {
@@ -115,6 +188,7 @@ func (e *traceExporterImp) ConsumeTraces(ctx context.Context, td ptrace.Traces)
 		exp.consumeWG.Done()
 		errs = multierr.Append(errs, err)
 		duration := time.Since(start)
+		e.loadBalancer.onExportResult(endpoints[exp], err)
 
 		if err == nil {
 			_ = stats.RecordWithTags(
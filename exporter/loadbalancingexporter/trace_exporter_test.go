@@ -60,6 +60,20 @@ func TestNewTracesExporter(t *testing.T) {
 	}
 }
 
+func TestNewTracesExporterRoutingKeys(t *testing.T) {
+	// the built-in routing keys of the other signals route the spans by trace ID
+	for _, key := range []string{"traceID", "resource", "metric", ""} {
+		t.Run(key, func(t *testing.T) {
+			cfg := simpleConfig()
+			cfg.RoutingKey = key
+			exp, err := newTracesExporter(exportertest.NewNopCreateSettings(), cfg)
+			require.NoError(t, err)
+			assert.Equal(t, traceIDRouting, exp.routingKey)
+			assert.Nil(t, exp.router)
+		})
+	}
+}
+
 func TestTracesExporterStart(t *testing.T) {
 	for _, tt := range []struct {
 		desc string
@@ -274,6 +288,79 @@ func TestServiceBasedRoutingForSameTraceId(t *testing.T) {
 	}
 }
 
+func TestConsumeTracesRecordBased(t *testing.T) {
+	for name, cfg := range recordBasedRoutingConfigs() {
+		t.Run(name, func(t *testing.T) {
+			var mu sync.Mutex
+			tenantEndpoints := map[string]map[string]bool{}
+			spans := 0
+			componentFactory := func(_ context.Context, endpoint string) (component.Component, error) {
+				return newMockTracesExporter(func(_ context.Context, td ptrace.Traces) error {
+					mu.Lock()
+					defer mu.Unlock()
+					rs := td.ResourceSpans().At(0)
+					assert.Equal(t, "service-1", rs.Resource().Attributes().AsRaw()["service.name"])
+					ss := rs.ScopeSpans().At(0)
+					assert.Equal(t, "scope-1", ss.Scope().Name())
+					for i := 0; i < ss.Spans().Len(); i++ {
+						tenant, _ := ss.Spans().At(i).Attributes().Get("tenant.id")
+						if tenantEndpoints[tenant.Str()] == nil {
+							tenantEndpoints[tenant.Str()] = map[string]bool{}
+						}
+						tenantEndpoints[tenant.Str()][endpoint] = true
+						spans++
+					}
+					return nil
+				}), nil
+			}
+			lb, err := newLoadBalancer(exportertest.NewNopCreateSettings(), cfg, componentFactory)
+			require.NoError(t, err)
+			lb.res = &mockResolver{
+				triggerCallbacks: true,
+				onResolve: func(_ context.Context) ([]string, error) {
+					return []string{"endpoint-1", "endpoint-2", "endpoint-3", "endpoint-4"}, nil
+				},
+			}
+
+			p, err := newTracesExporter(exportertest.NewNopCreateSettings(), cfg)
+			require.NoError(t, err)
+			require.NotNil(t, p.router)
+			p.loadBalancer = lb
+
+			require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() {
+				require.NoError(t, p.Shutdown(context.Background()))
+			}()
+
+			td := ptrace.NewTraces()
+			rs := td.ResourceSpans().AppendEmpty()
+			rs.Resource().Attributes().PutStr("service.name", "service-1")
+			ss := rs.ScopeSpans().AppendEmpty()
+			ss.Scope().SetName("scope-1")
+			for i := 0; i < 100; i++ {
+				span := ss.Spans().AppendEmpty()
+				span.SetTraceID([16]byte{byte(i)})
+				span.Attributes().PutStr("tenant.id", fmt.Sprintf("tenant-%d", i%10))
+			}
+
+			// test
+			require.NoError(t, p.ConsumeTraces(context.Background(), td))
+
+			// verify
+			endpoints := map[string]bool{}
+			for tenant, tenantEndpoint := range tenantEndpoints {
+				assert.Len(t, tenantEndpoint, 1, "the spans of %s were sent to more than one backend", tenant)
+				for endpoint := range tenantEndpoint {
+					endpoints[endpoint] = true
+				}
+			}
+			assert.Len(t, tenantEndpoints, 10)
+			assert.Greater(t, len(endpoints), 1)
+			assert.Equal(t, 100, spans)
+		})
+	}
+}
+
 func TestConsumeTracesExporterNoEndpoint(t *testing.T) {
 	componentFactory := func(_ context.Context, _ string) (component.Component, error) {
 		return newNopMockTracesExporter(), nil
@@ -736,6 +823,23 @@ func serviceBasedRoutingConfig() *Config {
 	}
 }
 
+// recordBasedRoutingConfigs returns the configurations routing the records by their tenant
+func recordBasedRoutingConfigs() map[string]*Config {
+	resolver := ResolverSettings{
+		Static: &StaticResolver{Hostnames: []string{"endpoint-1", "endpoint-2", "endpoint-3", "endpoint-4"}},
+	}
+	return map[string]*Config{
+		"attributes": {
+			Resolver:          resolver,
+			RoutingAttributes: []string{"tenant.id"},
+		},
+		"expression": {
+			Resolver:   resolver,
+			RoutingKey: `attributes["tenant.id"]`,
+		},
+	}
+}
+
 type mockTracesExporter struct {
 	component.Component
 	ConsumeTracesFn func(ctx context.Context, td ptrace.Traces) error
@@ -8,109 +8,239 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	// Postgres driver
 	_ "github.com/jackc/pgx/v4/stdlib"
 	// SQLite driver
 	_ "github.com/mattn/go-sqlite3"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+	// Pure-Go SQLite driver
+	_ "modernc.org/sqlite"
 )
 
 const (
-	createTable     = "create table if not exists %s (key text primary key, value blob)"
-	getQueryText    = "select value from %s where key=?"
-	setQueryText    = "insert into %s(key, value) values(?,?) on conflict(key) do update set value=?"
+	getQueryText    = "select value from %s where key=? and updated_at>=?"
+	touchQueryText  = "update %s set updated_at=? where key=?"
+	setQueryText    = "insert into %s(key, value, updated_at) values(?,?,?) on conflict(key) do update set value=excluded.value, updated_at=excluded.updated_at"
 	deleteQueryText = "delete from %s where key=?"
+	expireQueryText = "delete from %s where updated_at<?"
+	countQueryText  = "select count(*) from %s"
+)
+
+const (
+	getOperation    = "get"
+	setOperation    = "set"
+	deleteOperation = "delete"
+	batchOperation  = "batch"
 )
 
 type dbStorageClient struct {
 	db          *sql.DB
+	tableName   string
+	logger      *zap.Logger
+	telemetry   *dbStorageTelemetry
+	ttl         time.Duration
 	getQuery    *sql.Stmt
+	touchQuery  *sql.Stmt
 	setQuery    *sql.Stmt
 	deleteQuery *sql.Stmt
+	expireQuery *sql.Stmt
+	countQuery  *sql.Stmt
+
+	tableSize metric.Registration
+
+	stopCleanup chan struct{}
+	cleanupWg   sync.WaitGroup
 }
 
-func newClient(ctx context.Context, db *sql.DB, tableName string) (*dbStorageClient, error) {
-	var err error
-	_, err = db.ExecContext(ctx, fmt.Sprintf(createTable, tableName))
-	if err != nil {
+func newClient(ctx context.Context, db *sql.DB, d dialect, tableName string, ttl, cleanupInterval time.Duration, logger *zap.Logger, telemetry *dbStorageTelemetry) (*dbStorageClient, error) {
+	if err := migrate(ctx, db, d, tableName); err != nil {
 		return nil, err
 	}
 
-	selectQuery, err := db.PrepareContext(ctx, fmt.Sprintf(getQueryText, tableName))
-	if err != nil {
-		return nil, err
+	c := &dbStorageClient{
+		db:          db,
+		tableName:   tableName,
+		logger:      logger,
+		telemetry:   telemetry,
+		ttl:         ttl,
+		stopCleanup: make(chan struct{}),
 	}
-	setQuery, err := db.PrepareContext(ctx, fmt.Sprintf(setQueryText, tableName))
-	if err != nil {
-		return nil, err
+
+	var err error
+	for _, q := range []struct {
+		stmt  **sql.Stmt
+		query string
+	}{
+		{&c.getQuery, getQueryText},
+		{&c.touchQuery, touchQueryText},
+		{&c.setQuery, setQueryText},
+		{&c.deleteQuery, deleteQueryText},
+		{&c.expireQuery, expireQueryText},
+		{&c.countQuery, countQueryText},
+	} {
+		*q.stmt, err = db.PrepareContext(ctx, d.rebind(fmt.Sprintf(q.query, tableName)))
+		if err != nil {
+			return nil, errors.Join(err, c.closeQueries())
+		}
 	}
-	deleteQuery, err := db.PrepareContext(ctx, fmt.Sprintf(deleteQueryText, tableName))
+
+	c.tableSize, err = telemetry.observeTableSize(tableName, c.size)
 	if err != nil {
-		return nil, err
+		return nil, errors.Join(err, c.closeQueries())
+	}
+
+	if ttl > 0 {
+		c.cleanupWg.Add(1)
+		go c.cleanup(cleanupInterval)
 	}
-	return &dbStorageClient{db, selectQuery, setQuery, deleteQuery}, nil
+	return c, nil
 }
 
 // Get will retrieve data from storage that corresponds to the specified key
 func (c *dbStorageClient) Get(ctx context.Context, key string) ([]byte, error) {
-	rows, err := c.getQuery.QueryContext(ctx, key)
-	if err != nil {
-		return nil, err
-	}
-	if !rows.Next() {
-		return nil, nil
-	}
-	var result []byte
-	err = rows.Scan(&result)
-	if err != nil {
-		return result, err
-	}
-	err = rows.Close()
-	return result, err
+	defer c.telemetry.recordOperation(ctx, c.tableName, getOperation, time.Now())
+	return c.get(ctx, nil, key)
 }
 
 // Set will store data. The data can be retrieved using the same key
 func (c *dbStorageClient) Set(ctx context.Context, key string, value []byte) error {
-	_, err := c.setQuery.ExecContext(ctx, key, value, value)
-	return err
+	defer c.telemetry.recordOperation(ctx, c.tableName, setOperation, time.Now())
+	return c.set(ctx, nil, key, value)
 }
 
 // Delete will delete data associated with the specified key
 func (c *dbStorageClient) Delete(ctx context.Context, key string) error {
-	_, err := c.deleteQuery.ExecContext(ctx, key)
-	return err
+	defer c.telemetry.recordOperation(ctx, c.tableName, deleteOperation, time.Now())
+	return c.delete(ctx, nil, key)
 }
 
-// Batch executes the specified operations in order. Get operation results are updated in place
-func (c *dbStorageClient) Batch(ctx context.Context, ops ...storage.Operation) error {
-	var err error
+// Batch executes the specified operations in order, in a single transaction. Get operation results are updated in place
+func (c *dbStorageClient) Batch(ctx context.Context, ops ...storage.Operation) (err error) {
+	defer c.telemetry.recordOperation(ctx, c.tableName, batchOperation, time.Now())
+
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		}
+	}()
+
 	for _, op := range ops {
 		switch op.Type {
 		case storage.Get:
-			op.Value, err = c.Get(ctx, op.Key)
+			op.Value, err = c.get(ctx, tx, op.Key)
 		case storage.Set:
-			err = c.Set(ctx, op.Key, op.Value)
+			err = c.set(ctx, tx, op.Key, op.Value)
 		case storage.Delete:
-			err = c.Delete(ctx, op.Key)
+			err = c.delete(ctx, tx, op.Key)
 		default:
-			return errors.New("wrong operation type")
+			err = errors.New("wrong operation type")
 		}
 
 		if err != nil {
 			return err
 		}
 	}
-	return err
+	return tx.Commit()
 }
 
 // Close will close the database
 func (c *dbStorageClient) Close(_ context.Context) error {
-	if err := c.setQuery.Close(); err != nil {
-		return err
+	close(c.stopCleanup)
+	c.cleanupWg.Wait()
+	return errors.Join(c.tableSize.Unregister(), c.closeQueries())
+}
+
+func (c *dbStorageClient) get(ctx context.Context, tx *sql.Tx, key string) ([]byte, error) {
+	var result []byte
+	err := c.stmt(ctx, tx, c.getQuery).QueryRowContext(ctx, key, c.expiredBefore()).Scan(&result)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
 	}
-	if err := c.deleteQuery.Close(); err != nil {
-		return err
+	if err != nil {
+		return nil, err
+	}
+
+	if c.ttl > 0 {
+		if _, err = c.stmt(ctx, tx, c.touchQuery).ExecContext(ctx, time.Now().UnixNano(), key); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (c *dbStorageClient) set(ctx context.Context, tx *sql.Tx, key string, value []byte) error {
+	_, err := c.stmt(ctx, tx, c.setQuery).ExecContext(ctx, key, value, time.Now().UnixNano())
+	return err
+}
+
+func (c *dbStorageClient) delete(ctx context.Context, tx *sql.Tx, key string) error {
+	_, err := c.stmt(ctx, tx, c.deleteQuery).ExecContext(ctx, key)
+	return err
+}
+
+// stmt returns the prepared statement to run in the transaction, if any
+func (c *dbStorageClient) stmt(ctx context.Context, tx *sql.Tx, stmt *sql.Stmt) *sql.Stmt {
+	if tx == nil {
+		return stmt
+	}
+	return tx.StmtContext(ctx, stmt)
+}
+
+// expiredBefore returns the time, in nanoseconds since the epoch, before which the keys
+// that were last touched have expired
+func (c *dbStorageClient) expiredBefore() int64 {
+	if c.ttl <= 0 {
+		return 0
+	}
+	return time.Now().Add(-c.ttl).UnixNano()
+}
+
+// size returns the number of keys in the table
+func (c *dbStorageClient) size(ctx context.Context) (int64, error) {
+	var n int64
+	err := c.countQuery.QueryRowContext(ctx).Scan(&n)
+	return n, err
+}
+
+// cleanup deletes the expired keys at every interval, until the client is closed
+func (c *dbStorageClient) cleanup(interval time.Duration) {
+	defer c.cleanupWg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stopCleanup:
+			return
+		case <-ticker.C:
+			if err := c.expire(context.Background()); err != nil {
+				c.logger.Warn("Failed to delete the expired keys", zap.String("table", c.tableName), zap.Error(err))
+			}
+		}
+	}
+}
+
+// expire deletes the keys that have not been touched for longer than the ttl
+func (c *dbStorageClient) expire(ctx context.Context) error {
+	_, err := c.expireQuery.ExecContext(ctx, c.expiredBefore())
+	return err
+}
+
+func (c *dbStorageClient) closeQueries() error {
+	var errs error
+	for _, stmt := range []*sql.Stmt{c.getQuery, c.touchQuery, c.setQuery, c.deleteQuery, c.expireQuery, c.countQuery} {
+		if stmt != nil {
+			errs = errors.Join(errs, stmt.Close())
+		}
 	}
-	return c.getQuery.Close()
+	return errs
 }
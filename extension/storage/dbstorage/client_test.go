@@ -0,0 +1,259 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dbstorage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+// testDataSources are the data sources of the SQLite drivers, by driver name
+var testDataSources = map[string]string{
+	"sqlite":  "file:%s/foo.db?_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)&_txlock=immediate",
+	"sqlite3": "file:%s/foo.db?_busy_timeout=10000&_journal=WAL&_sync=NORMAL&_txlock=immediate",
+}
+
+func TestClientConformance(t *testing.T) {
+	for driver := range testDataSources {
+		t.Run(driver, func(t *testing.T) {
+			storagetest.RunClientConformanceTests(t, func(t *testing.T) storage.Client {
+				se := newStartedTestExtension(t, driver, nil)
+				client, err := se.GetClient(context.Background(), component.KindReceiver, newTestEntity("receiver"), "")
+				require.NoError(t, err)
+				return client
+			})
+		})
+	}
+}
+
+func TestClientExpiry(t *testing.T) {
+	ctx := context.Background()
+	se := newStartedTestExtension(t, "sqlite", func(cfg *Config) {
+		cfg.TTL = 200 * time.Millisecond
+		cfg.CleanupInterval = 10 * time.Millisecond
+	})
+	client, err := se.GetClient(ctx, component.KindReceiver, newTestEntity("receiver"), "")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, client.Close(ctx))
+	}()
+
+	require.NoError(t, client.Set(ctx, "untouched", []byte("value")))
+	require.NoError(t, client.Set(ctx, "read", []byte("value")))
+	require.NoError(t, client.Set(ctx, "written", []byte("value")))
+
+	// the keys that are read or written do not expire
+	assert.Eventually(t, func() bool {
+		size, err := client.(*dbStorageClient).size(ctx)
+		require.NoError(t, err)
+
+		val, err := client.Get(ctx, "read")
+		require.NoError(t, err)
+		require.Equal(t, []byte("value"), val)
+		require.NoError(t, client.Set(ctx, "written", []byte("value")))
+		return size == 2
+	}, 5*time.Second, 20*time.Millisecond)
+
+	val, err := client.Get(ctx, "untouched")
+	require.NoError(t, err)
+	assert.Nil(t, val)
+}
+
+func TestClientExpiredKeysAreNotRead(t *testing.T) {
+	ctx := context.Background()
+	se := newStartedTestExtension(t, "sqlite", func(cfg *Config) {
+		cfg.TTL = 50 * time.Millisecond
+		// the cleanup does not run during the test
+		cfg.CleanupInterval = time.Hour
+	})
+	client, err := se.GetClient(ctx, component.KindReceiver, newTestEntity("receiver"), "")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, client.Close(ctx))
+	}()
+
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	time.Sleep(100 * time.Millisecond)
+
+	val, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, val)
+
+	get := storage.GetOperation("key")
+	require.NoError(t, client.Batch(ctx, get))
+	assert.Nil(t, get.Value)
+}
+
+func TestClientMigratesExistingTable(t *testing.T) {
+	ctx := context.Background()
+	dataSource := fmt.Sprintf(testDataSources["sqlite"], t.TempDir())
+
+	// a table created before the migrations were introduced
+	db, err := sql.Open("sqlite", dataSource)
+	require.NoError(t, err)
+	_, err = db.Exec("create table receiver_nop_receiver (key text primary key, value blob)")
+	require.NoError(t, err)
+	_, err = db.Exec("insert into receiver_nop_receiver(key, value) values('key', 'value')")
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.DriverName = "sqlite"
+	cfg.DataSource = dataSource
+	cfg.TTL = time.Hour
+
+	for i := 0; i < 2; i++ {
+		ext, err := f.CreateExtension(ctx, extensiontest.NewNopCreateSettings(), cfg)
+		require.NoError(t, err)
+		se := ext.(*databaseStorage)
+		require.NoError(t, se.Start(ctx, componenttest.NewNopHost()))
+
+		client, err := se.GetClient(ctx, component.KindReceiver, newTestEntity("receiver"), "")
+		require.NoError(t, err)
+
+		val, err := client.Get(ctx, "key")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("value"), val)
+
+		var version int
+		require.NoError(t, se.db.QueryRow("select version from dbstorage_migrations where table_name='receiver_nop_receiver'").Scan(&version))
+		assert.Equal(t, len(migrations), version)
+
+		require.NoError(t, client.Close(ctx))
+		require.NoError(t, se.Shutdown(ctx))
+	}
+}
+
+func TestClientBatchIsTransactional(t *testing.T) {
+	ctx := context.Background()
+	se := newStartedTestExtension(t, "sqlite", nil)
+	client, err := se.GetClient(ctx, component.KindReceiver, newTestEntity("receiver"), "")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, client.Close(ctx))
+	}()
+
+	require.NoError(t, client.Set(ctx, "existing", []byte("value")))
+
+	invalid := storage.GetOperation("key")
+	invalid.Type = storage.Delete + 1
+	err = client.Batch(ctx,
+		storage.SetOperation("key", []byte("value")),
+		storage.DeleteOperation("existing"),
+		invalid,
+	)
+	assert.EqualError(t, err, "wrong operation type")
+
+	// none of the operations were applied
+	val, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, val)
+	val, err = client.Get(ctx, "existing")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), val)
+}
+
+func TestClientTelemetry(t *testing.T) {
+	ctx := context.Background()
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	defer func() {
+		assert.NoError(t, mp.Shutdown(ctx))
+	}()
+
+	se := newStartedTestExtension(t, "sqlite", nil)
+	se.(*databaseStorage).telemetry, _ = newDBStorageTelemetry(component.TelemetrySettings{MeterProvider: mp})
+	client, err := se.GetClient(ctx, component.KindReceiver, newTestEntity("receiver"), "")
+	require.NoError(t, err)
+
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	require.NoError(t, client.Set(ctx, "other key", []byte("value")))
+	_, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	require.NoError(t, client.Batch(ctx, storage.DeleteOperation("key")))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	operations := map[string]uint64{}
+	var size int64
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		switch m.Name {
+		case "db_storage_operation_duration":
+			for _, dp := range m.Data.(metricdata.Histogram[float64]).DataPoints {
+				table, _ := dp.Attributes.Value(attribute.Key(tableKey))
+				assert.Equal(t, "receiver_nop_receiver", table.AsString())
+				operation, _ := dp.Attributes.Value(attribute.Key(operationKey))
+				operations[operation.AsString()] = dp.Count
+			}
+		case "db_storage_table_size":
+			dps := m.Data.(metricdata.Gauge[int64]).DataPoints
+			require.Len(t, dps, 1)
+			size = dps[0].Value
+		}
+	}
+	assert.Equal(t, map[string]uint64{"set": 2, "get": 1, "batch": 1}, operations)
+	assert.Equal(t, int64(1), size)
+
+	// the size of the tables of closed clients is no longer reported
+	require.NoError(t, client.Close(ctx))
+	rm = metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(ctx, &rm))
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == "db_storage_table_size" {
+				assert.Empty(t, m.Data.(metricdata.Gauge[int64]).DataPoints)
+			}
+		}
+	}
+}
+
+func TestRebind(t *testing.T) {
+	query := "insert into t(key, value) values(?,?)"
+	assert.Equal(t, query, sqliteDialect.rebind(query))
+	assert.Equal(t, "insert into t(key, value) values($1,$2)", postgresDialect.rebind(query))
+	assert.Equal(t, postgresDialect, dialectFor("pgx"))
+	assert.Equal(t, sqliteDialect, dialectFor("sqlite"))
+}
+
+// newStartedTestExtension returns a started extension storing to a new SQLite database,
+// which is shut down at the end of the test
+func newStartedTestExtension(t *testing.T, driver string, configure func(cfg *Config)) storage.Extension {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.DriverName = driver
+	cfg.DataSource = fmt.Sprintf(testDataSources[driver], t.TempDir())
+	if configure != nil {
+		configure(cfg)
+	}
+	require.NoError(t, cfg.Validate())
+
+	ext, err := f.CreateExtension(context.Background(), extensiontest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, ext.Shutdown(context.Background()))
+	})
+
+	se, ok := ext.(storage.Extension)
+	require.True(t, ok)
+	return se
+}
@@ -5,12 +5,19 @@ package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-c
 
 import (
 	"errors"
+	"time"
 )
 
 // Config defines configuration for dbstorage extension.
 type Config struct {
 	DriverName string `mapstructure:"driver,omitempty"`
 	DataSource string `mapstructure:"datasource,omitempty"`
+
+	// TTL is the time after which the keys that are neither read nor written expire.
+	// Zero disables the expiry.
+	TTL time.Duration `mapstructure:"ttl,omitempty"`
+	// CleanupInterval is the interval at which the expired keys are deleted from the tables.
+	CleanupInterval time.Duration `mapstructure:"cleanup_interval,omitempty"`
 }
 
 func (cfg *Config) Validate() error {
@@ -20,6 +27,12 @@ func (cfg *Config) Validate() error {
 	if cfg.DriverName == "" {
 		return errors.New("missing driver name")
 	}
+	if cfg.TTL < 0 {
+		return errors.New("ttl must not be negative")
+	}
+	if cfg.TTL > 0 && cfg.CleanupInterval <= 0 {
+		return errors.New("cleanup_interval must be positive when the ttl is set")
+	}
 
 	return nil
 }
@@ -6,6 +6,7 @@ package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-c
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -31,6 +32,21 @@ func TestConfigValidate(t *testing.T) {
 			Config{DriverName: "foo", DataSource: "bar"},
 			nil,
 		},
+		{
+			"Negative ttl",
+			Config{DriverName: "foo", DataSource: "bar", TTL: -time.Minute},
+			errors.New("ttl must not be negative"),
+		},
+		{
+			"Ttl without cleanup interval",
+			Config{DriverName: "foo", DataSource: "bar", TTL: time.Hour},
+			errors.New("cleanup_interval must be positive when the ttl is set"),
+		},
+		{
+			"valid with ttl",
+			Config{DriverName: "foo", DataSource: "bar", TTL: time.Hour, CleanupInterval: time.Minute},
+			nil,
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage"
+
+import (
+	"strconv"
+	"strings"
+)
+
+// dialect holds what differs between the SQL databases the storage is written to
+type dialect struct {
+	// blobType is the type of the column of the values
+	blobType string
+	// numberedPlaceholders is true when the query parameters are $1, $2... instead of ?
+	numberedPlaceholders bool
+	// lockMigrations is the statement preventing concurrent migrations of the tables
+	lockMigrations string
+}
+
+var (
+	sqliteDialect = dialect{
+		blobType: "blob",
+	}
+	postgresDialect = dialect{
+		blobType:             "bytea",
+		numberedPlaceholders: true,
+		lockMigrations:       "lock table " + migrationsTable + " in share row exclusive mode",
+	}
+)
+
+// dialectFor returns the dialect of the database of a driver. The drivers that are not
+// known are expected to use the same syntax as SQLite.
+func dialectFor(driverName string) dialect {
+	switch driverName {
+	case "pgx", "postgres":
+		return postgresDialect
+	default:
+		return sqliteDialect
+	}
+}
+
+// rebind replaces the ? placeholders of a query with the placeholders of the dialect
+func (d dialect) rebind(query string) string {
+	if !d.numberedPlaceholders {
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r != '?' {
+			b.WriteRune(r)
+			continue
+		}
+		n++
+		b.WriteByte('$')
+		b.WriteString(strconv.Itoa(n))
+	}
+	return b.String()
+}
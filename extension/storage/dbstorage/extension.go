@@ -8,6 +8,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
@@ -16,20 +17,33 @@ import (
 )
 
 type databaseStorage struct {
-	driverName     string
-	datasourceName string
-	logger         *zap.Logger
-	db             *sql.DB
+	driverName      string
+	datasourceName  string
+	dialect         dialect
+	ttl             time.Duration
+	cleanupInterval time.Duration
+	logger          *zap.Logger
+	telemetry       *dbStorageTelemetry
+	db              *sql.DB
 }
 
 // Ensure this storage extension implements the appropriate interface
 var _ storage.Extension = (*databaseStorage)(nil)
 
-func newDBStorage(logger *zap.Logger, config *Config) (extension.Extension, error) {
+func newDBStorage(set component.TelemetrySettings, config *Config) (extension.Extension, error) {
+	telemetry, err := newDBStorageTelemetry(set)
+	if err != nil {
+		return nil, err
+	}
+
 	return &databaseStorage{
-		driverName:     config.DriverName,
-		datasourceName: config.DataSource,
-		logger:         logger,
+		driverName:      config.DriverName,
+		datasourceName:  config.DataSource,
+		dialect:         dialectFor(config.DriverName),
+		ttl:             config.TTL,
+		cleanupInterval: config.CleanupInterval,
+		logger:          set.Logger,
+		telemetry:       telemetry,
 	}, nil
 }
 
@@ -64,7 +78,7 @@ func (ds *databaseStorage) GetClient(ctx context.Context, kind component.Kind, e
 		fullName = fmt.Sprintf("%s_%s_%s_%s", kindString(kind), ent.Type(), ent.Name(), name)
 	}
 	fullName = strings.ReplaceAll(fullName, " ", "")
-	return newClient(ctx, ds.db, fullName)
+	return newClient(ctx, ds.db, ds.dialect, fullName, ds.ttl, ds.cleanupInterval, ds.logger, ds.telemetry)
 }
 
 func kindString(k component.Kind) string {
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage"
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// migrationsTable holds the schema version of the table of each client
+const migrationsTable = "dbstorage_migrations"
+
+const (
+	createMigrationsTable  = "create table if not exists " + migrationsTable + " (table_name text primary key, version integer not null)"
+	getVersionQueryText    = "select version from " + migrationsTable + " where table_name=?"
+	setVersionQueryText    = "insert into " + migrationsTable + "(table_name, version) values(?,?) on conflict(table_name) do update set version=excluded.version"
+	createTable            = "create table if not exists %s (key text primary key, value %s)"
+	addUpdatedAtColumn     = "alter table %s add column updated_at bigint not null default 0"
+	initUpdatedAtQueryText = "update %s set updated_at=?"
+)
+
+type migration func(ctx context.Context, tx *sql.Tx, d dialect, tableName string) error
+
+// migrations bring the table of a client from the schema version matching their index
+// to the next one. Tables created before the migrations were introduced are at version 0,
+// as the first migration leaves them unchanged.
+var migrations = []migration{
+	func(ctx context.Context, tx *sql.Tx, d dialect, tableName string) error {
+		_, err := tx.ExecContext(ctx, fmt.Sprintf(createTable, tableName, d.blobType))
+		return err
+	},
+	func(ctx context.Context, tx *sql.Tx, d dialect, tableName string) error {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(addUpdatedAtColumn, tableName)); err != nil {
+			return err
+		}
+		// the existing keys are considered touched now, rather than expiring right away
+		_, err := tx.ExecContext(ctx, d.rebind(fmt.Sprintf(initUpdatedAtQueryText, tableName)), time.Now().UnixNano())
+		return err
+	},
+}
+
+// migrate brings the table of a client to the latest schema version, in a single transaction
+func migrate(ctx context.Context, db *sql.DB, d dialect, tableName string) (err error) {
+	if _, err = db.ExecContext(ctx, createMigrationsTable); err != nil {
+		return err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		}
+	}()
+
+	if d.lockMigrations != "" {
+		if _, err = tx.ExecContext(ctx, d.lockMigrations); err != nil {
+			return err
+		}
+	}
+
+	var version int
+	err = tx.QueryRowContext(ctx, d.rebind(getVersionQueryText), tableName).Scan(&version)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		err = nil
+	case err != nil:
+		return err
+	}
+	if version >= len(migrations) {
+		return tx.Commit()
+	}
+
+	for _, m := range migrations[version:] {
+		if err = m(ctx, tx, d, tableName); err != nil {
+			return fmt.Errorf("failed to migrate the table %s from version %d: %w", tableName, version, err)
+		}
+		version++
+	}
+	if _, err = tx.ExecContext(ctx, d.rebind(setVersionQueryText), tableName, version); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage/internal/metadata"
+)
+
+const (
+	operationKey = "operation"
+	tableKey     = "table"
+)
+
+type dbStorageTelemetry struct {
+	meter             metric.Meter
+	operationDuration metric.Float64Histogram
+	tableSize         metric.Int64ObservableGauge
+}
+
+func newDBStorageTelemetry(set component.TelemetrySettings) (*dbStorageTelemetry, error) {
+	meter := metadata.Meter(set)
+
+	operationDuration, err := meter.Float64Histogram(
+		metadata.Type.String()+"_operation_duration",
+		metric.WithDescription("Duration of the storage operations"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	tableSize, err := meter.Int64ObservableGauge(
+		metadata.Type.String()+"_table_size",
+		metric.WithDescription("Number of keys in the table of a storage client"),
+		metric.WithUnit("{keys}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dbStorageTelemetry{
+		meter:             meter,
+		operationDuration: operationDuration,
+		tableSize:         tableSize,
+	}, nil
+}
+
+// recordOperation records the duration of an operation on a table, started at the given time
+func (t *dbStorageTelemetry) recordOperation(ctx context.Context, tableName, operation string, start time.Time) {
+	t.operationDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(
+		attribute.String(tableKey, tableName),
+		attribute.String(operationKey, operation),
+	))
+}
+
+// observeTableSize reports the size of a table, as returned by size, until the registration is unregistered
+func (t *dbStorageTelemetry) observeTableSize(tableName string, size func(ctx context.Context) (int64, error)) (metric.Registration, error) {
+	attrs := metric.WithAttributes(attribute.String(tableKey, tableName))
+	return t.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		n, err := size(ctx)
+		if err != nil {
+			return err
+		}
+		o.ObserveInt64(t.tableSize, n, attrs)
+		return nil
+	}, t.tableSize)
+}
@@ -35,6 +35,8 @@ type fileStorageClient struct {
 	openTimeout     time.Duration
 	cancel          context.CancelFunc
 	closed          bool
+	// encryptor encrypts the values, when encryption is enabled
+	encryptor *encryptor
 }
 
 func bboltOptions(timeout time.Duration, noSync bool) *bbolt.Options {
@@ -46,7 +48,7 @@ func bboltOptions(timeout time.Duration, noSync bool) *bbolt.Options {
 	}
 }
 
-func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig, noSync bool) (*fileStorageClient, error) {
+func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig, noSync bool, enc *encryptor) (*fileStorageClient, error) {
 	options := bboltOptions(timeout, noSync)
 	db, err := bbolt.Open(filePath, 0600, options)
 	if err != nil {
@@ -62,7 +64,7 @@ func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compa
 		return nil, err
 	}
 
-	client := &fileStorageClient{logger: logger, db: db, compactionCfg: compactionCfg, openTimeout: timeout}
+	client := &fileStorageClient{logger: logger, db: db, compactionCfg: compactionCfg, openTimeout: timeout, encryptor: enc}
 	if compactionCfg.OnRebound {
 		client.startCompactionLoop(context.Background())
 	}
@@ -104,7 +106,10 @@ func (c *fileStorageClient) Batch(_ context.Context, ops ...storage.Operation) e
 			switch op.Type {
 			case storage.Get:
 				value := bucket.Get([]byte(op.Key))
-				if value != nil {
+				if c.encryptor != nil {
+					// decrypting makes a copy of the value
+					op.Value, err = c.encryptor.decrypt(op.Key, value)
+				} else if value != nil {
 					// the output of Bucket.Get is only valid within a transaction, so we need to make a copy
 					// to be able to return the value
 					op.Value = make([]byte, len(value))
@@ -113,7 +118,13 @@ func (c *fileStorageClient) Batch(_ context.Context, ops ...storage.Operation) e
 					op.Value = nil
 				}
 			case storage.Set:
-				err = bucket.Put([]byte(op.Key), op.Value)
+				value := op.Value
+				if c.encryptor != nil {
+					if value, err = c.encryptor.encrypt(op.Key, value); err != nil {
+						return err
+					}
+				}
+				err = bucket.Put([]byte(op.Key), value)
 			case storage.Delete:
 				err = bucket.Delete([]byte(op.Key))
 			default:
@@ -193,7 +204,12 @@ func (c *fileStorageClient) Compact(compactionDirectory string, timeout time.Dur
 
 	compactionStart := time.Now()
 
-	if err = bbolt.Compact(compactedDb, c.db, maxTransactionSize); err != nil {
+	if c.encryptor != nil {
+		err = compactEncrypted(compactedDb, c.db, maxTransactionSize, c.encryptor)
+	} else {
+		err = bbolt.Compact(compactedDb, c.db, maxTransactionSize)
+	}
+	if err != nil {
 		return err
 	}
 
@@ -20,6 +20,9 @@ type Config struct {
 
 	// FSync specifies that fsync should be called after each database write
 	FSync bool `mapstructure:"fsync,omitempty"`
+
+	// Encryption specifies that the values are encrypted with AES-GCM when it is set
+	Encryption *EncryptionConfig `mapstructure:"encryption,omitempty"`
 }
 
 // CompactionConfig defines configuration for optional file storage compaction.
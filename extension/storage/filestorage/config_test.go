@@ -50,6 +50,21 @@ func TestLoadConfig(t *testing.T) {
 				FSync:   true,
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "encryption"),
+			expected: func() component.Config {
+				ret := NewFactory().CreateDefaultConfig().(*Config)
+				ret.Directory = "."
+				ret.Encryption = &EncryptionConfig{
+					Keys: []EncryptionKeyConfig{
+						{ID: "2024-02", Env: "FILE_STORAGE_KEY"},
+						{ID: "2024-01", File: "./testdata/key"},
+					},
+					UnencryptedValues: UnencryptedValuesError,
+				}
+				return ret
+			}(),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -94,3 +109,55 @@ func TestHandleProvidingFilePathAsDirWithAnError(t *testing.T) {
 	require.Error(t, err)
 	require.EqualError(t, err, file.Name()+" is not a directory")
 }
+
+func TestValidateEncryption(t *testing.T) {
+	tests := []struct {
+		name       string
+		encryption *EncryptionConfig
+		err        string
+	}{
+		{
+			name:       "no key",
+			encryption: &EncryptionConfig{},
+			err:        "at least one encryption key is required",
+		},
+		{
+			name:       "no key id",
+			encryption: &EncryptionConfig{Keys: []EncryptionKeyConfig{{Env: "KEY"}}},
+			err:        "encryption key id cannot be empty",
+		},
+		{
+			name:       "key id too long",
+			encryption: &EncryptionConfig{Keys: []EncryptionKeyConfig{{ID: strings.Repeat("a", 256), Env: "KEY"}}},
+			err:        "is longer than 255 bytes",
+		},
+		{
+			name:       "duplicate key id",
+			encryption: &EncryptionConfig{Keys: []EncryptionKeyConfig{{ID: "a", Env: "KEY"}, {ID: "a", Env: "OTHER_KEY"}}},
+			err:        `duplicate encryption key id "a"`,
+		},
+		{
+			name:       "no key source",
+			encryption: &EncryptionConfig{Keys: []EncryptionKeyConfig{{ID: "a"}}},
+			err:        `exactly one of file or env must be set for encryption key "a"`,
+		},
+		{
+			name:       "both key sources",
+			encryption: &EncryptionConfig{Keys: []EncryptionKeyConfig{{ID: "a", Env: "KEY", File: "key"}}},
+			err:        `exactly one of file or env must be set for encryption key "a"`,
+		},
+		{
+			name:       "invalid unencrypted values",
+			encryption: &EncryptionConfig{Keys: []EncryptionKeyConfig{{ID: "a", Env: "KEY"}}, UnencryptedValues: "drop"},
+			err:        `unencrypted_values must be "read", "ignore" or "error"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.Directory = t.TempDir()
+			cfg.Encryption = tt.encryption
+			assert.ErrorContains(t, component.ValidateConfig(cfg), tt.err)
+		})
+	}
+}
@@ -0,0 +1,275 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.etcd.io/bbolt"
+)
+
+const (
+	// UnencryptedValuesRead returns the values written before the encryption was enabled as they are.
+	// They are encrypted when they are written again, or when the database is compacted.
+	UnencryptedValuesRead = "read"
+	// UnencryptedValuesIgnore handles the values written before the encryption was enabled as if they did not exist.
+	// They are removed when the database is compacted.
+	UnencryptedValuesIgnore = "ignore"
+	// UnencryptedValuesError fails the operations reading values written before the encryption was enabled.
+	UnencryptedValuesError = "error"
+)
+
+// encryptedValueHeader starts all the encrypted values. Its last byte is the version of the format, which is:
+// header | key ID length (1 byte) | key ID | nonce | AES-GCM sealed value
+var encryptedValueHeader = []byte{0x00, 'e', 'n', 'c', 0x01}
+
+const maxKeyIDLength = 255
+
+// EncryptionConfig defines configuration for the encryption of the stored values.
+type EncryptionConfig struct {
+	// Keys are the AES keys of the encryption. The first key encrypts the values, the others only decrypt
+	// the values written before it was added, so that keys can be rotated.
+	Keys []EncryptionKeyConfig `mapstructure:"keys"`
+	// UnencryptedValues specifies how the values written before the encryption was enabled are read:
+	// "read" (the default), "ignore" or "error".
+	UnencryptedValues string `mapstructure:"unencrypted_values,omitempty"`
+}
+
+// EncryptionKeyConfig defines configuration for an encryption key.
+type EncryptionKeyConfig struct {
+	// ID identifies the key. It is stored with each value encrypted with the key.
+	ID string `mapstructure:"id"`
+	// File is the path of a file holding the base64 encoded key.
+	File string `mapstructure:"file,omitempty"`
+	// Env is the name of an environment variable holding the base64 encoded key.
+	Env string `mapstructure:"env,omitempty"`
+}
+
+func (cfg *EncryptionConfig) Validate() error {
+	if len(cfg.Keys) == 0 {
+		return errors.New("at least one encryption key is required")
+	}
+
+	ids := map[string]bool{}
+	for _, key := range cfg.Keys {
+		if key.ID == "" {
+			return errors.New("encryption key id cannot be empty")
+		}
+		if len(key.ID) > maxKeyIDLength {
+			return fmt.Errorf("encryption key id %q is longer than %d bytes", key.ID, maxKeyIDLength)
+		}
+		if ids[key.ID] {
+			return fmt.Errorf("duplicate encryption key id %q", key.ID)
+		}
+		ids[key.ID] = true
+		if (key.File == "") == (key.Env == "") {
+			return fmt.Errorf("exactly one of file or env must be set for encryption key %q", key.ID)
+		}
+	}
+
+	switch cfg.UnencryptedValues {
+	case "", UnencryptedValuesRead, UnencryptedValuesIgnore, UnencryptedValuesError:
+	default:
+		return fmt.Errorf("unencrypted_values must be %q, %q or %q", UnencryptedValuesRead, UnencryptedValuesIgnore, UnencryptedValuesError)
+	}
+	return nil
+}
+
+// load reads and decodes the key
+func (cfg EncryptionKeyConfig) load() ([]byte, error) {
+	var encoded string
+	if cfg.File != "" {
+		data, err := os.ReadFile(cfg.File)
+		if err != nil {
+			return nil, err
+		}
+		encoded = string(data)
+	} else {
+		var ok bool
+		if encoded, ok = os.LookupEnv(cfg.Env); !ok {
+			return nil, fmt.Errorf("environment variable %s is not set", cfg.Env)
+		}
+	}
+	return base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+}
+
+// encryptor encrypts and decrypts the values of the clients
+type encryptor struct {
+	currentID         string
+	keys              map[string]cipher.AEAD
+	unencryptedValues string
+}
+
+func newEncryptor(cfg *EncryptionConfig) (*encryptor, error) {
+	enc := &encryptor{
+		currentID:         cfg.Keys[0].ID,
+		keys:              make(map[string]cipher.AEAD, len(cfg.Keys)),
+		unencryptedValues: cfg.UnencryptedValues,
+	}
+	if enc.unencryptedValues == "" {
+		enc.unencryptedValues = UnencryptedValuesRead
+	}
+	for _, keyCfg := range cfg.Keys {
+		key, err := keyCfg.load()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load encryption key %q: %w", keyCfg.ID, err)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key %q: %w", keyCfg.ID, err)
+		}
+		if enc.keys[keyCfg.ID], err = cipher.NewGCM(block); err != nil {
+			return nil, err
+		}
+	}
+	return enc, nil
+}
+
+// encrypt seals the value with the current key. The key of the value is authenticated with it,
+// so that values cannot be swapped between keys.
+func (e *encryptor) encrypt(key string, value []byte) ([]byte, error) {
+	aead := e.keys[e.currentID]
+	nonceSize := aead.NonceSize()
+	prefixSize := len(encryptedValueHeader) + 1 + len(e.currentID)
+
+	out := make([]byte, prefixSize+nonceSize, prefixSize+nonceSize+len(value)+aead.Overhead())
+	copy(out, encryptedValueHeader)
+	out[len(encryptedValueHeader)] = byte(len(e.currentID))
+	copy(out[len(encryptedValueHeader)+1:], e.currentID)
+	nonce := out[prefixSize:]
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(out, nonce, value, []byte(key)), nil
+}
+
+// decrypt opens a value sealed by encrypt. The values which are not encrypted are handled
+// according to the unencrypted_values setting.
+func (e *encryptor) decrypt(key string, value []byte) ([]byte, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if !bytes.HasPrefix(value, encryptedValueHeader) {
+		switch e.unencryptedValues {
+		case UnencryptedValuesRead:
+			return append([]byte{}, value...), nil
+		case UnencryptedValuesIgnore:
+			return nil, nil
+		default:
+			return nil, fmt.Errorf("value of key %q is not encrypted", key)
+		}
+	}
+
+	keyID, sealed, err := splitEncryptedValue(value)
+	if err != nil {
+		return nil, fmt.Errorf("value of key %q: %w", key, err)
+	}
+	aead, ok := e.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("value of key %q is encrypted with unknown key %q", key, keyID)
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("value of key %q is truncated", key)
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt value of key %q: %w", key, err)
+	}
+	if plaintext == nil {
+		plaintext = []byte{}
+	}
+	return plaintext, nil
+}
+
+// reencrypt returns the value encrypted with the current key, or nil if the value must be dropped.
+// The values which cannot be decrypted are returned unchanged, so that they are not lost.
+func (e *encryptor) reencrypt(key string, value []byte) ([]byte, error) {
+	if bytes.HasPrefix(value, encryptedValueHeader) {
+		if keyID, _, err := splitEncryptedValue(value); err == nil && keyID == e.currentID {
+			return value, nil
+		}
+	}
+
+	plaintext, err := e.decrypt(key, value)
+	if err != nil {
+		return value, nil
+	}
+	if plaintext == nil {
+		return nil, nil
+	}
+	return e.encrypt(key, plaintext)
+}
+
+func splitEncryptedValue(value []byte) (keyID string, sealed []byte, err error) {
+	rest := value[len(encryptedValueHeader):]
+	if len(rest) == 0 || len(rest) < 1+int(rest[0]) {
+		return "", nil, errors.New("encrypted value is truncated")
+	}
+	return string(rest[1 : 1+rest[0]]), rest[1+rest[0]:], nil
+}
+
+// compactEncrypted is the equivalent of bbolt.Compact, which also encrypts the values with the current key.
+// Once all the databases have been compacted, the previous keys are no longer needed.
+func compactEncrypted(dst, src *bbolt.DB, txMaxSize int64, enc *encryptor) error {
+	tx, err := dst.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var size int64
+	err = src.View(func(srcTx *bbolt.Tx) error {
+		return srcTx.ForEach(func(name []byte, srcBucket *bbolt.Bucket) error {
+			bucket, err := tx.CreateBucketIfNotExists(name)
+			if err != nil {
+				return err
+			}
+			bucket.FillPercent = 1.0
+
+			return srcBucket.ForEach(func(k, v []byte) error {
+				if v == nil {
+					return fmt.Errorf("nested bucket %q is not supported", k)
+				}
+				value, err := enc.reencrypt(string(k), v)
+				if err != nil {
+					return err
+				}
+				if value == nil {
+					return nil
+				}
+
+				entrySize := int64(len(k) + len(value))
+				if txMaxSize != 0 && size+entrySize > txMaxSize {
+					if err = tx.Commit(); err != nil {
+						return err
+					}
+					if tx, err = dst.Begin(true); err != nil {
+						return err
+					}
+					bucket = tx.Bucket(name)
+					bucket.FillPercent = 1.0
+					size = 0
+				}
+				size += entrySize
+				// the keys and values of the source are only valid within its transaction
+				return bucket.Put(append([]byte{}, k...), append([]byte{}, value...))
+			})
+		})
+	})
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,238 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.uber.org/zap"
+)
+
+func TestEncryptorLoadsKeys(t *testing.T) {
+	t.Setenv("FILE_STORAGE_KEY", newTestKey(t, 16))
+
+	enc, err := newEncryptor(&EncryptionConfig{Keys: []EncryptionKeyConfig{
+		{ID: "env", Env: "FILE_STORAGE_KEY"},
+		{ID: "file", File: filepath.Join("testdata", "key")},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, "env", enc.currentID)
+	assert.Len(t, enc.keys, 2)
+	assert.Equal(t, UnencryptedValuesRead, enc.unencryptedValues)
+
+	_, err = newEncryptor(&EncryptionConfig{Keys: []EncryptionKeyConfig{{ID: "missing", Env: "FILE_STORAGE_MISSING_KEY"}}})
+	assert.EqualError(t, err, `failed to load encryption key "missing": environment variable FILE_STORAGE_MISSING_KEY is not set`)
+
+	t.Setenv("FILE_STORAGE_KEY", base64.StdEncoding.EncodeToString([]byte("too short")))
+	_, err = newEncryptor(&EncryptionConfig{Keys: []EncryptionKeyConfig{{ID: "short", Env: "FILE_STORAGE_KEY"}}})
+	assert.ErrorContains(t, err, `invalid encryption key "short"`)
+}
+
+func TestEncryptorRoundTrip(t *testing.T) {
+	enc := newTestEncryptor(t, UnencryptedValuesRead, "current")
+
+	for _, value := range [][]byte{[]byte("value"), {}} {
+		encrypted, err := enc.encrypt("key", value)
+		require.NoError(t, err)
+		assert.NotContains(t, string(encrypted), "value")
+
+		decrypted, err := enc.decrypt("key", encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, value, decrypted)
+
+		// the value is bound to its key
+		_, err = enc.decrypt("other key", encrypted)
+		assert.ErrorContains(t, err, `failed to decrypt value of key "other key"`)
+	}
+
+	decrypted, err := enc.decrypt("key", nil)
+	require.NoError(t, err)
+	assert.Nil(t, decrypted)
+}
+
+func TestEncryptorRejectsInvalidValues(t *testing.T) {
+	enc := newTestEncryptor(t, UnencryptedValuesRead, "current")
+	encrypted, err := enc.encrypt("key", []byte("value"))
+	require.NoError(t, err)
+
+	tampered := append([]byte{}, encrypted...)
+	tampered[len(tampered)-1] ^= 0xff
+	_, err = enc.decrypt("key", tampered)
+	assert.ErrorContains(t, err, `failed to decrypt value of key "key"`)
+
+	_, err = enc.decrypt("key", encrypted[:len(encryptedValueHeader)])
+	assert.EqualError(t, err, `value of key "key": encrypted value is truncated`)
+
+	_, err = enc.decrypt("key", encrypted[:len(encryptedValueHeader)+len("current")+2])
+	assert.EqualError(t, err, `value of key "key" is truncated`)
+
+	other := newTestEncryptor(t, UnencryptedValuesRead, "other")
+	_, err = other.decrypt("key", encrypted)
+	assert.EqualError(t, err, `value of key "key" is encrypted with unknown key "current"`)
+}
+
+func TestEncryptorUnencryptedValues(t *testing.T) {
+	tests := []struct {
+		unencryptedValues string
+		expected          []byte
+		err               string
+	}{
+		{unencryptedValues: UnencryptedValuesRead, expected: []byte("value")},
+		{unencryptedValues: UnencryptedValuesIgnore},
+		{unencryptedValues: UnencryptedValuesError, err: `value of key "key" is not encrypted`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.unencryptedValues, func(t *testing.T) {
+			enc := newTestEncryptor(t, tt.unencryptedValues, "current")
+			value, err := enc.decrypt("key", []byte("value"))
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func TestEncryptedClientRotatesKeys(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	t.Setenv("FILE_STORAGE_OLD_KEY", newTestKey(t, 32))
+	t.Setenv("FILE_STORAGE_NEW_KEY", newTestKey(t, 32))
+
+	newStorage := func(encryption *EncryptionConfig) storage.Client {
+		f := NewFactory()
+		cfg := f.CreateDefaultConfig().(*Config)
+		cfg.Directory = tempDir
+		cfg.Compaction.Directory = tempDir
+		cfg.Encryption = encryption
+		require.NoError(t, component.ValidateConfig(cfg))
+
+		ext, err := f.CreateExtension(ctx, extensiontest.NewNopCreateSettings(), cfg)
+		require.NoError(t, err)
+		client, err := ext.(storage.Extension).GetClient(ctx, component.KindReceiver, newTestEntity("my_component"), "")
+		require.NoError(t, err)
+		return client
+	}
+
+	// values written before the encryption was enabled
+	client := newStorage(nil)
+	require.NoError(t, client.Set(ctx, "plain", []byte("plain value")))
+	require.NoError(t, client.Close(ctx))
+
+	client = newStorage(&EncryptionConfig{Keys: []EncryptionKeyConfig{{ID: "old", Env: "FILE_STORAGE_OLD_KEY"}}})
+	require.NoError(t, client.Set(ctx, "old", []byte("old value")))
+	value, err := client.Get(ctx, "plain")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("plain value"), value)
+	require.NoError(t, client.Close(ctx))
+
+	client = newStorage(&EncryptionConfig{Keys: []EncryptionKeyConfig{
+		{ID: "new", Env: "FILE_STORAGE_NEW_KEY"},
+		{ID: "old", Env: "FILE_STORAGE_OLD_KEY"},
+	}})
+	require.NoError(t, client.Set(ctx, "new", []byte("new value")))
+	assertStoredKeyIDs(t, client, map[string]string{"plain": "", "old": "old", "new": "new"})
+
+	// the compaction encrypts all the values with the current key
+	require.NoError(t, client.(*fileStorageClient).Compact(tempDir, 0, 1))
+	assertStoredKeyIDs(t, client, map[string]string{"plain": "new", "old": "new", "new": "new"})
+	require.NoError(t, client.Close(ctx))
+
+	// the previous key is no longer needed
+	client = newStorage(&EncryptionConfig{
+		Keys:              []EncryptionKeyConfig{{ID: "new", Env: "FILE_STORAGE_NEW_KEY"}},
+		UnencryptedValues: UnencryptedValuesError,
+	})
+	for key, expected := range map[string]string{"plain": "plain value", "old": "old value", "new": "new value"} {
+		value, err = client.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, []byte(expected), value)
+	}
+	require.NoError(t, client.Close(ctx))
+}
+
+func TestEncryptedClientCompactionDropsIgnoredValues(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	dbFile := filepath.Join(tempDir, "my_db")
+
+	client, err := newClient(zap.NewNop(), dbFile, 0, &CompactionConfig{}, false, nil)
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "plain", []byte("plain value")))
+	require.NoError(t, client.Close(ctx))
+
+	client, err = newClient(zap.NewNop(), dbFile, 0, &CompactionConfig{}, false, newTestEncryptor(t, UnencryptedValuesIgnore, "current"))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, client.Close(ctx))
+	}()
+	for i := 0; i < 100; i++ {
+		require.NoError(t, client.Set(ctx, fmt.Sprintf("key_%d", i), []byte("value")))
+	}
+
+	value, err := client.Get(ctx, "plain")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	require.NoError(t, client.Compact(tempDir, 0, 64))
+	stored := storedKeyIDs(t, client)
+	assert.Len(t, stored, 100)
+	assert.NotContains(t, stored, "plain")
+	value, err = client.Get(ctx, "key_99")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+}
+
+func newTestKey(t *testing.T, size int) string {
+	key := make([]byte, size)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+func newTestEncryptor(t *testing.T, unencryptedValues string, keyID string) *encryptor {
+	keyFile := filepath.Join(t.TempDir(), keyID)
+	require.NoError(t, os.WriteFile(keyFile, []byte(newTestKey(t, 32)+"\n"), 0600))
+	enc, err := newEncryptor(&EncryptionConfig{
+		Keys:              []EncryptionKeyConfig{{ID: keyID, File: keyFile}},
+		UnencryptedValues: unencryptedValues,
+	})
+	require.NoError(t, err)
+	return enc
+}
+
+func assertStoredKeyIDs(t *testing.T, client storage.Client, expected map[string]string) {
+	assert.Equal(t, expected, storedKeyIDs(t, client.(*fileStorageClient)))
+}
+
+// storedKeyIDs returns the ID of the encryption key of each stored value, which is empty for unencrypted values
+func storedKeyIDs(t *testing.T, client *fileStorageClient) map[string]string {
+	keyIDs := map[string]string{}
+	require.NoError(t, client.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(defaultBucket).ForEach(func(k, v []byte) error {
+			keyIDs[string(k)] = ""
+			if len(v) > len(encryptedValueHeader) && string(v[:len(encryptedValueHeader)]) == string(encryptedValueHeader) {
+				keyID, _, err := splitEncryptedValue(v)
+				keyIDs[string(k)] = keyID
+				return err
+			}
+			return nil
+		})
+	}))
+	return keyIDs
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package memorystorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/memorystorage"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+var errClientClosed = errors.New("client closed")
+
+// memoryClient stores the data of a component in the bucket of the memory storage
+type memoryClient struct {
+	storage *memoryStorage
+	bucket  string
+	closed  bool
+}
+
+var _ storage.Client = (*memoryClient)(nil)
+
+// Get will retrieve data from storage that corresponds to the specified key
+func (c *memoryClient) Get(ctx context.Context, key string) ([]byte, error) {
+	op := storage.GetOperation(key)
+	err := c.Batch(ctx, op)
+	return op.Value, err
+}
+
+// Set will store data. The data can be retrieved using the same key
+func (c *memoryClient) Set(ctx context.Context, key string, value []byte) error {
+	return c.Batch(ctx, storage.SetOperation(key, value))
+}
+
+// Delete will delete data associated with the specified key
+func (c *memoryClient) Delete(ctx context.Context, key string) error {
+	return c.Batch(ctx, storage.DeleteOperation(key))
+}
+
+// Batch executes the specified operations in order, without interleaving with other operations. Get operation results are updated in place
+func (c *memoryClient) Batch(_ context.Context, ops ...storage.Operation) error {
+	c.storage.mu.Lock()
+	defer c.storage.mu.Unlock()
+
+	if c.closed {
+		return errClientClosed
+	}
+
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			op.Value = c.storage.get(c.bucket, op.Key)
+		case storage.Set:
+			if err := c.storage.set(c.bucket, op.Key, op.Value); err != nil {
+				return err
+			}
+		case storage.Delete:
+			c.storage.delete(c.bucket, op.Key)
+		default:
+			return errors.New("wrong operation type")
+		}
+	}
+	return nil
+}
+
+// Close will close the client. Its data is kept in the storage
+func (c *memoryClient) Close(_ context.Context) error {
+	c.storage.mu.Lock()
+	defer c.storage.mu.Unlock()
+	c.closed = true
+	return nil
+}
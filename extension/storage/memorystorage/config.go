@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package memorystorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/memorystorage"
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Config defines configuration for memory storage extension.
+type Config struct {
+	// MaxSizeBytes is the maximum total size of the keys and values held by the extension.
+	// The least recently used keys are evicted when it is exceeded. Zero means no limit.
+	MaxSizeBytes int64 `mapstructure:"max_size_bytes,omitempty"`
+
+	// Snapshot configures the optional periodic snapshots of the data to a file.
+	Snapshot SnapshotConfig `mapstructure:"snapshot,omitempty"`
+}
+
+// SnapshotConfig defines configuration for the snapshots of the memory storage.
+type SnapshotConfig struct {
+	// Directory is where the snapshot file is written, and read from on start.
+	// Snapshots are disabled when it is empty.
+	Directory string `mapstructure:"directory,omitempty"`
+	// Interval is the time between two snapshots. A last snapshot is taken on shutdown.
+	Interval time.Duration `mapstructure:"interval,omitempty"`
+}
+
+func (cfg *Config) Validate() error {
+	if cfg.MaxSizeBytes < 0 {
+		return errors.New("max_size_bytes cannot be negative")
+	}
+
+	if cfg.Snapshot.Directory == "" {
+		return nil
+	}
+	info, err := os.Stat(cfg.Snapshot.Directory)
+	if err != nil {
+		return fmt.Errorf("snapshot directory must exist: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", cfg.Snapshot.Directory)
+	}
+	if cfg.Snapshot.Interval <= 0 {
+		return errors.New("snapshot interval must be positive")
+	}
+
+	return nil
+}
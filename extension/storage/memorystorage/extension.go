@@ -0,0 +1,286 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package memorystorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/memorystorage"
+
+import (
+	"container/list"
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+)
+
+var errValueTooLarge = errors.New("the key and value are larger than max_size_bytes")
+
+type memoryStorage struct {
+	logger       *zap.Logger
+	maxSize      int64
+	snapshotFile string
+	interval     time.Duration
+
+	mu sync.Mutex
+	// entries holds the entries of all the clients, from the most to the least recently used
+	entries *list.List
+	// buckets indexes the entries by client name and key
+	buckets map[string]map[string]*list.Element
+	size    int64
+	// dirty is set when the entries changed since the last snapshot
+	dirty bool
+
+	stopSnapshots chan struct{}
+	snapshotsWg   sync.WaitGroup
+}
+
+// entry is a value stored by a client
+type entry struct {
+	Bucket string
+	Key    string
+	Value  []byte
+}
+
+func (e *entry) size() int64 {
+	return int64(len(e.Key) + len(e.Value))
+}
+
+// Ensure this storage extension implements the appropriate interface
+var _ storage.Extension = (*memoryStorage)(nil)
+
+func newMemoryStorage(id component.ID, logger *zap.Logger, config *Config) *memoryStorage {
+	ms := &memoryStorage{
+		logger:   logger,
+		maxSize:  config.MaxSizeBytes,
+		interval: config.Snapshot.Interval,
+		entries:  list.New(),
+		buckets:  map[string]map[string]*list.Element{},
+	}
+	if config.Snapshot.Directory != "" {
+		fileName := id.Type().String()
+		if id.Name() != "" {
+			fileName += "_" + id.Name()
+		}
+		ms.snapshotFile = filepath.Join(config.Snapshot.Directory, sanitize(fileName)+".snapshot")
+	}
+	return ms
+}
+
+// Start restores the last snapshot, and starts taking snapshots periodically
+func (ms *memoryStorage) Start(context.Context, component.Host) error {
+	if ms.snapshotFile == "" {
+		return nil
+	}
+	if err := ms.restore(); err != nil {
+		return fmt.Errorf("failed to restore the snapshot %s: %w", ms.snapshotFile, err)
+	}
+
+	ms.stopSnapshots = make(chan struct{})
+	ms.snapshotsWg.Add(1)
+	go ms.takeSnapshots()
+	return nil
+}
+
+// Shutdown takes a last snapshot
+func (ms *memoryStorage) Shutdown(context.Context) error {
+	if ms.stopSnapshots == nil {
+		return nil
+	}
+	close(ms.stopSnapshots)
+	ms.snapshotsWg.Wait()
+	return ms.snapshot()
+}
+
+// GetClient returns a storage client for an individual component
+func (ms *memoryStorage) GetClient(_ context.Context, kind component.Kind, ent component.ID, name string) (storage.Client, error) {
+	var bucket string
+	if name == "" {
+		bucket = fmt.Sprintf("%s_%s_%s", kindString(kind), ent.Type(), ent.Name())
+	} else {
+		bucket = fmt.Sprintf("%s_%s_%s_%s", kindString(kind), ent.Type(), ent.Name(), name)
+	}
+	return &memoryClient{storage: ms, bucket: bucket}, nil
+}
+
+func (ms *memoryStorage) get(bucket, key string) []byte {
+	elem, ok := ms.buckets[bucket][key]
+	if !ok {
+		return nil
+	}
+	ms.entries.MoveToFront(elem)
+	return clone(elem.Value.(*entry).Value)
+}
+
+func (ms *memoryStorage) set(bucket, key string, value []byte) error {
+	e := &entry{Bucket: bucket, Key: key, Value: clone(value)}
+	if ms.maxSize > 0 && e.size() > ms.maxSize {
+		return errValueTooLarge
+	}
+
+	ms.delete(bucket, key)
+	ms.add(e)
+	for ms.maxSize > 0 && ms.size > ms.maxSize {
+		evicted := ms.entries.Back().Value.(*entry)
+		ms.logger.Debug("Evicting the least recently used key", zap.String("bucket", evicted.Bucket), zap.String("key", evicted.Key))
+		ms.delete(evicted.Bucket, evicted.Key)
+	}
+	return nil
+}
+
+// add inserts an entry as the most recently used one
+func (ms *memoryStorage) add(e *entry) {
+	keys, ok := ms.buckets[e.Bucket]
+	if !ok {
+		keys = map[string]*list.Element{}
+		ms.buckets[e.Bucket] = keys
+	}
+	keys[e.Key] = ms.entries.PushFront(e)
+	ms.size += e.size()
+	ms.dirty = true
+}
+
+func (ms *memoryStorage) delete(bucket, key string) {
+	keys := ms.buckets[bucket]
+	elem, ok := keys[key]
+	if !ok {
+		return
+	}
+	ms.entries.Remove(elem)
+	delete(keys, key)
+	if len(keys) == 0 {
+		delete(ms.buckets, bucket)
+	}
+	ms.size -= elem.Value.(*entry).size()
+	ms.dirty = true
+}
+
+// takeSnapshots writes a snapshot at every interval, until the extension is shut down
+func (ms *memoryStorage) takeSnapshots() {
+	defer ms.snapshotsWg.Done()
+
+	ticker := time.NewTicker(ms.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ms.stopSnapshots:
+			return
+		case <-ticker.C:
+			if err := ms.snapshot(); err != nil {
+				ms.logger.Error("Failed to write the snapshot", zap.String("file", ms.snapshotFile), zap.Error(err))
+			}
+		}
+	}
+}
+
+// snapshot writes the entries to the snapshot file, if they changed since the last snapshot.
+// The file is replaced atomically, so that a crash while writing leaves the previous snapshot.
+func (ms *memoryStorage) snapshot() error {
+	ms.mu.Lock()
+	if !ms.dirty {
+		ms.mu.Unlock()
+		return nil
+	}
+	// the entries are kept from the least to the most recently used, to restore their order
+	entries := make([]entry, 0, ms.entries.Len())
+	for elem := ms.entries.Back(); elem != nil; elem = elem.Prev() {
+		entries = append(entries, *elem.Value.(*entry))
+	}
+	ms.dirty = false
+	ms.mu.Unlock()
+
+	err := ms.writeSnapshot(entries)
+	if err != nil {
+		ms.mu.Lock()
+		ms.dirty = true
+		ms.mu.Unlock()
+	}
+	return err
+}
+
+func (ms *memoryStorage) writeSnapshot(entries []entry) error {
+	tmp, err := os.CreateTemp(filepath.Dir(ms.snapshotFile), filepath.Base(ms.snapshotFile)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err = gob.NewEncoder(tmp).Encode(entries); err != nil {
+		return errors.Join(err, tmp.Close())
+	}
+	if err = tmp.Sync(); err != nil {
+		return errors.Join(err, tmp.Close())
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), ms.snapshotFile)
+}
+
+// restore loads the entries of the snapshot file, if there is one
+func (ms *memoryStorage) restore() error {
+	f, err := os.Open(ms.snapshotFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var entries []entry
+	if err = gob.NewDecoder(f).Decode(&entries); err != nil {
+		return err
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	for i := range entries {
+		// the max size might have been lowered since the snapshot
+		if err = ms.set(entries[i].Bucket, entries[i].Key, entries[i].Value); err != nil && !errors.Is(err, errValueTooLarge) {
+			return err
+		}
+	}
+	ms.dirty = false
+	return nil
+}
+
+func clone(value []byte) []byte {
+	if value == nil {
+		return nil
+	}
+	return append(make([]byte, 0, len(value)), value...)
+}
+
+func kindString(k component.Kind) string {
+	switch k {
+	case component.KindReceiver:
+		return "receiver"
+	case component.KindProcessor:
+		return "processor"
+	case component.KindExporter:
+		return "exporter"
+	case component.KindExtension:
+		return "extension"
+	case component.KindConnector:
+		return "connector"
+	default:
+		return "other" // not expected
+	}
+}
+
+// sanitize replaces the characters of a name that are not safe in a file name
+func sanitize(name string) string {
+	safe := []rune(name)
+	for i, r := range safe {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.') {
+			safe[i] = '_'
+		}
+	}
+	return string(safe)
+}
@@ -0,0 +1,196 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package memorystorage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/memorystorage/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func TestClientConformance(t *testing.T) {
+	storagetest.RunClientConformanceTests(t, func(t *testing.T) storage.Client {
+		se := newStartedTestExtension(t, nil)
+		client, err := se.GetClient(context.Background(), component.KindReceiver, newTestEntity("receiver"), "")
+		require.NoError(t, err)
+		return client
+	})
+}
+
+func TestExtensionIsolatesClients(t *testing.T) {
+	ctx := context.Background()
+	se := newStartedTestExtension(t, nil)
+
+	first, err := se.GetClient(ctx, component.KindReceiver, newTestEntity("receiver"), "")
+	require.NoError(t, err)
+	second, err := se.GetClient(ctx, component.KindReceiver, newTestEntity("receiver"), "other")
+	require.NoError(t, err)
+
+	require.NoError(t, first.Set(ctx, "key", []byte("first")))
+	require.NoError(t, second.Set(ctx, "key", []byte("second")))
+
+	val, err := first.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("first"), val)
+
+	// the data of a closed client is available to the next client of the same component
+	require.NoError(t, first.Close(ctx))
+	_, err = first.Get(ctx, "key")
+	assert.ErrorIs(t, err, errClientClosed)
+
+	reopened, err := se.GetClient(ctx, component.KindReceiver, newTestEntity("receiver"), "")
+	require.NoError(t, err)
+	val, err = reopened.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("first"), val)
+}
+
+func TestExtensionEvictsLeastRecentlyUsedKeys(t *testing.T) {
+	ctx := context.Background()
+	se := newStartedTestExtension(t, func(cfg *Config) {
+		// room for three keys of 1 byte with values of 9 bytes
+		cfg.MaxSizeBytes = 30
+	})
+
+	first, err := se.GetClient(ctx, component.KindReceiver, newTestEntity("receiver"), "")
+	require.NoError(t, err)
+	second, err := se.GetClient(ctx, component.KindExporter, newTestEntity("exporter"), "")
+	require.NoError(t, err)
+
+	value := []byte("123456789")
+	require.NoError(t, first.Set(ctx, "a", value))
+	require.NoError(t, second.Set(ctx, "b", value))
+	require.NoError(t, first.Set(ctx, "c", value))
+
+	// reading "a" makes "b" the least recently used key, of any client
+	_, err = first.Get(ctx, "a")
+	require.NoError(t, err)
+	require.NoError(t, first.Set(ctx, "d", value))
+
+	val, err := second.Get(ctx, "b")
+	require.NoError(t, err)
+	assert.Nil(t, val)
+	for _, key := range []string{"a", "c", "d"} {
+		val, err = first.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, value, val, key)
+	}
+
+	err = first.Set(ctx, "e", make([]byte, 30))
+	assert.ErrorIs(t, err, errValueTooLarge)
+	assert.Equal(t, int64(30), se.(*memoryStorage).size)
+}
+
+func TestExtensionRestoresSnapshot(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	configure := func(cfg *Config) {
+		cfg.Snapshot.Directory = dir
+		cfg.Snapshot.Interval = time.Hour
+	}
+
+	se := newTestExtension(t, configure)
+	require.NoError(t, se.Start(ctx, componenttest.NewNopHost()))
+	client, err := se.GetClient(ctx, component.KindReceiver, newTestEntity("receiver"), "")
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	require.NoError(t, client.Set(ctx, "other key", []byte("other value")))
+	require.NoError(t, client.Close(ctx))
+	require.NoError(t, se.Shutdown(ctx))
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "memory_storage.snapshot", files[0].Name())
+
+	// "key" is the least recently used entry after the restore, and is the one evicted
+	se = newStartedTestExtension(t, func(cfg *Config) {
+		configure(cfg)
+		cfg.MaxSizeBytes = 30
+	})
+	client, err = se.GetClient(ctx, component.KindReceiver, newTestEntity("receiver"), "")
+	require.NoError(t, err)
+	val, err := client.Get(ctx, "other key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("other value"), val)
+	require.NoError(t, client.Set(ctx, "new key", []byte("new")))
+	val, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, val)
+}
+
+func TestExtensionTakesSnapshotsPeriodically(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	se := newStartedTestExtension(t, func(cfg *Config) {
+		cfg.Snapshot.Directory = dir
+		cfg.Snapshot.Interval = 10 * time.Millisecond
+	})
+	client, err := se.GetClient(ctx, component.KindReceiver, newTestEntity("receiver"), "")
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(filepath.Join(dir, "memory_storage.snapshot"))
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestExtensionFailsOnCorruptedSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "memory_storage.snapshot"), []byte("corrupted"), 0600))
+
+	se := newTestExtension(t, func(cfg *Config) {
+		cfg.Snapshot.Directory = dir
+	})
+	assert.ErrorContains(t, se.Start(context.Background(), componenttest.NewNopHost()), "failed to restore the snapshot")
+}
+
+func TestSanitize(t *testing.T) {
+	assert.Equal(t, "memory_storage_my_name_1", sanitize("memory_storage_my/name:1"))
+}
+
+func newTestEntity(name string) component.ID {
+	return component.MustNewIDWithName("nop", name)
+}
+
+// newTestExtension returns an extension which is not started
+func newTestExtension(t *testing.T, configure func(cfg *Config)) storage.Extension {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	if configure != nil {
+		configure(cfg)
+	}
+	require.NoError(t, cfg.Validate())
+
+	set := extensiontest.NewNopCreateSettings()
+	set.ID = component.NewID(metadata.Type)
+	ext, err := f.CreateExtension(context.Background(), set, cfg)
+	require.NoError(t, err)
+	se, ok := ext.(storage.Extension)
+	require.True(t, ok)
+	return se
+}
+
+// newStartedTestExtension returns a started extension, which is shut down at the end of the test
+func newStartedTestExtension(t *testing.T, configure func(cfg *Config)) storage.Extension {
+	se := newTestExtension(t, configure)
+	require.NoError(t, se.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, se.Shutdown(context.Background()))
+	})
+	return se
+}
@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package storagetest // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+// RunClientConformanceTests checks that the clients returned by newClient behave as
+// expected from a storage.Client. Every test gets a new, empty, client and closes it
+// when done, so that storage extensions can run the same checks on their clients.
+func RunClientConformanceTests(t *testing.T, newClient func(t *testing.T) storage.Client) {
+	for _, tt := range []struct {
+		name string
+		test func(t *testing.T, client storage.Client)
+	}{
+		{name: "GetMissingKey", test: testGetMissingKey},
+		{name: "SetGet", test: testSetGet},
+		{name: "Overwrite", test: testOverwrite},
+		{name: "Delete", test: testDelete},
+		{name: "DeleteMissingKey", test: testDeleteMissingKey},
+		{name: "BinaryValue", test: testBinaryValue},
+		{name: "Batch", test: testBatch},
+		{name: "Concurrency", test: testConcurrency},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newClient(t)
+			tt.test(t, client)
+			assert.NoError(t, client.Close(context.Background()))
+		})
+	}
+}
+
+func testGetMissingKey(t *testing.T, client storage.Client) {
+	val, err := client.Get(context.Background(), "missing")
+	require.NoError(t, err)
+	assert.Nil(t, val)
+}
+
+func testSetGet(t *testing.T, client storage.Client) {
+	ctx := context.Background()
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+
+	val, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), val)
+}
+
+func testOverwrite(t *testing.T, client storage.Client) {
+	ctx := context.Background()
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	require.NoError(t, client.Set(ctx, "key", []byte("other value")))
+
+	val, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("other value"), val)
+}
+
+func testDelete(t *testing.T, client storage.Client) {
+	ctx := context.Background()
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	require.NoError(t, client.Set(ctx, "other key", []byte("other value")))
+	require.NoError(t, client.Delete(ctx, "key"))
+
+	val, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, val)
+
+	val, err = client.Get(ctx, "other key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("other value"), val)
+}
+
+func testDeleteMissingKey(t *testing.T, client storage.Client) {
+	assert.NoError(t, client.Delete(context.Background(), "missing"))
+}
+
+func testBinaryValue(t *testing.T, client storage.Client) {
+	ctx := context.Background()
+	value := []byte{0, 1, 0xfe, 0xff, '\n', 0}
+	require.NoError(t, client.Set(ctx, "key", value))
+
+	val, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, value, val)
+}
+
+func testBatch(t *testing.T, client storage.Client) {
+	ctx := context.Background()
+	require.NoError(t, client.Set(ctx, "existing", []byte("existing value")))
+
+	getExisting := storage.GetOperation("existing")
+	setKey := storage.SetOperation("key", []byte("value"))
+	getKey := storage.GetOperation("key")
+	overwriteKey := storage.SetOperation("key", []byte("other value"))
+	getOverwritten := storage.GetOperation("key")
+	deleteKey := storage.DeleteOperation("key")
+	getDeleted := storage.GetOperation("key")
+	require.NoError(t, client.Batch(ctx, getExisting, setKey, getKey, overwriteKey, getOverwritten, deleteKey, getDeleted))
+
+	// the operations are applied in order
+	assert.Equal(t, []byte("existing value"), getExisting.Value)
+	assert.Equal(t, []byte("value"), getKey.Value)
+	assert.Equal(t, []byte("other value"), getOverwritten.Value)
+	assert.Nil(t, getDeleted.Value)
+
+	val, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, val)
+}
+
+func testConcurrency(t *testing.T, client storage.Client) {
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := fmt.Sprintf("key-%d", i)
+			for j := 0; j < 20; j++ {
+				value := []byte(fmt.Sprintf("value-%d-%d", i, j))
+				assert.NoError(t, client.Batch(ctx, storage.SetOperation(key, value)))
+
+				val, err := client.Get(ctx, key)
+				assert.NoError(t, err)
+				assert.Equal(t, value, val)
+			}
+			assert.NoError(t, client.Delete(ctx, key))
+		}(i)
+	}
+	wg.Wait()
+}
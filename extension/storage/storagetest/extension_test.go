@@ -94,3 +94,9 @@ func runExtensionLifecycle(t *testing.T, ext *TestStorage, expectPersistence boo
 	require.NoError(t, clientTwo.Close(ctx))
 	require.NoError(t, ext.Shutdown(ctx))
 }
+
+func TestInMemoryClientConformance(t *testing.T) {
+	RunClientConformanceTests(t, func(*testing.T) storage.Client {
+		return NewInMemoryClient(component.KindProcessor, component.MustNewID("foo"), "client")
+	})
+}
@@ -0,0 +1,256 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package grok compiles grok patterns, as used by Logstash, into regular expressions.
+// A grok pattern references named pattern definitions with %{SYNTAX}, %{SYNTAX:SEMANTIC}
+// or %{SYNTAX:SEMANTIC:TYPE}, where SEMANTIC is the name of the captured value and TYPE
+// is either int or float. The standard pattern library is available to all the patterns.
+package grok // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/grok"
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	TypeInt   = "int"
+	TypeFloat = "float"
+)
+
+//go:embed patterns/grok-patterns
+var standardPatterns string
+
+// standardDefinitions are the definitions of the standard pattern library, by name
+var standardDefinitions = mustParseDefinitions(standardPatterns)
+
+var (
+	// referenceRegexp matches the %{SYNTAX:SEMANTIC:TYPE} references and the named capture groups of a pattern
+	referenceRegexp = regexp.MustCompile(`%\{(\w+)(?::([^:}]+))?(?::([^:}]+))?\}|\(\?P?<([^>=!][^>]*)>`)
+	nameRegexp      = regexp.MustCompile(`^\w+$`)
+)
+
+// Regexp is a compiled grok pattern.
+type Regexp struct {
+	regexp   *regexp.Regexp
+	captures []capture
+}
+
+type capture struct {
+	// group is the index of the capture group in the regular expression
+	group int
+	name  string
+	typ   string
+}
+
+// Compile compiles a grok pattern. The given definitions take precedence over the standard ones.
+// If namedCapturesOnly is false, the values of the references without a semantic are
+// captured under the name of their syntax.
+func Compile(pattern string, definitions map[string]string, namedCapturesOnly bool) (*Regexp, error) {
+	for name := range definitions {
+		if !nameRegexp.MatchString(name) {
+			return nil, fmt.Errorf("invalid grok pattern name %q", name)
+		}
+	}
+
+	c := &compiler{
+		definitions:       definitions,
+		namedCapturesOnly: namedCapturesOnly,
+		visiting:          map[string]bool{},
+	}
+	expanded, err := c.expand(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := regexp.Compile(expanded)
+	if err != nil {
+		return nil, fmt.Errorf("compiling grok pattern: %w", err)
+	}
+
+	captures := make([]capture, 0, len(c.captures))
+	for i, group := range r.SubexpNames() {
+		if capt, ok := c.captures[group]; ok {
+			capt.group = i
+			captures = append(captures, capt)
+		}
+	}
+	return &Regexp{regexp: r, captures: captures}, nil
+}
+
+// Names returns the names of the captured values, in the order of the pattern.
+// A name is repeated if it is captured by several parts of the pattern.
+func (r *Regexp) Names() []string {
+	names := make([]string, 0, len(r.captures))
+	for _, c := range r.captures {
+		names = append(names, c.name)
+	}
+	return names
+}
+
+// String returns the regular expression the grok pattern is compiled to.
+func (r *Regexp) String() string {
+	return r.regexp.String()
+}
+
+// Match returns the values captured from the first match of the pattern in the given string.
+// The values are strings, or int64 and float64 when converted to the int and float types.
+// The empty captures are not returned, and when a name is captured several times, its first
+// non-empty value is returned. The second return value is false if the pattern does not match.
+func (r *Regexp) Match(s string) (map[string]any, bool) {
+	matches := r.regexp.FindStringSubmatchIndex(s)
+	if matches == nil {
+		return nil, false
+	}
+
+	values := make(map[string]any, len(r.captures))
+	for _, c := range r.captures {
+		start, end := matches[2*c.group], matches[2*c.group+1]
+		if start < 0 || start == end {
+			continue
+		}
+		if _, ok := values[c.name]; ok {
+			continue
+		}
+		values[c.name] = convert(s[start:end], c.typ)
+	}
+	return values, true
+}
+
+// convert converts a captured value to its type. The value is kept as a string if it cannot be converted.
+func convert(value string, typ string) any {
+	switch typ {
+	case TypeInt:
+		if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return i
+		}
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return int64(f)
+		}
+	case TypeFloat:
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	}
+	return value
+}
+
+// ParseDefinitions parses pattern definitions in the format of the Logstash pattern files,
+// where each line is the name of a pattern followed by a space and its definition.
+// The empty lines and the lines starting with '#' are ignored.
+func ParseDefinitions(text string) (map[string]string, error) {
+	definitions := map[string]string{}
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, definition, ok := strings.Cut(line, " ")
+		definition = strings.TrimSpace(definition)
+		if !ok || definition == "" {
+			return nil, fmt.Errorf("invalid grok pattern definition %q: expected a name followed by a space and a pattern", line)
+		}
+		if !nameRegexp.MatchString(name) {
+			return nil, fmt.Errorf("invalid grok pattern name %q", name)
+		}
+		definitions[name] = definition
+	}
+	return definitions, scanner.Err()
+}
+
+func mustParseDefinitions(text string) map[string]string {
+	definitions, err := ParseDefinitions(text)
+	if err != nil {
+		panic(err)
+	}
+	return definitions
+}
+
+type compiler struct {
+	definitions       map[string]string
+	namedCapturesOnly bool
+
+	// visiting are the names of the definitions being expanded, to detect circular references
+	visiting map[string]bool
+	// captures are the captures by the name of their group in the regular expression
+	captures map[string]capture
+}
+
+// expand replaces the references of a pattern by their definitions, and the named capture groups
+// by generated names, since the captured names are not required to be valid group names.
+func (c *compiler) expand(pattern string) (string, error) {
+	var expanded strings.Builder
+	last := 0
+	for _, m := range referenceRegexp.FindAllStringSubmatchIndex(pattern, -1) {
+		expanded.WriteString(pattern[last:m[0]])
+		last = m[1]
+
+		if m[8] >= 0 {
+			// a named capture group, whose content is expanded with the rest of the pattern
+			expanded.WriteString("(?P<" + c.addCapture(pattern[m[8]:m[9]], "") + ">")
+			continue
+		}
+
+		syntax := pattern[m[2]:m[3]]
+		var semantic, typ string
+		if m[4] >= 0 {
+			semantic = pattern[m[4]:m[5]]
+		}
+		if m[6] >= 0 {
+			typ = pattern[m[6]:m[7]]
+			if typ != TypeInt && typ != TypeFloat {
+				return "", fmt.Errorf("unsupported type %q in grok pattern reference %q, supported types are %q and %q", typ, pattern[m[0]:m[1]], TypeInt, TypeFloat)
+			}
+		}
+
+		definition, err := c.expandDefinition(syntax)
+		if err != nil {
+			return "", err
+		}
+
+		if semantic == "" && !c.namedCapturesOnly {
+			semantic = syntax
+		}
+		if semantic == "" {
+			expanded.WriteString("(?:" + definition + ")")
+			continue
+		}
+		expanded.WriteString("(?P<" + c.addCapture(semantic, typ) + ">" + definition + ")")
+	}
+	expanded.WriteString(pattern[last:])
+	return expanded.String(), nil
+}
+
+func (c *compiler) expandDefinition(name string) (string, error) {
+	definition, ok := c.definitions[name]
+	if !ok {
+		if definition, ok = standardDefinitions[name]; !ok {
+			return "", fmt.Errorf("unknown grok pattern %q", name)
+		}
+	}
+	if c.visiting[name] {
+		return "", fmt.Errorf("circular reference in grok pattern %q", name)
+	}
+	c.visiting[name] = true
+	defer delete(c.visiting, name)
+
+	expanded, err := c.expand(definition)
+	if err != nil {
+		return "", fmt.Errorf("expanding grok pattern %q: %w", name, err)
+	}
+	return expanded, nil
+}
+
+func (c *compiler) addCapture(name string, typ string) string {
+	if c.captures == nil {
+		c.captures = map[string]capture{}
+	}
+	group := "grok" + strconv.Itoa(len(c.captures))
+	c.captures[group] = capture{name: name, typ: typ}
+	return group
+}
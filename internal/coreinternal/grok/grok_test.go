@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package grok
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStandardPatternsCompile(t *testing.T) {
+	require.NotEmpty(t, standardDefinitions)
+	for name := range standardDefinitions {
+		_, err := Compile("%{"+name+"}", nil, true)
+		assert.NoError(t, err, name)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	testCases := []struct {
+		name              string
+		pattern           string
+		definitions       map[string]string
+		namedCapturesOnly bool
+		input             string
+		expected          map[string]any
+	}{
+		{
+			name:              "combined_apache_log",
+			pattern:           "%{COMBINEDAPACHELOG}",
+			namedCapturesOnly: true,
+			input:             `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326 "http://www.example.com/start.html" "Mozilla/4.08 [en] (Win98; I ;Nav)"`,
+			expected: map[string]any{
+				"clientip":    "127.0.0.1",
+				"ident":       "-",
+				"auth":        "frank",
+				"timestamp":   "10/Oct/2000:13:55:36 -0700",
+				"verb":        "GET",
+				"request":     "/apache_pb.gif",
+				"httpversion": "1.0",
+				"response":    "200",
+				"bytes":       "2326",
+				"referrer":    `"http://www.example.com/start.html"`,
+				"agent":       `"Mozilla/4.08 [en] (Win98; I ;Nav)"`,
+			},
+		},
+		{
+			name:              "syslog_base",
+			pattern:           "%{SYSLOGBASE} %{GREEDYDATA:message}",
+			namedCapturesOnly: true,
+			input:             "Mar  7 00:00:01 myhost sshd[1234]: Accepted publickey for alice from 10.0.0.1 port 22 ssh2",
+			expected: map[string]any{
+				"timestamp": "Mar  7 00:00:01",
+				"logsource": "myhost",
+				"program":   "sshd",
+				"pid":       "1234",
+				"message":   "Accepted publickey for alice from 10.0.0.1 port 22 ssh2",
+			},
+		},
+		{
+			name:              "types",
+			pattern:           "%{WORD:method} %{NUMBER:status:int} %{NUMBER:duration:float} %{NUMBER:size:int}",
+			namedCapturesOnly: true,
+			input:             "GET 200 0.25 1.5",
+			expected: map[string]any{
+				"method":   "GET",
+				"status":   int64(200),
+				"duration": 0.25,
+				"size":     int64(1),
+			},
+		},
+		{
+			name:              "custom_definitions",
+			pattern:           "%{QUEUE:queue} %{TIMESTAMP_ISO8601:time}",
+			definitions:       map[string]string{"QUEUE": `queue-%{INT}`, "HOUR": `(?:[01][0-9]|2[0-3])`},
+			namedCapturesOnly: true,
+			input:             "queue-12 2024-03-04T10:15:30Z",
+			expected: map[string]any{
+				"queue": "queue-12",
+				"time":  "2024-03-04T10:15:30Z",
+			},
+		},
+		{
+			name:              "named_capture_groups",
+			pattern:           `(?<http.request.method>[A-Z]+) (?P<url.path>\S+) %{INT:http.response.status_code:int}`,
+			namedCapturesOnly: true,
+			input:             "GET /index.html 404",
+			expected: map[string]any{
+				"http.request.method":       "GET",
+				"url.path":                  "/index.html",
+				"http.response.status_code": int64(404),
+			},
+		},
+		{
+			name:    "unnamed_captures",
+			pattern: "%{WORD} %{INT:code}",
+			input:   "error 42",
+			expected: map[string]any{
+				"WORD": "error",
+				"code": "42",
+			},
+		},
+		{
+			name:              "alternatives_and_empty_captures",
+			pattern:           `%{INT:id}%{SPACE:space}-(?:%{IPV4:address}|%{HOSTNAME:address})`,
+			namedCapturesOnly: true,
+			input:             "1-example.com",
+			expected: map[string]any{
+				"id":      "1",
+				"address": "example.com",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := Compile(tc.pattern, tc.definitions, tc.namedCapturesOnly)
+			require.NoError(t, err)
+
+			values, ok := r.Match(tc.input)
+			require.True(t, ok)
+			assert.Equal(t, tc.expected, values)
+		})
+	}
+}
+
+func TestNoMatch(t *testing.T) {
+	r, err := Compile("^%{IPV4:ip}$", nil, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ip"}, r.Names())
+
+	_, ok := r.Match("10.0.0.256")
+	assert.False(t, ok)
+}
+
+func TestCompileErrors(t *testing.T) {
+	testCases := []struct {
+		name        string
+		pattern     string
+		definitions map[string]string
+		err         string
+	}{
+		{
+			name:    "unknown_pattern",
+			pattern: "%{WORD:a} %{UNKNOWN:b}",
+			err:     `unknown grok pattern "UNKNOWN"`,
+		},
+		{
+			name:        "unknown_nested_pattern",
+			pattern:     "%{OUTER}",
+			definitions: map[string]string{"OUTER": "%{INNER}"},
+			err:         `expanding grok pattern "OUTER": unknown grok pattern "INNER"`,
+		},
+		{
+			name:        "circular_reference",
+			pattern:     "%{A}",
+			definitions: map[string]string{"A": "a%{B}", "B": "b%{A}"},
+			err:         `circular reference in grok pattern "A"`,
+		},
+		{
+			name:    "unsupported_type",
+			pattern: "%{INT:count:long}",
+			err:     `unsupported type "long" in grok pattern reference "%{INT:count:long}"`,
+		},
+		{
+			name:        "invalid_name",
+			pattern:     "%{WORD}",
+			definitions: map[string]string{"MY-PATTERN": "x"},
+			err:         `invalid grok pattern name "MY-PATTERN"`,
+		},
+		{
+			name:    "invalid_regex",
+			pattern: "%{WORD:a}(",
+			err:     "compiling grok pattern",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Compile(tc.pattern, tc.definitions, true)
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestParseDefinitions(t *testing.T) {
+	definitions, err := ParseDefinitions(`
+# queue patterns
+QUEUE queue-%{INT}
+  QUEUE_LINE   %{QUEUE:queue} %{GREEDYDATA:message}
+`)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"QUEUE":      "queue-%{INT}",
+		"QUEUE_LINE": "%{QUEUE:queue} %{GREEDYDATA:message}",
+	}, definitions)
+
+	_, err = ParseDefinitions("QUEUE")
+	assert.EqualError(t, err, `invalid grok pattern definition "QUEUE": expected a name followed by a space and a pattern`)
+
+	_, err = ParseDefinitions("QUEUE-1 queue")
+	assert.EqualError(t, err, `invalid grok pattern name "QUEUE-1"`)
+}
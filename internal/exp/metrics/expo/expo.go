@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package expo implements the operations on the buckets of exponential
+// histograms, as defined in
+// https://opentelemetry.io/docs/specs/otel/metrics/data-model/#exponentialhistogram
+package expo // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/expo"
+
+import (
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+type (
+	DataPoint = pmetric.ExponentialHistogramDataPoint
+	Buckets   = pmetric.ExponentialHistogramDataPointBuckets
+)
+
+// Scale of an exponential histogram. The bucket at index i covers the values
+// in (base^i, base^(i+1)], where base = 2^(2^-scale).
+type Scale int32
+
+// Idx returns the index of the bucket holding the value v at the scale.
+func (scale Scale) Idx(v float64) int {
+	// ceil(log_base(v)) - 1, log_base(v) being log2(v) * 2^scale
+	return int(math.Ceil(math.Ldexp(math.Log2(v), int(scale)))) - 1
+}
+
+// Bounds returns the lower and upper bounds of the bucket at the index.
+func (scale Scale) Bounds(index int) (min, max float64) {
+	lower := func(index int) float64 {
+		return math.Exp2(math.Ldexp(float64(index), -int(scale)))
+	}
+	return lower(index), lower(index + 1)
+}
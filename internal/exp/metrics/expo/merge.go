@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package expo // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/expo"
+
+// Merge adds the counts of the buckets of in to those of dp, aligning their
+// offsets. Both buckets must be at the same scale.
+func Merge(dp, in Buckets) {
+	if in.BucketCounts().Len() == 0 {
+		return
+	}
+	if dp.BucketCounts().Len() == 0 {
+		in.CopyTo(dp)
+		return
+	}
+
+	lower := min(dp.Offset(), in.Offset())
+	upper := max(dp.Offset()+int32(dp.BucketCounts().Len()), in.Offset()+int32(in.BucketCounts().Len()))
+
+	counts := make([]uint64, upper-lower)
+	for _, bs := range []Buckets{dp, in} {
+		for i := 0; i < bs.BucketCounts().Len(); i++ {
+			counts[bs.Offset()-lower+int32(i)] += bs.BucketCounts().At(i)
+		}
+	}
+	dp.SetOffset(lower)
+	dp.BucketCounts().FromRaw(counts)
+}
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package expo_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/expo"
+)
+
+func TestMerge(t *testing.T) {
+	cases := []struct {
+		name   string
+		dp, in expo.Buckets
+		want   expo.Buckets
+	}{{
+		name: "aligned",
+		dp:   buckets(1, 1, 2, 3),
+		in:   buckets(1, 1, 1, 1),
+		want: buckets(1, 2, 3, 4),
+	}, {
+		name: "in after",
+		dp:   buckets(0, 1, 2),
+		in:   buckets(3, 1, 1),
+		want: buckets(0, 1, 2, 0, 1, 1),
+	}, {
+		name: "in before",
+		dp:   buckets(2, 1, 2),
+		in:   buckets(-1, 5, 0, 0, 4),
+		want: buckets(-1, 5, 0, 0, 5, 2),
+	}, {
+		name: "empty dp",
+		dp:   buckets(0),
+		in:   buckets(4, 1),
+		want: buckets(4, 1),
+	}, {
+		name: "empty in",
+		dp:   buckets(4, 1),
+		in:   buckets(0),
+		want: buckets(4, 1),
+	}}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			expo.Merge(c.dp, c.in)
+			require.Equal(t, c.want, c.dp)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package expo // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/expo"
+
+import "fmt"
+
+// Downscale the buckets from the scale from to the lower scale to. Each bucket
+// at the lower scale holds the counts of the 2^(from-to) buckets it covers.
+func Downscale(bs Buckets, from, to Scale) {
+	switch {
+	case from == to:
+		return
+	case from < to:
+		// Upscaling would require to split the counts of the buckets.
+		panic(fmt.Sprintf("cannot upscale buckets from scale %d to %d", from, to))
+	}
+
+	counts := bs.BucketCounts()
+	if counts.Len() == 0 {
+		return
+	}
+
+	shift := int32(from - to)
+	offset := bs.Offset()
+	lower := offset >> shift
+	upper := (offset + int32(counts.Len()) - 1) >> shift
+
+	merged := make([]uint64, upper-lower+1)
+	for i := 0; i < counts.Len(); i++ {
+		// The arithmetic shift floors the negative indexes as well.
+		merged[((offset+int32(i))>>shift)-lower] += counts.At(i)
+	}
+	bs.SetOffset(lower)
+	counts.FromRaw(merged)
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package expo_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/expo"
+)
+
+func buckets(offset int32, counts ...uint64) expo.Buckets {
+	bs := pmetric.NewExponentialHistogramDataPointBuckets()
+	bs.SetOffset(offset)
+	bs.BucketCounts().FromRaw(counts)
+	return bs
+}
+
+func TestDownscale(t *testing.T) {
+	cases := []struct {
+		name     string
+		from, to expo.Scale
+		in, want expo.Buckets
+	}{{
+		name: "same scale",
+		from: 2, to: 2,
+		in:   buckets(3, 1, 2, 3),
+		want: buckets(3, 1, 2, 3),
+	}, {
+		name: "one scale",
+		from: 1, to: 0,
+		// indexes 2..5 => 1,1,2,2
+		in:   buckets(2, 1, 2, 3, 4),
+		want: buckets(1, 3, 7),
+	}, {
+		name: "odd offset",
+		from: 1, to: 0,
+		// indexes 3..6 => 1,2,2,3
+		in:   buckets(3, 1, 2, 3, 4),
+		want: buckets(1, 1, 5, 4),
+	}, {
+		name: "negative offset",
+		from: 2, to: 0,
+		// indexes -5..-2 => -2,-1,-1,-1
+		in:   buckets(-5, 1, 2, 3, 4),
+		want: buckets(-2, 1, 9),
+	}, {
+		name: "empty",
+		from: 3, to: 1,
+		in:   buckets(4),
+		want: buckets(4),
+	}}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			expo.Downscale(c.in, c.from, c.to)
+			require.Equal(t, c.want, c.in)
+		})
+	}
+
+	require.Panics(t, func() {
+		expo.Downscale(buckets(0, 1), 0, 1)
+	})
+}
+
+func TestScaleBounds(t *testing.T) {
+	cases := []struct {
+		scale    expo.Scale
+		value    float64
+		index    int
+		min, max float64
+	}{
+		{scale: 0, value: 1, index: -1, min: 0.5, max: 1},
+		{scale: 0, value: 3, index: 1, min: 2, max: 4},
+		{scale: 0, value: 4, index: 1, min: 2, max: 4},
+		{scale: 1, value: 3, index: 3, min: 2.8284271247461903, max: 4},
+		{scale: -1, value: 3, index: 0, min: 1, max: 4},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("scale=%d,value=%v", c.scale, c.value), func(t *testing.T) {
+			index := c.scale.Idx(c.value)
+			require.Equal(t, c.index, index)
+			min, max := c.scale.Bounds(index)
+			require.InDelta(t, c.min, min, 1e-12)
+			require.InDelta(t, c.max, max, 1e-12)
+		})
+	}
+}
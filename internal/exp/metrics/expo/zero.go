@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package expo // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/expo"
+
+import "fmt"
+
+// WidenZero widens the zero bucket of the point to hold at least the values up
+// to width. The buckets below the new threshold are moved into the zero bucket,
+// and the threshold is raised to the upper bound of the last bucket moved, so
+// that no bucket is split.
+func WidenZero(dp DataPoint, width float64) {
+	switch {
+	case width == dp.ZeroThreshold():
+		return
+	case width < dp.ZeroThreshold():
+		panic(fmt.Sprintf("cannot narrow the zero bucket from %f to %f", dp.ZeroThreshold(), width))
+	}
+
+	scale := Scale(dp.Scale())
+	// the largest index of the buckets moved into the zero bucket
+	zero := int32(scale.Idx(width))
+
+	widen := func(bs Buckets) {
+		counts := bs.BucketCounts()
+		moved := min(int(zero-bs.Offset()+1), counts.Len())
+		if moved <= 0 {
+			return
+		}
+		for i := 0; i < moved; i++ {
+			dp.SetZeroCount(dp.ZeroCount() + counts.At(i))
+		}
+		counts.FromRaw(counts.AsRaw()[moved:])
+		bs.SetOffset(bs.Offset() + int32(moved))
+	}
+	widen(dp.Positive())
+	widen(dp.Negative())
+
+	_, upper := scale.Bounds(int(zero))
+	dp.SetZeroThreshold(upper)
+}
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package expo_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/expo"
+)
+
+func TestWidenZero(t *testing.T) {
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	dp.SetScale(0)
+	dp.SetZeroThreshold(0.5)
+	dp.SetZeroCount(1)
+	// buckets (1,2], (2,4], (4,8], (8,16]
+	buckets(0, 1, 2, 3, 4).CopyTo(dp.Positive())
+	// buckets (-2,-1], (-4,-2]
+	buckets(0, 5, 6).CopyTo(dp.Negative())
+
+	// 3 is in (2,4], so both (1,2] and (2,4] are moved into the zero bucket
+	expo.WidenZero(dp, 3)
+	require.Equal(t, 4.0, dp.ZeroThreshold())
+	require.Equal(t, uint64(1+1+2+5+6), dp.ZeroCount())
+	require.Equal(t, buckets(2, 3, 4), dp.Positive())
+	require.Equal(t, int32(2), dp.Negative().Offset())
+	require.Zero(t, dp.Negative().BucketCounts().Len())
+
+	// widening to the current width changes nothing
+	expo.WidenZero(dp, 4)
+	require.Equal(t, 4.0, dp.ZeroThreshold())
+	require.Equal(t, buckets(2, 3, 4), dp.Positive())
+
+	require.Panics(t, func() {
+		expo.WidenZero(dp, 1)
+	})
+}
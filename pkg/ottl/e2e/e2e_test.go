@@ -34,6 +34,22 @@ func Test_e2e_editors(t *testing.T) {
 		statement string
 		want      func(tCtx ottllog.TransformContext)
 	}{
+		{
+			statement: `append(attributes["test"], "pass")`,
+			want: func(tCtx ottllog.TransformContext) {
+				s := tCtx.GetLogRecord().Attributes().PutEmptySlice("test")
+				s.AppendEmpty().SetStr("pass")
+			},
+		},
+		{
+			statement: `append(attributes["http.method"], values = ["pass", 1])`,
+			want: func(tCtx ottllog.TransformContext) {
+				s := tCtx.GetLogRecord().Attributes().PutEmptySlice("http.method")
+				s.AppendEmpty().SetStr("get")
+				s.AppendEmpty().SetStr("pass")
+				s.AppendEmpty().SetInt(1)
+			},
+		},
 		{
 			statement: `delete_key(attributes, "http.method")`,
 			want: func(tCtx ottllog.TransformContext) {
@@ -255,12 +271,36 @@ func Test_e2e_converters(t *testing.T) {
 				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
 			},
 		},
+		{
+			statement: `set(attributes["test"], Base64Encode("pass"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "cGFzcw==")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Base64Encode("pass?>", "base64-raw-url"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "cGFzcz8-")
+			},
+		},
 		{
 			statement: `set(attributes["test"], Concat(["A","B"], ":"))`,
 			want: func(tCtx ottllog.TransformContext) {
 				tCtx.GetLogRecord().Attributes().PutStr("test", "A:B")
 			},
 		},
+		{
+			statement: `set(attributes["test"], Decode("cGFzcw==", "base64"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Decode("pass", "ISO-8859-1"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
+			},
+		},
 		{
 			statement: `set(attributes["test"], ConvertCase(attributes["http.method"], "upper"))`,
 			want: func(tCtx ottllog.TransformContext) {
@@ -315,6 +355,20 @@ func Test_e2e_converters(t *testing.T) {
 				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
 			},
 		},
+		{
+			statement: `set(attributes["test"], ExtractGrokPatterns("aa123bb", "%{INT:numbers:int}"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				m := tCtx.GetLogRecord().Attributes().PutEmptyMap("test")
+				m.PutInt("numbers", 123)
+			},
+		},
+		{
+			statement: `set(attributes["test"], ExtractGrokPatterns("queue-12", "%{QUEUE:queue}", true, ["QUEUE=queue-%{INT}"]))`,
+			want: func(tCtx ottllog.TransformContext) {
+				m := tCtx.GetLogRecord().Attributes().PutEmptyMap("test")
+				m.PutStr("queue", "queue-12")
+			},
+		},
 		{
 			statement: `set(attributes["test"], ExtractPatterns("aa123bb", "(?P<numbers>\\d+)"))`,
 			want: func(tCtx ottllog.TransformContext) {
@@ -328,12 +382,30 @@ func Test_e2e_converters(t *testing.T) {
 				tCtx.GetLogRecord().Attributes().PutInt("test", 266877920130663416)
 			},
 		},
+		{
+			statement: `set(attributes["test"], FormatTime(time, "%Y-%m-%d %H:%M:%S.%L"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "2020-02-11 20:26:12.000")
+			},
+		},
+		{
+			statement: `set(attributes["test"], FormatTime(time, "%A %d %B", "Asia/Tokyo", "fr"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "mercredi 12 février")
+			},
+		},
 		{
 			statement: `set(attributes["test"], Hour(Time("12", "%H")))`,
 			want: func(tCtx ottllog.TransformContext) {
 				tCtx.GetLogRecord().Attributes().PutInt("test", 12)
 			},
 		},
+		{
+			statement: `set(attributes["test"], Hours(Duration("PT1H30M")))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutDouble("test", 1.5)
+			},
+		},
 		{
 			statement: `set(attributes["test"], Hours(Duration("90m")))`,
 			want: func(tCtx ottllog.TransformContext) {
@@ -376,6 +448,24 @@ func Test_e2e_converters(t *testing.T) {
 				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
 			},
 		},
+		{
+			statement: `set(attributes["test"], Index(Split(attributes["flags"], "|"), "B"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutInt("test", 1)
+			},
+		},
+		{
+			statement: `set(attributes["test"], "pass") where IsInCIDR("10.1.2.3", ["10.0.0.0/8"])`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
+			},
+		},
+		{
+			statement: `set(attributes["test"], "pass") where IsList(Split(attributes["flags"], "|"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
+			},
+		},
 		{
 			statement: `set(attributes["test"], "pass") where IsMap(attributes["foo"])`,
 			want: func(tCtx ottllog.TransformContext) {
@@ -394,6 +484,12 @@ func Test_e2e_converters(t *testing.T) {
 				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
 			},
 		},
+		{
+			statement: `set(attributes["test"], Join(Split(attributes["flags"], "|"), ","))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "A,B,C")
+			},
+		},
 		{
 			statement: `set(attributes["test"], Len(attributes["foo"]))`,
 			want: func(tCtx ottllog.TransformContext) {
@@ -519,6 +615,53 @@ func Test_e2e_converters(t *testing.T) {
 				tCtx.GetLogRecord().Attributes().PutStr("test", "d74ff0ee8da3b9806b18c877dbf29bbde50b5bd8e4dad7a3a725000feb82e8f1")
 			},
 		},
+		{
+			statement: `set(attributes["test"], SHA512("pass"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "5b722b307fce6c944905d132691d5e4a2214b7fe92b738920eb3fce3a90420a19511c3010a0e7712b054daef5b57bad59ecbd93b3280f210578f547f4aed4d25")
+			},
+		},
+		{
+			statement: `set(attributes["test"], MD5("pass"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "1a1dc91c907325c69271ddf0c944bc72")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Murmur3Hash("Hello World"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "ce837619")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Murmur3Hash128("Hello World"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "dbc2a0c1ab26631a27b4c09fcf1fe683")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Hex(attributes["http.method"]))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "676574")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Slice(Split(attributes["flags"], "|"), 1))`,
+			want: func(tCtx ottllog.TransformContext) {
+				s := tCtx.GetLogRecord().Attributes().PutEmptySlice("test")
+				s.AppendEmpty().SetStr("B")
+				s.AppendEmpty().SetStr("C")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Sort(Split(attributes["flags"], "|"), "desc"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				s := tCtx.GetLogRecord().Attributes().PutEmptySlice("test")
+				s.AppendEmpty().SetStr("C")
+				s.AppendEmpty().SetStr("B")
+				s.AppendEmpty().SetStr("A")
+			},
+		},
 		{
 			statement: `set(span_id, SpanID(0x0000000000000000))`,
 			want: func(tCtx ottllog.TransformContext) {
@@ -552,6 +695,46 @@ func Test_e2e_converters(t *testing.T) {
 				tCtx.GetLogRecord().SetTimestamp(pcommon.NewTimestampFromTime(TestLogTimestamp.AsTime().Truncate(time.Second)))
 			},
 		},
+		{
+			statement: `set(attributes["test"], Unique(["A", "B", "A", 1, 1]))`,
+			want: func(tCtx ottllog.TransformContext) {
+				s := tCtx.GetLogRecord().Attributes().PutEmptySlice("test")
+				s.AppendEmpty().SetStr("A")
+				s.AppendEmpty().SetStr("B")
+				s.AppendEmpty().SetInt(1)
+			},
+		},
+		{
+			statement: `set(attributes["test"], URL("https://example.com:8443/index.html?lang=en"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				m := tCtx.GetLogRecord().Attributes().PutEmptyMap("test")
+				m.PutStr("url.original", "https://example.com:8443/index.html?lang=en")
+				m.PutStr("url.scheme", "https")
+				m.PutStr("url.domain", "example.com")
+				m.PutInt("url.port", 8443)
+				m.PutStr("url.path", "/index.html")
+				m.PutStr("url.query", "lang=en")
+				m.PutStr("url.extension", "html")
+			},
+		},
+		{
+			statement: `set(attributes["test"], UserAgent("curl/7.81.0")["user_agent.name"])`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "curl")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Concat([Year(time), Month(time), Day(time), Weekday(time)], "-"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "2020-2-11-2")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Concat([Minute(time), Second(time), Nanosecond(time)], ":"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "26:12:321")
+			},
+		},
 		{
 			statement: `set(attributes["test"], "pass") where UnixMicro(time) > 0`,
 			want: func(tCtx ottllog.TransformContext) {
@@ -4,7 +4,9 @@
 package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
 	"encoding/hex"
 	"fmt"
 	"reflect"
@@ -373,6 +375,66 @@ func (g StandardPMapGetter[K]) Get(ctx context.Context, tCtx K) (pcommon.Map, er
 	}
 }
 
+// PSliceGetter is a Getter that must return a pcommon.Slice.
+type PSliceGetter[K any] interface {
+	// Get retrieves a pcommon.Slice value.
+	Get(ctx context.Context, tCtx K) (pcommon.Slice, error)
+}
+
+// StandardPSliceGetter is a basic implementation of PSliceGetter
+type StandardPSliceGetter[K any] struct {
+	Getter func(ctx context.Context, tCtx K) (any, error)
+}
+
+// Get retrieves a pcommon.Slice value.
+// The Go slices, such as the values returned by converters, are converted to a new pcommon.Slice.
+// If the value is not a slice a new TypeError is returned.
+// If there is an error getting the value it will be returned.
+func (g StandardPSliceGetter[K]) Get(ctx context.Context, tCtx K) (pcommon.Slice, error) {
+	val, err := g.Getter(ctx, tCtx)
+	if err != nil {
+		return pcommon.Slice{}, fmt.Errorf("error getting value in %T: %w", g, err)
+	}
+	if val == nil {
+		return pcommon.Slice{}, TypeError("expected pcommon.Slice but got nil")
+	}
+	switch v := val.(type) {
+	case pcommon.Slice:
+		return v, nil
+	case pcommon.Value:
+		if v.Type() == pcommon.ValueTypeSlice {
+			return v.Slice(), nil
+		}
+		return pcommon.Slice{}, TypeError(fmt.Sprintf("expected pcommon.Slice but got %v", v.Type()))
+	case []any:
+		s := pcommon.NewSlice()
+		err = s.FromRaw(v)
+		if err != nil {
+			return pcommon.Slice{}, err
+		}
+		return s, nil
+	case []string:
+		return toPSlice(v, pcommon.Value.SetStr), nil
+	case []int64:
+		return toPSlice(v, pcommon.Value.SetInt), nil
+	case []float64:
+		return toPSlice(v, pcommon.Value.SetDouble), nil
+	case []bool:
+		return toPSlice(v, pcommon.Value.SetBool), nil
+	default:
+		return pcommon.Slice{}, TypeError(fmt.Sprintf("expected pcommon.Slice but got %T", val))
+	}
+}
+
+func toPSlice[T any](values []T, set func(pcommon.Value, T)) pcommon.Slice {
+	s := pcommon.NewSlice()
+	s.EnsureCapacity(len(values))
+	for _, v := range values {
+		set(s.AppendEmpty(), v)
+	}
+	return s
+}
+
 // StringLikeGetter is a Getter that returns a string by converting the underlying value to a string if necessary.
 type StringLikeGetter[K any] interface {
 	// Get retrieves a string value.
@@ -421,6 +483,64 @@ func (g StandardStringLikeGetter[K]) Get(ctx context.Context, tCtx K) (*string,
 	return &result, nil
 }
 
+// ByteSliceLikeGetter is a Getter that returns a []byte by converting the underlying value to a []byte if necessary.
+type ByteSliceLikeGetter[K any] interface {
+	// Get retrieves a []byte value.
+	// The expectation is that the underlying value is converted to a []byte if possible.
+	// Strings are converted to their UTF-8 bytes, ints and floats to their 8 bytes big-endian representation
+	// and bools to a single byte.
+	// If the value cannot be converted to a []byte, nil and an error are returned.
+	// If the value is nil, nil is returned without an error.
+	Get(ctx context.Context, tCtx K) ([]byte, error)
+}
+
+type StandardByteSliceLikeGetter[K any] struct {
+	Getter func(ctx context.Context, tCtx K) (any, error)
+}
+
+func (g StandardByteSliceLikeGetter[K]) Get(ctx context.Context, tCtx K) ([]byte, error) {
+	val, err := g.Getter(ctx, tCtx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting value in %T: %w", g, err)
+	}
+	if val == nil {
+		return nil, nil
+	}
+	switch v := val.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	case int64, float64, bool:
+		return valueToBytes(v), nil
+	case pcommon.Value:
+		switch v.Type() {
+		case pcommon.ValueTypeBytes:
+			return v.Bytes().AsRaw(), nil
+		case pcommon.ValueTypeStr:
+			return []byte(v.Str()), nil
+		case pcommon.ValueTypeInt:
+			return valueToBytes(v.Int()), nil
+		case pcommon.ValueTypeDouble:
+			return valueToBytes(v.Double()), nil
+		case pcommon.ValueTypeBool:
+			return valueToBytes(v.Bool()), nil
+		default:
+			return nil, TypeError(fmt.Sprintf("unsupported value type: %v", v.Type()))
+		}
+	default:
+		return nil, TypeError(fmt.Sprintf("unsupported type: %T", v))
+	}
+}
+
+// valueToBytes returns the big-endian representation of an int64, a float64 or a bool.
+func valueToBytes(v any) []byte {
+	var buf bytes.Buffer
+	// Writing fixed-size values to a bytes.Buffer cannot fail.
+	_ = binary.Write(&buf, binary.BigEndian, v)
+	return buf.Bytes()
+}
+
 // FloatLikeGetter is a Getter that returns a float64 by converting the underlying value to a float64 if necessary.
 type FloatLikeGetter[K any] interface {
 	// Get retrieves a float64 value.
@@ -941,6 +941,177 @@ func Test_StandardStringLikeGetter_WrappedError(t *testing.T) {
 	assert.False(t, ok)
 }
 
+func Test_StandardByteSliceLikeGetter(t *testing.T) {
+	tests := []struct {
+		name             string
+		getter           ByteSliceLikeGetter[any]
+		want             []byte
+		valid            bool
+		expectedErrorMsg string
+	}{
+		{
+			name: "string type",
+			getter: StandardByteSliceLikeGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return "str", nil
+				},
+			},
+			want:  []byte("str"),
+			valid: true,
+		},
+		{
+			name: "[]byte type",
+			getter: StandardByteSliceLikeGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return []byte{1, 2}, nil
+				},
+			},
+			want:  []byte{1, 2},
+			valid: true,
+		},
+		{
+			name: "int64 type",
+			getter: StandardByteSliceLikeGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return int64(1), nil
+				},
+			},
+			want:  []byte{0, 0, 0, 0, 0, 0, 0, 1},
+			valid: true,
+		},
+		{
+			name: "float64 type",
+			getter: StandardByteSliceLikeGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return 1.5, nil
+				},
+			},
+			want:  []byte{0x3f, 0xf8, 0, 0, 0, 0, 0, 0},
+			valid: true,
+		},
+		{
+			name: "bool type",
+			getter: StandardByteSliceLikeGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return true, nil
+				},
+			},
+			want:  []byte{1},
+			valid: true,
+		},
+		{
+			name: "ValueTypeBytes type",
+			getter: StandardByteSliceLikeGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return func() pcommon.Value {
+						v := pcommon.NewValueBytes()
+						v.Bytes().FromRaw([]byte{3, 4})
+						return v
+					}(), nil
+				},
+			},
+			want:  []byte{3, 4},
+			valid: true,
+		},
+		{
+			name: "ValueTypeStr type",
+			getter: StandardByteSliceLikeGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return pcommon.NewValueStr("str"), nil
+				},
+			},
+			want:  []byte("str"),
+			valid: true,
+		},
+		{
+			name: "ValueTypeInt type",
+			getter: StandardByteSliceLikeGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return pcommon.NewValueInt(256), nil
+				},
+			},
+			want:  []byte{0, 0, 0, 0, 0, 0, 1, 0},
+			valid: true,
+		},
+		{
+			name: "ValueTypeDouble type",
+			getter: StandardByteSliceLikeGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return pcommon.NewValueDouble(-2), nil
+				},
+			},
+			want:  []byte{0xc0, 0, 0, 0, 0, 0, 0, 0},
+			valid: true,
+		},
+		{
+			name: "ValueTypeBool type",
+			getter: StandardByteSliceLikeGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return pcommon.NewValueBool(false), nil
+				},
+			},
+			want:  []byte{0},
+			valid: true,
+		},
+		{
+			name: "nil",
+			getter: StandardByteSliceLikeGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return nil, nil
+				},
+			},
+			want:  nil,
+			valid: true,
+		},
+		{
+			name: "ValueTypeMap type",
+			getter: StandardByteSliceLikeGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return pcommon.NewValueMap(), nil
+				},
+			},
+			valid:            false,
+			expectedErrorMsg: "unsupported value type: Map",
+		},
+		{
+			name: "invalid type",
+			getter: StandardByteSliceLikeGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return make(chan int), nil
+				},
+			},
+			valid:            false,
+			expectedErrorMsg: "unsupported type: chan int",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			val, err := tt.getter.Get(context.Background(), nil)
+			if tt.valid {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, val)
+			} else {
+				assert.IsType(t, TypeError(""), err)
+				assert.EqualError(t, err, tt.expectedErrorMsg)
+			}
+		})
+	}
+}
+
+// nolint:errorlint
+func Test_StandardByteSliceLikeGetter_WrappedError(t *testing.T) {
+	getter := StandardByteSliceLikeGetter[any]{
+		Getter: func(_ context.Context, _ any) (any, error) {
+			return nil, TypeError("")
+		},
+	}
+	_, err := getter.Get(context.Background(), nil)
+	assert.Error(t, err)
+	_, ok := err.(TypeError)
+	assert.False(t, ok)
+}
+
 func Test_StandardFloatGetter(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -1769,6 +1940,147 @@ func Test_StandardPMapGetter_WrappedError(t *testing.T) {
 	assert.False(t, ok)
 }
 
+func Test_StandardPSliceGetter(t *testing.T) {
+	tests := []struct {
+		name             string
+		getter           StandardPSliceGetter[any]
+		want             []any
+		valid            bool
+		expectedErrorMsg string
+	}{
+		{
+			name: "pcommon.Slice type",
+			getter: StandardPSliceGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					s := pcommon.NewSlice()
+					s.AppendEmpty().SetStr("a")
+					return s, nil
+				},
+			},
+			want:  []any{"a"},
+			valid: true,
+		},
+		{
+			name: "ValueTypeSlice type",
+			getter: StandardPSliceGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					v := pcommon.NewValueSlice()
+					v.Slice().AppendEmpty().SetInt(1)
+					return v, nil
+				},
+			},
+			want:  []any{int64(1)},
+			valid: true,
+		},
+		{
+			name: "[]any type",
+			getter: StandardPSliceGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return []any{"a", int64(1), 1.5, true}, nil
+				},
+			},
+			want:  []any{"a", int64(1), 1.5, true},
+			valid: true,
+		},
+		{
+			name: "[]string type",
+			getter: StandardPSliceGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return []string{"a", "b"}, nil
+				},
+			},
+			want:  []any{"a", "b"},
+			valid: true,
+		},
+		{
+			name: "[]int64 type",
+			getter: StandardPSliceGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return []int64{1, 2}, nil
+				},
+			},
+			want:  []any{int64(1), int64(2)},
+			valid: true,
+		},
+		{
+			name: "[]float64 type",
+			getter: StandardPSliceGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return []float64{1.5}, nil
+				},
+			},
+			want:  []any{1.5},
+			valid: true,
+		},
+		{
+			name: "[]bool type",
+			getter: StandardPSliceGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return []bool{true}, nil
+				},
+			},
+			want:  []any{true},
+			valid: true,
+		},
+		{
+			name: "Incorrect type",
+			getter: StandardPSliceGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return "str", nil
+				},
+			},
+			valid:            false,
+			expectedErrorMsg: "expected pcommon.Slice but got string",
+		},
+		{
+			name: "Incorrect value type",
+			getter: StandardPSliceGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return pcommon.NewValueMap(), nil
+				},
+			},
+			valid:            false,
+			expectedErrorMsg: "expected pcommon.Slice but got Map",
+		},
+		{
+			name: "nil",
+			getter: StandardPSliceGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return nil, nil
+				},
+			},
+			valid:            false,
+			expectedErrorMsg: "expected pcommon.Slice but got nil",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			val, err := tt.getter.Get(context.Background(), nil)
+			if tt.valid {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, val.AsRaw())
+			} else {
+				assert.IsType(t, TypeError(""), err)
+				assert.EqualError(t, err, tt.expectedErrorMsg)
+			}
+		})
+	}
+}
+
+// nolint:errorlint
+func Test_StandardPSliceGetter_WrappedError(t *testing.T) {
+	getter := StandardPSliceGetter[any]{
+		Getter: func(_ context.Context, _ any) (any, error) {
+			return nil, TypeError("")
+		},
+	}
+	_, err := getter.Get(context.Background(), nil)
+	assert.Error(t, err)
+	_, ok := err.(TypeError)
+	assert.False(t, ok)
+}
+
 func Test_StandardDurationGetter(t *testing.T) {
 	oneHourOneMinuteOneSecond, err := time.ParseDuration("1h1m1s")
 	require.NoError(t, err)
@@ -1235,6 +1235,50 @@ func Test_NewFunctionCall(t *testing.T) {
 			},
 			want: nil,
 		},
+		{
+			name: "pslicegetter arg",
+			inv: editor{
+				Function: "testing_pslicegetter",
+				Arguments: []argument{
+					{
+						Value: value{
+							Literal: &mathExprLiteral{
+								Path: &path{
+									Fields: []field{
+										{
+											Name: "name",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "byteslicelikegetter arg",
+			inv: editor{
+				Function: "testing_byteslicelikegetter",
+				Arguments: []argument{
+					{
+						Value: value{
+							Literal: &mathExprLiteral{
+								Path: &path{
+									Fields: []field{
+										{
+											Name: "name",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: nil,
+		},
 		{
 			name: "string arg",
 			inv: editor{
@@ -1880,6 +1924,26 @@ func functionWithPMapGetter(PMapGetter[any]) (ExprFunc[any], error) {
 	}, nil
 }
 
+type pSliceGetterArguments struct {
+	PSliceArg PSliceGetter[any]
+}
+
+func functionWithPSliceGetter(PSliceGetter[any]) (ExprFunc[any], error) {
+	return func(context.Context, any) (any, error) {
+		return "anything", nil
+	}, nil
+}
+
+type byteSliceLikeGetterArguments struct {
+	ByteSliceLikeGetterArg ByteSliceLikeGetter[any]
+}
+
+func functionWithByteSliceLikeGetter(ByteSliceLikeGetter[any]) (ExprFunc[any], error) {
+	return func(context.Context, any) (any, error) {
+		return "anything", nil
+	}, nil
+}
+
 type stringArguments struct {
 	StringArg string
 }
@@ -2161,6 +2225,16 @@ func defaultFunctionsForTests() map[string]Factory[any] {
 			&pMapGetterArguments{},
 			functionWithPMapGetter,
 		),
+		createFactory[any](
+			"testing_pslicegetter",
+			&pSliceGetterArguments{},
+			functionWithPSliceGetter,
+		),
+		createFactory[any](
+			"testing_byteslicelikegetter",
+			&byteSliceLikeGetterArguments{},
+			functionWithByteSliceLikeGetter,
+		),
 		createFactory[any](
 			"testing_string",
 			&stringArguments{},
@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type AppendArguments[K any] struct {
+	Target ottl.GetSetter[K]
+	Value  ottl.Optional[ottl.Getter[K]]
+	Values ottl.Optional[[]ottl.Getter[K]]
+}
+
+func NewAppendFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("append", &AppendArguments[K]{}, createAppendFunction[K])
+}
+
+func createAppendFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*AppendArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("AppendFactory args must be of type *AppendArguments[K]")
+	}
+
+	return appendTo(args.Target, args.Value, args.Values)
+}
+
+// appendTo appends the value and the values to the target. A target that is not a slice
+// becomes a slice holding its value, and a target that is not set becomes a new slice.
+func appendTo[K any](target ottl.GetSetter[K], value ottl.Optional[ottl.Getter[K]], values ottl.Optional[[]ottl.Getter[K]]) (ottl.ExprFunc[K], error) {
+	if value.IsEmpty() && values.IsEmpty() {
+		return nil, fmt.Errorf("at least one of the optional arguments ('value' or 'values') must be provided")
+	}
+
+	return func(ctx context.Context, tCtx K) (any, error) {
+		t, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		res := pcommon.NewSlice()
+		if t != nil {
+			if err = appendValue(res, t); err != nil {
+				return nil, err
+			}
+		}
+
+		var getters []ottl.Getter[K]
+		if !value.IsEmpty() {
+			getters = append(getters, value.Get())
+		}
+		if !values.IsEmpty() {
+			getters = append(getters, values.Get()...)
+		}
+		for _, getter := range getters {
+			val, err := getter.Get(ctx, tCtx)
+			if err != nil {
+				return nil, err
+			}
+			if err = setSliceValue(res.AppendEmpty(), val); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, target.Set(ctx, tCtx, res)
+	}, nil
+}
+
+// appendValue appends the elements of a slice, or a single value, to res.
+func appendValue(res pcommon.Slice, val any) error {
+	switch v := val.(type) {
+	case pcommon.Slice:
+		v.CopyTo(res)
+		return nil
+	case pcommon.Value:
+		if v.Type() == pcommon.ValueTypeSlice {
+			v.Slice().CopyTo(res)
+			return nil
+		}
+	case []any:
+		return res.FromRaw(v)
+	case []string:
+		appendTyped(res, v)
+		return nil
+	case []int64:
+		appendTyped(res, v)
+		return nil
+	case []float64:
+		appendTyped(res, v)
+		return nil
+	case []bool:
+		appendTyped(res, v)
+		return nil
+	}
+	return setSliceValue(res.AppendEmpty(), val)
+}
+
+func appendTyped[T any](res pcommon.Slice, values []T) {
+	for _, v := range values {
+		// The values of the typed slices are always supported by FromRaw.
+		_ = res.AppendEmpty().FromRaw(v)
+	}
+}
+
+// setSliceValue sets val, as returned by a getter, to the element of a slice.
+func setSliceValue(dst pcommon.Value, val any) error {
+	switch v := val.(type) {
+	case pcommon.Value:
+		v.CopyTo(dst)
+	case pcommon.Map:
+		v.CopyTo(dst.SetEmptyMap())
+	case pcommon.Slice:
+		v.CopyTo(dst.SetEmptySlice())
+	case []string:
+		appendTyped(dst.SetEmptySlice(), v)
+	case []int64:
+		appendTyped(dst.SetEmptySlice(), v)
+	case []float64:
+		appendTyped(dst.SetEmptySlice(), v)
+	case []bool:
+		appendTyped(dst.SetEmptySlice(), v)
+	default:
+		return dst.FromRaw(v)
+	}
+	return nil
+}
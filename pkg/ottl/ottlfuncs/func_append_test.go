@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func literalGetter(v any) ottl.Getter[any] {
+	return &ottl.StandardGetSetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return v, nil
+		},
+	}
+}
+
+func Test_Append(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   any
+		value    ottl.Optional[ottl.Getter[any]]
+		values   ottl.Optional[[]ottl.Getter[any]]
+		expected []any
+	}{
+		{
+			name:     "append value to slice",
+			target:   []any{"a"},
+			value:    ottl.NewTestingOptional(literalGetter("b")),
+			expected: []any{"a", "b"},
+		},
+		{
+			name:     "append values to pcommon.Slice",
+			target:   func() pcommon.Slice { s := pcommon.NewSlice(); s.AppendEmpty().SetInt(1); return s }(),
+			values:   ottl.NewTestingOptional([]ottl.Getter[any]{literalGetter(int64(2)), literalGetter(3.5)}),
+			expected: []any{int64(1), int64(2), 3.5},
+		},
+		{
+			name:     "append value and values",
+			target:   []string{"a"},
+			value:    ottl.NewTestingOptional(literalGetter("b")),
+			values:   ottl.NewTestingOptional([]ottl.Getter[any]{literalGetter("c")}),
+			expected: []any{"a", "b", "c"},
+		},
+		{
+			name:     "append to scalar",
+			target:   "a",
+			value:    ottl.NewTestingOptional(literalGetter(true)),
+			expected: []any{"a", true},
+		},
+		{
+			name:     "append to pcommon.Value slice",
+			target:   func() pcommon.Value { v := pcommon.NewValueSlice(); v.Slice().AppendEmpty().SetStr("a"); return v }(),
+			value:    ottl.NewTestingOptional(literalGetter("b")),
+			expected: []any{"a", "b"},
+		},
+		{
+			name:     "append to nil",
+			target:   nil,
+			value:    ottl.NewTestingOptional(literalGetter("a")),
+			expected: []any{"a"},
+		},
+		{
+			name:     "append slice as an element",
+			target:   []any{"a"},
+			value:    ottl.NewTestingOptional(literalGetter([]string{"b", "c"})),
+			expected: []any{"a", []any{"b", "c"}},
+		},
+		{
+			name:     "append map",
+			target:   []any{},
+			value:    ottl.NewTestingOptional(literalGetter(map[string]any{"k": "v"})),
+			expected: []any{map[string]any{"k": "v"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result pcommon.Slice
+			target := &ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.target, nil
+				},
+				Setter: func(_ context.Context, _ any, val any) error {
+					result = val.(pcommon.Slice)
+					return nil
+				},
+			}
+			exprFunc, err := appendTo[any](target, tt.value, tt.values)
+			require.NoError(t, err)
+			res, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Nil(t, res)
+			assert.Equal(t, tt.expected, result.AsRaw())
+		})
+	}
+}
+
+func Test_Append_NoValue(t *testing.T) {
+	_, err := appendTo[any](&ottl.StandardGetSetter[any]{}, ottl.Optional[ottl.Getter[any]]{}, ottl.Optional[[]ottl.Getter[any]]{})
+	assert.ErrorContains(t, err, "at least one of the optional arguments")
+}
+
+func Test_Append_InvalidValue(t *testing.T) {
+	target := &ottl.StandardGetSetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return []any{}, nil
+		},
+		Setter: func(context.Context, any, any) error {
+			t.Errorf("nothing should be set in this scenario")
+			return nil
+		},
+	}
+	exprFunc, err := appendTo[any](target, ottl.NewTestingOptional(literalGetter(struct{}{})), ottl.Optional[[]ottl.Getter[any]]{})
+	require.NoError(t, err)
+	_, err = exprFunc(context.Background(), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// base64Encodings are the variants of base64 supported by the Base64Encode and Decode converters.
+var base64Encodings = map[string]*base64.Encoding{
+	"base64":         base64.StdEncoding,
+	"base64-raw":     base64.RawStdEncoding,
+	"base64-url":     base64.URLEncoding,
+	"base64-raw-url": base64.RawURLEncoding,
+}
+
+type Base64EncodeArguments[K any] struct {
+	Target  ottl.StringGetter[K]
+	Variant ottl.Optional[string]
+}
+
+func NewBase64EncodeFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Base64Encode", &Base64EncodeArguments[K]{}, createBase64EncodeFunction[K])
+}
+
+func createBase64EncodeFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*Base64EncodeArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("Base64EncodeFactory args must be of type *Base64EncodeArguments[K]")
+	}
+
+	return base64Encode(args.Target, args.Variant)
+}
+
+func base64Encode[K any](target ottl.StringGetter[K], variant ottl.Optional[string]) (ottl.ExprFunc[K], error) {
+	encoding := base64.StdEncoding
+	if !variant.IsEmpty() {
+		var ok bool
+		if encoding, ok = base64Encodings[variant.Get()]; !ok {
+			return nil, fmt.Errorf("unsupported base64 variant %q, must be one of base64, base64-raw, base64-url or base64-raw-url", variant.Get())
+		}
+	}
+
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeToString([]byte(val)), nil
+	}, nil
+}
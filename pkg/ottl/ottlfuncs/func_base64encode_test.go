@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Base64Encode(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		variant  ottl.Optional[string]
+		expected any
+	}{
+		{
+			name:     "default",
+			value:    "hello?>",
+			expected: "aGVsbG8/Pg==",
+		},
+		{
+			name:     "base64",
+			value:    "hello?>",
+			variant:  ottl.NewTestingOptional[string]("base64"),
+			expected: "aGVsbG8/Pg==",
+		},
+		{
+			name:     "base64-raw",
+			value:    "hello?>",
+			variant:  ottl.NewTestingOptional[string]("base64-raw"),
+			expected: "aGVsbG8/Pg",
+		},
+		{
+			name:     "base64-url",
+			value:    "hello?>",
+			variant:  ottl.NewTestingOptional[string]("base64-url"),
+			expected: "aGVsbG8_Pg==",
+		},
+		{
+			name:     "base64-raw-url",
+			value:    "hello?>",
+			variant:  ottl.NewTestingOptional[string]("base64-raw-url"),
+			expected: "aGVsbG8_Pg",
+		},
+		{
+			name:     "empty string",
+			value:    "",
+			expected: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := base64Encode[any](&ottl.StandardStringGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.value, nil
+				},
+			}, tt.variant)
+			require.NoError(t, err)
+			result, err := exprFunc(context.Background(), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_Base64EncodeError(t *testing.T) {
+	target := &ottl.StandardStringGetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return 10, nil
+		},
+	}
+
+	_, err := base64Encode[any](target, ottl.NewTestingOptional[string]("base32"))
+	assert.ErrorContains(t, err, `unsupported base64 variant "base32"`)
+
+	exprFunc, err := base64Encode[any](target, ottl.Optional[string]{})
+	require.NoError(t, err)
+	_, err = exprFunc(context.Background(), nil)
+	assert.ErrorContains(t, err, "expected string but got int")
+}
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/text/encoding/ianaindex"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type DecodeArguments[K any] struct {
+	Target   ottl.ByteSliceLikeGetter[K]
+	Encoding string
+}
+
+func NewDecodeFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Decode", &DecodeArguments[K]{}, createDecodeFunction[K])
+}
+
+func createDecodeFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*DecodeArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("DecodeFactory args must be of type *DecodeArguments[K]")
+	}
+
+	return decode(args.Target, args.Encoding)
+}
+
+func decode[K any](target ottl.ByteSliceLikeGetter[K], encoding string) (ottl.ExprFunc[K], error) {
+	decodeBytes, err := decoderFor(encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		decoded, err := decodeBytes(val)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode the value as %s: %w", encoding, err)
+		}
+		return string(decoded), nil
+	}, nil
+}
+
+// decoderFor returns a function decoding bytes in the given encoding, either one of the base64
+// variants or a character set registered by IANA whose bytes are decoded to UTF-8.
+func decoderFor(encoding string) (func([]byte) ([]byte, error), error) {
+	if b64, ok := base64Encodings[encoding]; ok {
+		return func(val []byte) ([]byte, error) {
+			decoded := make([]byte, b64.DecodedLen(len(val)))
+			n, err := b64.Decode(decoded, val)
+			return decoded[:n], err
+		}, nil
+	}
+
+	charset, err := ianaindex.IANA.Encoding(encoding)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported encoding %q: %w", encoding, err)
+	}
+	if charset == nil {
+		return nil, fmt.Errorf("unsupported encoding %q", encoding)
+	}
+	return func(val []byte) ([]byte, error) {
+		return charset.NewDecoder().Bytes(val)
+	}, nil
+}
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Decode(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		encoding string
+		expected any
+	}{
+		{
+			name:     "base64",
+			value:    "aGVsbG8/Pg==",
+			encoding: "base64",
+			expected: "hello?>",
+		},
+		{
+			name:     "base64-raw",
+			value:    "aGVsbG8/Pg",
+			encoding: "base64-raw",
+			expected: "hello?>",
+		},
+		{
+			name:     "base64-url",
+			value:    "aGVsbG8_Pg==",
+			encoding: "base64-url",
+			expected: "hello?>",
+		},
+		{
+			name:     "base64-raw-url",
+			value:    "aGVsbG8_Pg",
+			encoding: "base64-raw-url",
+			expected: "hello?>",
+		},
+		{
+			name:     "ISO-8859-1",
+			value:    []byte("caf\xe9"),
+			encoding: "ISO-8859-1",
+			expected: "café",
+		},
+		{
+			name:     "windows-1252",
+			value:    []byte("\x80 5"),
+			encoding: "windows-1252",
+			expected: "€ 5",
+		},
+		{
+			name:     "UTF-16 with byte order mark",
+			value:    []byte("\xff\xfec\x00a\x00f\x00\xe9\x00"),
+			encoding: "UTF-16",
+			expected: "café",
+		},
+		{
+			name:     "UTF-8",
+			value:    "café",
+			encoding: "utf-8",
+			expected: "café",
+		},
+		{
+			name: "bytes value",
+			value: func() pcommon.Value {
+				v := pcommon.NewValueBytes()
+				v.Bytes().FromRaw([]byte("caf\xe9"))
+				return v
+			}(),
+			encoding: "latin1",
+			expected: "café",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := decode[any](&ottl.StandardByteSliceLikeGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.value, nil
+				},
+			}, tt.encoding)
+			require.NoError(t, err)
+			result, err := exprFunc(context.Background(), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_DecodeError(t *testing.T) {
+	target := &ottl.StandardByteSliceLikeGetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return "not base64!", nil
+		},
+	}
+
+	_, err := decode[any](target, "not-an-encoding")
+	assert.ErrorContains(t, err, `unsupported encoding "not-an-encoding"`)
+
+	exprFunc, err := decode[any](target, "base64")
+	require.NoError(t, err)
+	_, err = exprFunc(context.Background(), nil)
+	assert.ErrorContains(t, err, "failed to decode the value as base64")
+}
@@ -6,6 +6,10 @@ package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-c
 import (
 	"context"
 	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
@@ -34,6 +38,9 @@ func Duration[K any](duration ottl.StringGetter[K]) (ottl.ExprFunc[K], error) {
 		if err != nil {
 			return nil, err
 		}
+		if isISO8601Duration(d) {
+			return parseISO8601Duration(d)
+		}
 		dur, err := time.ParseDuration(d)
 		if err != nil {
 			return nil, err
@@ -41,3 +48,46 @@ func Duration[K any](duration ottl.StringGetter[K]) (ottl.ExprFunc[K], error) {
 		return dur, nil
 	}, nil
 }
+
+// iso8601Duration matches the ISO-8601 durations, P[n]W or P[n]DT[n]H[n]M[n]S, whose
+// last value may have a fraction.
+var iso8601Duration = regexp.MustCompile(`^([+-])?P(?:(\d+(?:[.,]\d+)?)W)?(?:(\d+(?:[.,]\d+)?)D)?(?:T(?:(\d+(?:[.,]\d+)?)H)?(?:(\d+(?:[.,]\d+)?)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+
+// iso8601Units are the units of the values matched by iso8601Duration, in order.
+var iso8601Units = []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+
+func isISO8601Duration(d string) bool {
+	return strings.HasPrefix(strings.TrimLeft(d, "+-"), "P")
+}
+
+// parseISO8601Duration parses an ISO-8601 duration. The years and months, whose length
+// varies, are not supported, and a day is always 24 hours long.
+func parseISO8601Duration(d string) (time.Duration, error) {
+	matches := iso8601Duration.FindStringSubmatch(d)
+	if matches == nil || d == "P" || strings.HasSuffix(d, "T") {
+		return 0, fmt.Errorf("invalid ISO-8601 duration %q, only weeks, days, hours, minutes and seconds are supported", d)
+	}
+
+	var dur time.Duration
+	hasFraction := false
+	for i, unit := range iso8601Units {
+		value := matches[i+2]
+		if value == "" {
+			continue
+		}
+		// Only the smallest value of the duration can have a fraction.
+		if hasFraction {
+			return 0, fmt.Errorf("invalid ISO-8601 duration %q, only the last value can have a fraction", d)
+		}
+		v, err := strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64)
+		if err != nil {
+			return 0, err
+		}
+		hasFraction = strings.ContainsAny(value, ".,")
+		dur += time.Duration(math.Round(v * float64(unit)))
+	}
+	if matches[1] == "-" {
+		dur = -dur
+	}
+	return dur, nil
+}
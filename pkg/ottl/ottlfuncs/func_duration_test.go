@@ -144,6 +144,60 @@ func Test_Duration(t *testing.T) {
 			},
 			expected: time.Duration(212460078000000),
 		},
+		{
+			name: "ISO-8601 hours and minutes",
+			duration: &ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return "PT1H30M", nil
+				},
+			},
+			expected: 90 * time.Minute,
+		},
+		{
+			name: "ISO-8601 days and time",
+			duration: &ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return "P1DT2H3M4S", nil
+				},
+			},
+			expected: 26*time.Hour + 3*time.Minute + 4*time.Second,
+		},
+		{
+			name: "ISO-8601 weeks",
+			duration: &ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return "P2W", nil
+				},
+			},
+			expected: 14 * 24 * time.Hour,
+		},
+		{
+			name: "ISO-8601 fraction",
+			duration: &ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return "PT0.5S", nil
+				},
+			},
+			expected: 500 * time.Millisecond,
+		},
+		{
+			name: "ISO-8601 comma fraction",
+			duration: &ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return "PT1,5M", nil
+				},
+			},
+			expected: 90 * time.Second,
+		},
+		{
+			name: "ISO-8601 negative",
+			duration: &ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return "-PT10S", nil
+				},
+			},
+			expected: -10 * time.Second,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -180,6 +234,51 @@ func Test_DurationError(t *testing.T) {
 			},
 			expectedError: "invalid duration",
 		},
+		{
+			name: "ISO-8601 years",
+			duration: &ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return "P1Y", nil
+				},
+			},
+			expectedError: "invalid ISO-8601 duration",
+		},
+		{
+			name: "ISO-8601 months",
+			duration: &ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return "P1M", nil
+				},
+			},
+			expectedError: "invalid ISO-8601 duration",
+		},
+		{
+			name: "ISO-8601 without values",
+			duration: &ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return "P", nil
+				},
+			},
+			expectedError: "invalid ISO-8601 duration",
+		},
+		{
+			name: "ISO-8601 without time values",
+			duration: &ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return "P1DT", nil
+				},
+			},
+			expectedError: "invalid ISO-8601 duration",
+		},
+		{
+			name: "ISO-8601 fraction not last",
+			duration: &ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return "PT1.5H30M", nil
+				},
+			},
+			expectedError: "only the last value can have a fraction",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
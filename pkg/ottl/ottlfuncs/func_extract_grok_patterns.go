@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/grok"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type ExtractGrokPatternsArguments[K any] struct {
+	Target             ottl.StringGetter[K]
+	Pattern            string
+	NamedCapturesOnly  ottl.Optional[bool]
+	PatternDefinitions ottl.Optional[[]string]
+}
+
+func NewExtractGrokPatternsFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ExtractGrokPatterns", &ExtractGrokPatternsArguments[K]{}, createExtractGrokPatternsFunction[K])
+}
+
+func createExtractGrokPatternsFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ExtractGrokPatternsArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("ExtractGrokPatternsFactory args must be of type *ExtractGrokPatternsArguments[K]")
+	}
+
+	return extractGrokPatterns(args.Target, args.Pattern, args.NamedCapturesOnly, args.PatternDefinitions)
+}
+
+func extractGrokPatterns[K any](target ottl.StringGetter[K], pattern string, nco ottl.Optional[bool], patternDefinitions ottl.Optional[[]string]) (ottl.ExprFunc[K], error) {
+	namedCapturesOnly := true
+	if !nco.IsEmpty() {
+		namedCapturesOnly = nco.Get()
+	}
+
+	definitions := map[string]string{}
+	if !patternDefinitions.IsEmpty() {
+		for _, d := range patternDefinitions.Get() {
+			name, definition, ok := strings.Cut(d, "=")
+			if !ok || name == "" || definition == "" {
+				return nil, fmt.Errorf("the pattern definition %q supplied to ExtractGrokPatterns is not in the NAME=PATTERN format", d)
+			}
+			definitions[name] = definition
+		}
+	}
+
+	r, err := grok.Compile(pattern, definitions, namedCapturesOnly)
+	if err != nil {
+		return nil, fmt.Errorf("the pattern supplied to ExtractGrokPatterns is not a valid pattern: %w", err)
+	}
+
+	if len(r.Names()) == 0 {
+		return nil, fmt.Errorf("at least 1 named capture must be supplied in the given grok pattern")
+	}
+
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		result := pcommon.NewMap()
+		values, ok := r.Match(val)
+		if !ok {
+			return result, nil
+		}
+
+		for k, v := range values {
+			switch v := v.(type) {
+			case int64:
+				result.PutInt(k, v)
+			case float64:
+				result.PutDouble(k, v)
+			case string:
+				result.PutStr(k, v)
+			}
+		}
+		return result, nil
+	}, nil
+}
@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_extractGrokPatterns(t *testing.T) {
+	tests := []struct {
+		name               string
+		target             string
+		pattern            string
+		namedCapturesOnly  ottl.Optional[bool]
+		patternDefinitions ottl.Optional[[]string]
+		want               func(pcommon.Map)
+	}{
+		{
+			name:    "combined apache log",
+			target:  `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326 "-" "curl/8.4.0"`,
+			pattern: "%{COMBINEDAPACHELOG}",
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("clientip", "127.0.0.1")
+				expectedMap.PutStr("ident", "-")
+				expectedMap.PutStr("auth", "frank")
+				expectedMap.PutStr("timestamp", "10/Oct/2000:13:55:36 -0700")
+				expectedMap.PutStr("verb", "GET")
+				expectedMap.PutStr("request", "/apache_pb.gif")
+				expectedMap.PutStr("httpversion", "1.0")
+				expectedMap.PutStr("response", "200")
+				expectedMap.PutStr("bytes", "2326")
+				expectedMap.PutStr("referrer", `"-"`)
+				expectedMap.PutStr("agent", `"curl/8.4.0"`)
+			},
+		},
+		{
+			name:    "typed captures",
+			target:  "GET /index.html 404 0.25",
+			pattern: "%{WORD:http.request.method} %{URIPATH:url.path} %{INT:http.response.status_code:int} %{NUMBER:duration:float}",
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("http.request.method", "GET")
+				expectedMap.PutStr("url.path", "/index.html")
+				expectedMap.PutInt("http.response.status_code", 404)
+				expectedMap.PutDouble("duration", 0.25)
+			},
+		},
+		{
+			name:               "pattern definitions",
+			target:             "queue-12 took 15ms",
+			pattern:            "%{QUEUE:queue} took %{DURATION:duration}",
+			patternDefinitions: ottl.NewTestingOptional[[]string]([]string{"QUEUE=queue-%{INT}", "DURATION=%{INT}(?:ms|s)"}),
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("queue", "queue-12")
+				expectedMap.PutStr("duration", "15ms")
+			},
+		},
+		{
+			name:              "unnamed captures",
+			target:            "error 42",
+			pattern:           "%{WORD} %{INT:code}",
+			namedCapturesOnly: ottl.NewTestingOptional[bool](false),
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("WORD", "error")
+				expectedMap.PutStr("code", "42")
+			},
+		},
+		{
+			name:    "no pattern found",
+			target:  "error 42",
+			pattern: "^%{IPV4:ip}$",
+			want:    func(_ pcommon.Map) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return tt.target, nil
+				},
+			}
+			exprFunc, err := extractGrokPatterns(target, tt.pattern, tt.namedCapturesOnly, tt.patternDefinitions)
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+
+			resultMap, ok := result.(pcommon.Map)
+			require.True(t, ok)
+
+			expected := pcommon.NewMap()
+			tt.want(expected)
+
+			assert.Equal(t, expected.AsRaw(), resultMap.AsRaw())
+		})
+	}
+}
+
+func Test_extractGrokPatterns_validation(t *testing.T) {
+	tests := []struct {
+		name               string
+		pattern            string
+		patternDefinitions ottl.Optional[[]string]
+		err                string
+	}{
+		{
+			name:    "bad regex",
+			pattern: "%{WORD:a}(",
+			err:     "the pattern supplied to ExtractGrokPatterns is not a valid pattern",
+		},
+		{
+			name:    "unknown pattern",
+			pattern: "%{UNKNOWN:a}",
+			err:     `unknown grok pattern "UNKNOWN"`,
+		},
+		{
+			name:    "no named capture",
+			pattern: "%{WORD} %{INT}",
+			err:     "at least 1 named capture must be supplied in the given grok pattern",
+		},
+		{
+			name:               "bad pattern definition",
+			pattern:            "%{QUEUE:queue}",
+			patternDefinitions: ottl.NewTestingOptional[[]string]([]string{"QUEUE"}),
+			err:                `the pattern definition "QUEUE" supplied to ExtractGrokPatterns is not in the NAME=PATTERN format`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return "foobar", nil
+				},
+			}
+			exprFunc, err := extractGrokPatterns[any](target, tt.pattern, ottl.Optional[bool]{}, tt.patternDefinitions)
+			assert.ErrorContains(t, err, tt.err)
+			assert.Nil(t, exprFunc)
+		})
+	}
+}
+
+func Test_extractGrokPatterns_bad_input(t *testing.T) {
+	target := &ottl.StandardStringGetter[any]{
+		Getter: func(_ context.Context, _ any) (any, error) {
+			return 123, nil
+		},
+	}
+	exprFunc, err := extractGrokPatterns[any](target, "%{GREEDYDATA:line}", ottl.Optional[bool]{}, ottl.Optional[[]string]{})
+	require.NoError(t, err)
+
+	result, err := exprFunc(nil, nil)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/elastic/lunes"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/timeutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+var (
+	strptimeDirective = regexp.MustCompile(`%.`)
+
+	// The composite directives are expanded so that their names are translated
+	// as well. %% is replaced by itself so that "%%c" is left as is.
+	strptimeCompositeDirectives = strings.NewReplacer(
+		"%%", "%%",
+		"%c", "%a %b %d %H:%M:%S %Y",
+		"%r", "%I:%M:%S %P",
+	)
+
+	// The fractions of seconds keep their trailing zeros when formatted.
+	strptimeFractionFormatters = map[string]timeFormatter{
+		"%L": func(t time.Time) string { return fmt.Sprintf("%03d", t.Nanosecond()/int(time.Millisecond)) },
+		"%f": func(t time.Time) string { return fmt.Sprintf("%06d", t.Nanosecond()/int(time.Microsecond)) },
+		"%s": func(t time.Time) string { return fmt.Sprintf("%09d", t.Nanosecond()) },
+	}
+)
+
+type FormatTimeArguments[K any] struct {
+	Time     ottl.TimeGetter[K]
+	Format   string
+	Location ottl.Optional[string]
+	Locale   ottl.Optional[string]
+}
+
+func NewFormatTimeFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("FormatTime", &FormatTimeArguments[K]{}, createFormatTimeFunction[K])
+}
+
+func createFormatTimeFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*FormatTimeArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("FormatTimeFactory args must be of type *FormatTimeArguments[K]")
+	}
+
+	return FormatTime(args.Time, args.Format, args.Location, args.Locale)
+}
+
+// timeFormatter formats one part of the format of FormatTime.
+type timeFormatter func(t time.Time) string
+
+func FormatTime[K any](timeValue ottl.TimeGetter[K], format string, location ottl.Optional[string], locale ottl.Optional[string]) (ottl.ExprFunc[K], error) {
+	if format == "" {
+		return nil, fmt.Errorf("format cannot be nil")
+	}
+
+	var loc *time.Location
+	if !location.IsEmpty() {
+		var err error
+		loc, err = time.LoadLocation(location.Get())
+		if err != nil {
+			return nil, fmt.Errorf("invalid location: %w", err)
+		}
+	}
+
+	var l lunes.Locale
+	if !locale.IsEmpty() {
+		var err error
+		l, err = lunes.NewDefaultLocale(locale.Get())
+		if err != nil {
+			return nil, fmt.Errorf("invalid locale: %w", err)
+		}
+	}
+
+	formatters, err := newTimeFormatters(strptimeCompositeDirectives.Replace(format), l)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, tCtx K) (any, error) {
+		t, err := timeValue.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if loc != nil {
+			t = t.In(loc)
+		}
+
+		var sb strings.Builder
+		for _, f := range formatters {
+			sb.WriteString(f(t))
+		}
+		return sb.String(), nil
+	}, nil
+}
+
+// newTimeFormatters splits the strptime format into its directives and the text between
+// them. Each directive is formatted on its own, so that the text is never mistaken for a
+// part of a Go layout.
+func newTimeFormatters(format string, locale lunes.Locale) ([]timeFormatter, error) {
+	var formatters []timeFormatter
+	last := 0
+	for _, loc := range strptimeDirective.FindAllStringIndex(format, -1) {
+		if text := format[last:loc[0]]; text != "" {
+			formatters = append(formatters, func(time.Time) string { return text })
+		}
+		last = loc[1]
+
+		directive := format[loc[0]:loc[1]]
+		if f := localizedFormatter(directive, locale); f != nil {
+			formatters = append(formatters, f)
+			continue
+		}
+		if f, ok := strptimeFractionFormatters[directive]; ok {
+			formatters = append(formatters, f)
+			continue
+		}
+		layout, err := timeutils.StrptimeToGotime(directive)
+		if err != nil {
+			return nil, err
+		}
+		formatters = append(formatters, func(t time.Time) string { return t.Format(layout) })
+	}
+	if text := format[last:]; text != "" {
+		formatters = append(formatters, func(time.Time) string { return text })
+	}
+	return formatters, nil
+}
+
+// localizedFormatter returns the formatter of the names of the days, months and day
+// periods in the language of the locale, or nil when the directive has no name or the
+// locale does not translate it.
+func localizedFormatter(directive string, locale lunes.Locale) timeFormatter {
+	if locale == nil {
+		return nil
+	}
+
+	var names []string
+	var index func(t time.Time) int
+	switch directive {
+	case "%a":
+		names, index = locale.ShortDayNames(), weekdayIndex
+	case "%A":
+		names, index = locale.LongDayNames(), weekdayIndex
+	case "%b", "%h":
+		names, index = locale.ShortMonthNames(), monthIndex
+	case "%B":
+		names, index = locale.LongMonthNames(), monthIndex
+	case "%p":
+		names, index = locale.DayPeriods(), dayPeriodIndex
+	case "%P":
+		for _, name := range locale.DayPeriods() {
+			names = append(names, strings.ToLower(name))
+		}
+		index = dayPeriodIndex
+	default:
+		return nil
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	return func(t time.Time) string {
+		return names[index(t)]
+	}
+}
+
+func weekdayIndex(t time.Time) int {
+	return int(t.Weekday())
+}
+
+func monthIndex(t time.Time) int {
+	return int(t.Month()) - 1
+}
+
+func dayPeriodIndex(t time.Time) int {
+	if t.Hour() < 12 {
+		return 0
+	}
+	return 1
+}
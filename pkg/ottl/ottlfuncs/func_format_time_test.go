@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_FormatTime(t *testing.T) {
+	ts := time.Date(2024, time.March, 9, 14, 5, 7, 120000000, time.UTC)
+	tests := []struct {
+		name     string
+		time     time.Time
+		format   string
+		location ottl.Optional[string]
+		locale   ottl.Optional[string]
+		expected string
+	}{
+		{
+			name:     "date",
+			time:     ts,
+			format:   "%Y-%m-%d",
+			expected: "2024-03-09",
+		},
+		{
+			name:     "index name",
+			time:     ts,
+			format:   "logs-%Y.%m.%d",
+			expected: "logs-2024.03.09",
+		},
+		{
+			name:     "time with milliseconds",
+			time:     ts,
+			format:   "%H:%M:%S.%L",
+			expected: "14:05:07.120",
+		},
+		{
+			name:     "names",
+			time:     ts,
+			format:   "%A %d %B %Y, %I:%M %p",
+			expected: "Saturday 09 March 2024, 02:05 PM",
+		},
+		{
+			name:     "text that looks like a go layout",
+			time:     ts,
+			format:   "Jan 2006 %Y",
+			expected: "Jan 2006 2024",
+		},
+		{
+			name:     "percent sign",
+			time:     ts,
+			format:   "100%% %Y",
+			expected: "100% 2024",
+		},
+		{
+			name:     "location",
+			time:     ts,
+			format:   "%Y-%m-%dT%H:%M:%S%j",
+			location: ottl.NewTestingOptional("America/New_York"),
+			expected: "2024-03-09T09:05:07-05:00",
+		},
+		{
+			name:     "locale",
+			time:     ts,
+			format:   "%A %d %B %Y",
+			locale:   ottl.NewTestingOptional("fr-FR"),
+			expected: "samedi 09 mars 2024",
+		},
+		{
+			name:     "locale short names",
+			time:     ts,
+			format:   "%a %d %b",
+			locale:   ottl.NewTestingOptional("de"),
+			expected: "Sa. 09 März",
+		},
+		{
+			name:     "locale composite directive",
+			time:     ts,
+			format:   "%c",
+			locale:   ottl.NewTestingOptional("es"),
+			expected: "sáb mar 09 14:05:07 2024",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := FormatTime[any](&ottl.StandardTimeGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.time, nil
+				},
+			}, tt.format, tt.location, tt.locale)
+			require.NoError(t, err)
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_FormatTime_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		location ottl.Optional[string]
+		locale   ottl.Optional[string]
+		err      string
+	}{
+		{
+			name:   "empty format",
+			format: "",
+			err:    "format cannot be nil",
+		},
+		{
+			name:   "unsupported directive",
+			format: "%Y-%Q",
+			err:    "unsupported ctimefmt.ToNative() directive: %Q",
+		},
+		{
+			name:     "invalid location",
+			format:   "%Y",
+			location: ottl.NewTestingOptional("Mars/Olympus_Mons"),
+			err:      "invalid location",
+		},
+		{
+			name:   "invalid locale",
+			format: "%Y",
+			locale: ottl.NewTestingOptional("xx-unknown"),
+			err:    "invalid locale",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FormatTime[any](&ottl.StandardTimeGetter[any]{}, tt.format, tt.location, tt.locale)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func Test_FormatTime_InvalidTime(t *testing.T) {
+	exprFunc, err := FormatTime[any](&ottl.StandardTimeGetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return "not a time", nil
+		},
+	}, "%Y", ottl.Optional[string]{}, ottl.Optional[string]{})
+	require.NoError(t, err)
+	_, err = exprFunc(context.Background(), nil)
+	assert.Error(t, err)
+}
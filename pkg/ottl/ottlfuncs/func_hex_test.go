@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Hex(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected any
+	}{
+		{
+			name:     "string",
+			value:    "hello",
+			expected: "68656c6c6f",
+		},
+		{
+			name:     "bytes",
+			value:    []byte{0x01, 0xab, 0xff},
+			expected: "01abff",
+		},
+		{
+			name:     "int64",
+			value:    int64(12),
+			expected: "000000000000000c",
+		},
+		{
+			name:     "float64",
+			value:    1.2,
+			expected: "3ff3333333333333",
+		},
+		{
+			name:     "bool",
+			value:    true,
+			expected: "01",
+		},
+		{
+			name:     "pcommon.Value",
+			value:    pcommon.NewValueStr("hello"),
+			expected: "68656c6c6f",
+		},
+		{
+			name:     "nil",
+			value:    nil,
+			expected: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := hexFunc[any](&ottl.StandardByteSliceLikeGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.value, nil
+				},
+			})
+			result, err := exprFunc(context.Background(), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_HexError(t *testing.T) {
+	exprFunc := hexFunc[any](&ottl.StandardByteSliceLikeGetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return map[string]any{}, nil
+		},
+	})
+	_, err := exprFunc(context.Background(), nil)
+	assert.ErrorContains(t, err, "unsupported type: map[string]interface {}")
+}
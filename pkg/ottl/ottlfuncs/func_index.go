@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type IndexArguments[K any] struct {
+	Target ottl.PSliceGetter[K]
+	Value  ottl.Getter[K]
+}
+
+func NewIndexFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Index", &IndexArguments[K]{}, createIndexFunction[K])
+}
+
+func createIndexFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*IndexArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("IndexFactory args must be of type *IndexArguments[K]")
+	}
+
+	return index(args.Target, args.Value), nil
+}
+
+// index returns the index of the first element of the target slice equal to value, or -1.
+func index[K any](target ottl.PSliceGetter[K], value ottl.Getter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		slice, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		val, err := value.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		// Convert the value the same way as the elements of the slice so that
+		// values of equivalent types, int and int64 for instance, are equal.
+		expected := pcommon.NewValueEmpty()
+		if err = setSliceValue(expected, val); err != nil {
+			return nil, err
+		}
+		raw := expected.AsRaw()
+		for i := 0; i < slice.Len(); i++ {
+			if reflect.DeepEqual(slice.At(i).AsRaw(), raw) {
+				return int64(i), nil
+			}
+		}
+		return int64(-1), nil
+	}
+}
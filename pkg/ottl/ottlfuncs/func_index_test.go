@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Index(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   any
+		value    any
+		expected int64
+	}{
+		{
+			name:     "string",
+			target:   []any{"a", "b", "c"},
+			value:    "b",
+			expected: 1,
+		},
+		{
+			name:     "int",
+			target:   []int64{1, 2, 3},
+			value:    int64(3),
+			expected: 2,
+		},
+		{
+			name:     "int of another type",
+			target:   []any{1, 2},
+			value:    2,
+			expected: 1,
+		},
+		{
+			name:     "first match",
+			target:   []string{"a", "b", "a"},
+			value:    "a",
+			expected: 0,
+		},
+		{
+			name:     "pcommon.Value",
+			target:   []any{"a", 1.5},
+			value:    pcommon.NewValueDouble(1.5),
+			expected: 1,
+		},
+		{
+			name:     "map",
+			target:   []any{"a", map[string]any{"k": "v"}},
+			value:    map[string]any{"k": "v"},
+			expected: 1,
+		},
+		{
+			name:     "not found",
+			target:   []any{"a", "b"},
+			value:    "c",
+			expected: -1,
+		},
+		{
+			name:     "same string of another type",
+			target:   []any{int64(1)},
+			value:    "1",
+			expected: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := index[any](&ottl.StandardPSliceGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.target, nil
+				},
+			}, &ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.value, nil
+				},
+			})
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_Index_Error(t *testing.T) {
+	exprFunc := index[any](&ottl.StandardPSliceGetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return []any{"a"}, nil
+		},
+	}, &ottl.StandardGetSetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return struct{}{}, nil
+		},
+	})
+	_, err := exprFunc(context.Background(), nil)
+	assert.Error(t, err)
+}
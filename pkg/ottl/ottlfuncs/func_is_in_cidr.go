@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type IsInCIDRArguments[K any] struct {
+	Target   ottl.StringGetter[K]
+	Networks []string
+}
+
+func NewIsInCIDRFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("IsInCIDR", &IsInCIDRArguments[K]{}, createIsInCIDRFunction[K])
+}
+
+func createIsInCIDRFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*IsInCIDRArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("IsInCIDRFactory args must be of type *IsInCIDRArguments[K]")
+	}
+
+	return isInCIDR(args.Target, args.Networks)
+}
+
+// isInCIDR returns true if the target IP address is in one of the networks. A target that
+// is not an IP address is not in any network.
+func isInCIDR[K any](target ottl.StringGetter[K], networks []string) (ottl.ExprFunc[K], error) {
+	if len(networks) == 0 {
+		return nil, fmt.Errorf("at least one network must be provided")
+	}
+	ipNets := make([]*net.IPNet, len(networks))
+	for i, network := range networks {
+		_, ipNet, err := net.ParseCIDR(network)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network %q: %w", network, err)
+		}
+		ipNets[i] = ipNet
+	}
+
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		ip := net.ParseIP(val)
+		if ip == nil {
+			return false, nil
+		}
+		for _, ipNet := range ipNets {
+			if ipNet.Contains(ip) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}, nil
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_IsInCIDR(t *testing.T) {
+	tests := []struct {
+		name     string
+		ip       string
+		networks []string
+		expected bool
+	}{
+		{
+			name:     "in network",
+			ip:       "10.1.2.3",
+			networks: []string{"10.0.0.0/8"},
+			expected: true,
+		},
+		{
+			name:     "in one of the networks",
+			ip:       "192.168.1.10",
+			networks: []string{"10.0.0.0/8", "192.168.0.0/16"},
+			expected: true,
+		},
+		{
+			name:     "not in network",
+			ip:       "172.16.0.1",
+			networks: []string{"10.0.0.0/8", "192.168.0.0/16"},
+			expected: false,
+		},
+		{
+			name:     "ipv6",
+			ip:       "2001:db8::1",
+			networks: []string{"2001:db8::/32"},
+			expected: true,
+		},
+		{
+			name:     "ipv4 in ipv6 network",
+			ip:       "10.1.2.3",
+			networks: []string{"2001:db8::/32"},
+			expected: false,
+		},
+		{
+			name:     "not an ip",
+			ip:       "example.com",
+			networks: []string{"10.0.0.0/8"},
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := isInCIDR[any](&ottl.StandardStringGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.ip, nil
+				},
+			}, tt.networks)
+			require.NoError(t, err)
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_IsInCIDR_InvalidNetworks(t *testing.T) {
+	_, err := isInCIDR[any](&ottl.StandardStringGetter[any]{}, []string{"10.0.0.0"})
+	assert.ErrorContains(t, err, "invalid network")
+
+	_, err = isInCIDR[any](&ottl.StandardStringGetter[any]{}, nil)
+	assert.ErrorContains(t, err, "at least one network")
+}
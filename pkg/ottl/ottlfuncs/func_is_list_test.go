@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_IsList(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected bool
+	}{
+		{
+			name:     "pcommon.Slice",
+			value:    pcommon.NewSlice(),
+			expected: true,
+		},
+		{
+			name:     "ValueTypeSlice",
+			value:    pcommon.NewValueSlice(),
+			expected: true,
+		},
+		{
+			name:     "[]any",
+			value:    []any{1, "a"},
+			expected: true,
+		},
+		{
+			name:     "[]string",
+			value:    []string{"a"},
+			expected: true,
+		},
+		{
+			name:     "not list",
+			value:    "not a list",
+			expected: false,
+		},
+		{
+			name:     "ValueTypeMap",
+			value:    pcommon.NewValueMap(),
+			expected: false,
+		},
+		{
+			name:     "nil",
+			value:    nil,
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := isList[any](&ottl.StandardPSliceGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.value, nil
+				},
+			})
+			result, err := exprFunc(context.Background(), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+// nolint:errorlint
+func Test_IsList_Error(t *testing.T) {
+	exprFunc := isList[any](&ottl.StandardPSliceGetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return nil, ottl.TypeError("")
+		},
+	})
+	result, err := exprFunc(context.Background(), nil)
+	assert.Equal(t, false, result)
+	assert.Error(t, err)
+	_, ok := err.(ottl.TypeError)
+	assert.False(t, ok)
+}
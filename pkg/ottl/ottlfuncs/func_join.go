@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type JoinArguments[K any] struct {
+	Target    ottl.PSliceGetter[K]
+	Delimiter string
+}
+
+func NewJoinFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Join", &JoinArguments[K]{}, createJoinFunction[K])
+}
+
+func createJoinFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*JoinArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("JoinFactory args must be of type *JoinArguments[K]")
+	}
+
+	return join(args.Target, args.Delimiter), nil
+}
+
+func join[K any](target ottl.PSliceGetter[K], delimiter string) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		elems := make([]string, val.Len())
+		for i := 0; i < val.Len(); i++ {
+			elems[i] = val.At(i).AsString()
+		}
+		return strings.Join(elems, delimiter), nil
+	}
+}
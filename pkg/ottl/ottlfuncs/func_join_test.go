@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Join(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     any
+		delimiter string
+		expected  string
+	}{
+		{
+			name:      "strings",
+			value:     []string{"a", "b", "c"},
+			delimiter: ",",
+			expected:  "a,b,c",
+		},
+		{
+			name:      "mixed types",
+			value:     []any{"a", int64(1), 2.5, true},
+			delimiter: " ",
+			expected:  "a 1 2.5 true",
+		},
+		{
+			name:      "empty delimiter",
+			value:     []any{"a", "b"},
+			delimiter: "",
+			expected:  "ab",
+		},
+		{
+			name:      "empty slice",
+			value:     []any{},
+			delimiter: ",",
+			expected:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := join[any](&ottl.StandardPSliceGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.value, nil
+				},
+			}, tt.delimiter)
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type SliceArguments[K any] struct {
+	Target ottl.PSliceGetter[K]
+	Start  ottl.IntGetter[K]
+	End    ottl.Optional[ottl.IntGetter[K]]
+}
+
+func NewSliceFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Slice", &SliceArguments[K]{}, createSliceFunction[K])
+}
+
+func createSliceFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*SliceArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("SliceFactory args must be of type *SliceArguments[K]")
+	}
+
+	return slice(args.Target, args.Start, args.End), nil
+}
+
+// slice returns a copy of the elements of the target slice from start, included, to end,
+// excluded. The elements up to the end of the target are returned when end is not set.
+func slice[K any](target ottl.PSliceGetter[K], startGetter ottl.IntGetter[K], endGetter ottl.Optional[ottl.IntGetter[K]]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		start, err := startGetter.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if start < 0 {
+			return nil, fmt.Errorf("invalid start for slice function, %d cannot be negative", start)
+		}
+		end := int64(val.Len())
+		if !endGetter.IsEmpty() {
+			end, err = endGetter.Get().Get(ctx, tCtx)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if end < start {
+			return nil, fmt.Errorf("invalid range for slice function, end %d cannot be less than start %d", end, start)
+		}
+		if end > int64(val.Len()) {
+			return nil, fmt.Errorf("invalid range for slice function, %d cannot be greater than the length of target slice(%d)", end, val.Len())
+		}
+
+		result := pcommon.NewSlice()
+		result.EnsureCapacity(int(end - start))
+		for i := start; i < end; i++ {
+			val.At(int(i)).CopyTo(result.AppendEmpty())
+		}
+		return result, nil
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func intGetter(v int64) ottl.IntGetter[any] {
+	return &ottl.StandardIntGetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return v, nil
+		},
+	}
+}
+
+func Test_Slice(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   any
+		start    int64
+		end      ottl.Optional[ottl.IntGetter[any]]
+		expected []any
+	}{
+		{
+			name:     "start to end of slice",
+			target:   []any{"a", "b", "c"},
+			start:    1,
+			expected: []any{"b", "c"},
+		},
+		{
+			name:     "start to end",
+			target:   []int64{1, 2, 3, 4},
+			start:    1,
+			end:      ottl.NewTestingOptional[ottl.IntGetter[any]](intGetter(3)),
+			expected: []any{int64(2), int64(3)},
+		},
+		{
+			name:     "empty range",
+			target:   []string{"a", "b"},
+			start:    1,
+			end:      ottl.NewTestingOptional[ottl.IntGetter[any]](intGetter(1)),
+			expected: []any{},
+		},
+		{
+			name:     "whole slice",
+			target:   []any{"a", map[string]any{"k": "v"}},
+			start:    0,
+			end:      ottl.NewTestingOptional[ottl.IntGetter[any]](intGetter(2)),
+			expected: []any{"a", map[string]any{"k": "v"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := slice[any](&ottl.StandardPSliceGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.target, nil
+				},
+			}, intGetter(tt.start), tt.end)
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.(pcommon.Slice).AsRaw())
+		})
+	}
+}
+
+func Test_Slice_Error(t *testing.T) {
+	tests := []struct {
+		name  string
+		start int64
+		end   ottl.Optional[ottl.IntGetter[any]]
+		err   string
+	}{
+		{
+			name:  "negative start",
+			start: -1,
+			err:   "cannot be negative",
+		},
+		{
+			name:  "end before start",
+			start: 2,
+			end:   ottl.NewTestingOptional[ottl.IntGetter[any]](intGetter(1)),
+			err:   "cannot be less than start",
+		},
+		{
+			name:  "end after length",
+			start: 0,
+			end:   ottl.NewTestingOptional[ottl.IntGetter[any]](intGetter(4)),
+			err:   "cannot be greater than the length of target slice",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := slice[any](&ottl.StandardPSliceGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return []any{"a", "b", "c"}, nil
+				},
+			}, intGetter(tt.start), tt.end)
+			_, err := exprFunc(context.Background(), nil)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+const (
+	sortAscending  = "asc"
+	sortDescending = "desc"
+)
+
+type SortArguments[K any] struct {
+	Target ottl.PSliceGetter[K]
+	Order  ottl.Optional[string]
+}
+
+func NewSortFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Sort", &SortArguments[K]{}, createSortFunction[K])
+}
+
+func createSortFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*SortArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("SortFactory args must be of type *SortArguments[K]")
+	}
+
+	order := sortAscending
+	if !args.Order.IsEmpty() {
+		order = args.Order.Get()
+	}
+
+	return sortSlice(args.Target, order)
+}
+
+// sortSlice returns a sorted copy of the target slice. Numbers are compared by value,
+// strings lexicographically and booleans with false before true. Slices holding values
+// of different types, or of types that cannot be ordered, are sorted by their string
+// representation.
+func sortSlice[K any](target ottl.PSliceGetter[K], order string) (ottl.ExprFunc[K], error) {
+	if order != sortAscending && order != sortDescending {
+		return nil, fmt.Errorf("invalid sort order %q, must be %q or %q", order, sortAscending, sortDescending)
+	}
+
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		values := make([]pcommon.Value, val.Len())
+		for i := 0; i < val.Len(); i++ {
+			values[i] = val.At(i)
+		}
+		less := lessFunc(values)
+		sort.SliceStable(values, func(i, j int) bool {
+			if order == sortDescending {
+				return less(values[j], values[i])
+			}
+			return less(values[i], values[j])
+		})
+
+		result := pcommon.NewSlice()
+		result.EnsureCapacity(len(values))
+		for _, v := range values {
+			v.CopyTo(result.AppendEmpty())
+		}
+		return result, nil
+	}, nil
+}
+
+// lessFunc returns the comparison used to sort the values, based on their types.
+func lessFunc(values []pcommon.Value) func(a, b pcommon.Value) bool {
+	numeric, str, boolean := true, true, true
+	for _, v := range values {
+		switch v.Type() {
+		case pcommon.ValueTypeInt, pcommon.ValueTypeDouble:
+			str, boolean = false, false
+		case pcommon.ValueTypeStr:
+			numeric, boolean = false, false
+		case pcommon.ValueTypeBool:
+			numeric, str = false, false
+		default:
+			numeric, str, boolean = false, false, false
+		}
+	}
+
+	switch {
+	case numeric:
+		return func(a, b pcommon.Value) bool {
+			return toFloat64(a) < toFloat64(b)
+		}
+	case str:
+		return func(a, b pcommon.Value) bool {
+			return a.Str() < b.Str()
+		}
+	case boolean:
+		return func(a, b pcommon.Value) bool {
+			return !a.Bool() && b.Bool()
+		}
+	default:
+		return func(a, b pcommon.Value) bool {
+			return a.AsString() < b.AsString()
+		}
+	}
+}
+
+func toFloat64(v pcommon.Value) float64 {
+	if v.Type() == pcommon.ValueTypeInt {
+		return float64(v.Int())
+	}
+	return v.Double()
+}
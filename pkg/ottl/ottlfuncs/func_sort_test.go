@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Sort(t *testing.T) {
+	pMap := pcommon.NewValueMap()
+	pMap.Map().PutStr("k", "v")
+
+	tests := []struct {
+		name     string
+		value    any
+		order    string
+		expected []any
+	}{
+		{
+			name:     "numbers",
+			value:    []any{int64(3), 1.5, int64(-2), 0.0},
+			order:    sortAscending,
+			expected: []any{int64(-2), 0.0, 1.5, int64(3)},
+		},
+		{
+			name:     "numbers descending",
+			value:    []int64{1, 3, 2},
+			order:    sortDescending,
+			expected: []any{int64(3), int64(2), int64(1)},
+		},
+		{
+			name:     "strings",
+			value:    []string{"b", "c", "a"},
+			order:    sortAscending,
+			expected: []any{"a", "b", "c"},
+		},
+		{
+			name:     "bools",
+			value:    []bool{true, false, true},
+			order:    sortAscending,
+			expected: []any{false, true, true},
+		},
+		{
+			name:     "mixed types",
+			value:    []any{"b", int64(10), true, 2.5},
+			order:    sortAscending,
+			expected: []any{int64(10), 2.5, "b", true},
+		},
+		{
+			name: "pcommon.Slice",
+			value: func() pcommon.Slice {
+				s := pcommon.NewSlice()
+				s.AppendEmpty().SetStr("z")
+				pMap.CopyTo(s.AppendEmpty())
+				return s
+			}(),
+			order:    sortAscending,
+			expected: []any{"z", map[string]any{"k": "v"}},
+		},
+		{
+			name:     "empty",
+			value:    []any{},
+			order:    sortAscending,
+			expected: []any{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := sortSlice[any](&ottl.StandardPSliceGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.value, nil
+				},
+			}, tt.order)
+			require.NoError(t, err)
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.(pcommon.Slice).AsRaw())
+		})
+	}
+}
+
+func Test_Sort_DoesNotModifyTarget(t *testing.T) {
+	target := pcommon.NewSlice()
+	require.NoError(t, target.FromRaw([]any{"b", "a"}))
+	exprFunc, err := sortSlice[any](&ottl.StandardPSliceGetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return target, nil
+		},
+	}, sortAscending)
+	require.NoError(t, err)
+	result, err := exprFunc(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []any{"a", "b"}, result.(pcommon.Slice).AsRaw())
+	assert.Equal(t, []any{"b", "a"}, target.AsRaw())
+}
+
+func Test_Sort_InvalidOrder(t *testing.T) {
+	_, err := sortSlice[any](&ottl.StandardPSliceGetter[any]{}, "random")
+	assert.ErrorContains(t, err, "invalid sort order")
+}
+
+func Test_Sort_Error(t *testing.T) {
+	exprFunc, err := sortSlice[any](&ottl.StandardPSliceGetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return "not a slice", nil
+		},
+	}, sortAscending)
+	require.NoError(t, err)
+	_, err = exprFunc(context.Background(), nil)
+	assert.Error(t, err)
+}
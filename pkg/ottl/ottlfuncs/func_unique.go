@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type UniqueArguments[K any] struct {
+	Target ottl.PSliceGetter[K]
+}
+
+func NewUniqueFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Unique", &UniqueArguments[K]{}, createUniqueFunction[K])
+}
+
+func createUniqueFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*UniqueArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("UniqueFactory args must be of type *UniqueArguments[K]")
+	}
+
+	return unique(args.Target), nil
+}
+
+// unique returns a copy of the target slice without its duplicate elements, keeping
+// the first occurrence of each element.
+func unique[K any](target ottl.PSliceGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		result := pcommon.NewSlice()
+		// The scalar values are looked up by value, the maps, slices and bytes,
+		// which cannot be map keys, are compared with those already kept.
+		seen := map[any]struct{}{}
+		var kept []any
+		for i := 0; i < val.Len(); i++ {
+			raw := val.At(i).AsRaw()
+			switch raw.(type) {
+			case map[string]any, []any, []byte:
+				if containsRaw(kept, raw) {
+					continue
+				}
+				kept = append(kept, raw)
+			default:
+				if _, ok := seen[raw]; ok {
+					continue
+				}
+				seen[raw] = struct{}{}
+			}
+			val.At(i).CopyTo(result.AppendEmpty())
+		}
+		return result, nil
+	}
+}
+
+func containsRaw(values []any, raw any) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, raw) {
+			return true
+		}
+	}
+	return false
+}
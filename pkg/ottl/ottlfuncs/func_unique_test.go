@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Unique(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected []any
+	}{
+		{
+			name:     "strings",
+			value:    []string{"b", "a", "b", "c", "a"},
+			expected: []any{"b", "a", "c"},
+		},
+		{
+			name:     "mixed types",
+			value:    []any{int64(1), "1", 1, true, 1.0, true},
+			expected: []any{int64(1), "1", true, 1.0},
+		},
+		{
+			name: "maps and slices",
+			value: []any{
+				map[string]any{"k": "v"},
+				[]any{"a"},
+				map[string]any{"k": "v"},
+				[]any{"a"},
+				[]any{"b"},
+			},
+			expected: []any{map[string]any{"k": "v"}, []any{"a"}, []any{"b"}},
+		},
+		{
+			name:     "no duplicates",
+			value:    []int64{1, 2},
+			expected: []any{int64(1), int64(2)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := unique[any](&ottl.StandardPSliceGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.value, nil
+				},
+			})
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.(pcommon.Slice).AsRaw())
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// The url attributes of the semantic conventions.
+const (
+	attributeURLOriginal  = "url.original"
+	attributeURLScheme    = "url.scheme"
+	attributeURLDomain    = "url.domain"
+	attributeURLPort      = "url.port"
+	attributeURLPath      = "url.path"
+	attributeURLQuery     = "url.query"
+	attributeURLFragment  = "url.fragment"
+	attributeURLExtension = "url.extension"
+)
+
+type URLArguments[K any] struct {
+	URI ottl.StringGetter[K]
+}
+
+func NewURLFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("URL", &URLArguments[K]{}, createURLFunction[K])
+}
+
+func createURLFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*URLArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("URLFactory args must be of type *URLArguments[K]")
+	}
+
+	return parseURL(args.URI), nil
+}
+
+// parseURL returns a `pcommon.Map` holding the parts of the target URI, keyed by the url
+// attributes of the semantic conventions. The parts that are not set are left out, and the
+// credentials of the URI are never returned.
+func parseURL[K any](target ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		uri, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if uri == "" {
+			return nil, fmt.Errorf("url must not be empty")
+		}
+
+		parsed, err := url.Parse(uri)
+		if err != nil {
+			return nil, err
+		}
+
+		result := pcommon.NewMap()
+		result.PutStr(attributeURLOriginal, uri)
+		putStrIfNotEmpty(result, attributeURLScheme, parsed.Scheme)
+		putStrIfNotEmpty(result, attributeURLDomain, parsed.Hostname())
+		if rawPort := parsed.Port(); rawPort != "" {
+			port, err := strconv.ParseInt(rawPort, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid port %q: %w", rawPort, err)
+			}
+			result.PutInt(attributeURLPort, port)
+		}
+		putStrIfNotEmpty(result, attributeURLPath, parsed.EscapedPath())
+		putStrIfNotEmpty(result, attributeURLQuery, parsed.RawQuery)
+		putStrIfNotEmpty(result, attributeURLFragment, parsed.Fragment)
+		if i := strings.LastIndex(parsed.Path, "."); i > strings.LastIndex(parsed.Path, "/") {
+			putStrIfNotEmpty(result, attributeURLExtension, parsed.Path[i+1:])
+		}
+		return result, nil
+	}
+}
+
+func putStrIfNotEmpty(m pcommon.Map, key, value string) {
+	if value != "" {
+		m.PutStr(key, value)
+	}
+}
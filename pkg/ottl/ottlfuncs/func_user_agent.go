@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ua-parser/uap-go/uaparser"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// The user_agent attributes of the semantic conventions.
+const (
+	attributeUserAgentOriginal  = "user_agent.original"
+	attributeUserAgentName      = "user_agent.name"
+	attributeUserAgentVersion   = "user_agent.version"
+	attributeUserAgentOSName    = "user_agent.os.name"
+	attributeUserAgentOSVersion = "user_agent.os.version"
+
+	// unknownFamily is the family of the user agents and operating systems that
+	// the parser does not know.
+	unknownFamily = "Other"
+)
+
+// The regexes of the parser are compiled once, the first time a UserAgent
+// function is created.
+var (
+	userAgentParser     *uaparser.Parser
+	userAgentParserOnce sync.Once
+)
+
+type UserAgentArguments[K any] struct {
+	UserAgent ottl.StringGetter[K]
+}
+
+func NewUserAgentFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("UserAgent", &UserAgentArguments[K]{}, createUserAgentFunction[K])
+}
+
+func createUserAgentFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*UserAgentArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("UserAgentFactory args must be of type *UserAgentArguments[K]")
+	}
+
+	userAgentParserOnce.Do(func() {
+		userAgentParser = uaparser.NewFromSaved()
+	})
+	return userAgent(args.UserAgent, userAgentParser), nil
+}
+
+// userAgent returns a `pcommon.Map` holding the name and version of the user agent and of
+// its operating system, keyed by the user_agent attributes of the semantic conventions.
+// The parts the parser does not know are left out.
+func userAgent[K any](target ottl.StringGetter[K], parser *uaparser.Parser) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		original, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		result := pcommon.NewMap()
+		result.PutStr(attributeUserAgentOriginal, original)
+
+		agent := parser.ParseUserAgent(original)
+		if agent.Family != unknownFamily {
+			result.PutStr(attributeUserAgentName, agent.Family)
+			putStrIfNotEmpty(result, attributeUserAgentVersion, agent.ToVersionString())
+		}
+		os := parser.ParseOs(original)
+		if os.Family != unknownFamily {
+			result.PutStr(attributeUserAgentOSName, os.Family)
+			putStrIfNotEmpty(result, attributeUserAgentOSVersion, os.ToVersionString())
+		}
+		return result, nil
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_UserAgent(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent string
+		expected  map[string]any
+	}{
+		{
+			name:      "firefox",
+			userAgent: "Mozilla/5.0 (X11; Ubuntu; Linux x86_64; rv:123.0) Gecko/20100101 Firefox/123.0",
+			expected: map[string]any{
+				"user_agent.original": "Mozilla/5.0 (X11; Ubuntu; Linux x86_64; rv:123.0) Gecko/20100101 Firefox/123.0",
+				"user_agent.name":     "Firefox",
+				"user_agent.version":  "123.0",
+				"user_agent.os.name":  "Ubuntu",
+			},
+		},
+		{
+			name:      "curl",
+			userAgent: "curl/7.81.0",
+			expected: map[string]any{
+				"user_agent.original": "curl/7.81.0",
+				"user_agent.name":     "curl",
+				"user_agent.version":  "7.81.0",
+			},
+		},
+		{
+			name:      "mobile safari",
+			userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Mobile/15E148 Safari/604.1",
+			expected: map[string]any{
+				"user_agent.original":   "Mozilla/5.0 (iPhone; CPU iPhone OS 17_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Mobile/15E148 Safari/604.1",
+				"user_agent.name":       "Mobile Safari",
+				"user_agent.version":    "17.1",
+				"user_agent.os.name":    "iOS",
+				"user_agent.os.version": "17.1",
+			},
+		},
+		{
+			name:      "unknown",
+			userAgent: "unknown-agent",
+			expected: map[string]any{
+				"user_agent.original": "unknown-agent",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := createUserAgentFunction[any](ottl.FunctionContext{}, &UserAgentArguments[any]{
+				UserAgent: &ottl.StandardStringGetter[any]{
+					Getter: func(context.Context, any) (any, error) {
+						return tt.userAgent, nil
+					},
+				},
+			})
+			require.NoError(t, err)
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.(pcommon.Map).AsRaw())
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 func StandardFuncs[K any]() map[string]ottl.Factory[K] {
 	f := []ottl.Factory[K]{
 		// Editors
+		NewAppendFactory[K](),
 		NewDeleteKeyFactory[K](),
 		NewDeleteMatchingKeysFactory[K](),
 		NewFlattenFactory[K](),
@@ -36,46 +37,71 @@ func converters[K any]() []ottl.Factory[K] {
 	return []ottl.Factory[K]{
 		// Converters
 		NewBase64DecodeFactory[K](),
+		NewBase64EncodeFactory[K](),
 		NewConcatFactory[K](),
 		NewConvertCaseFactory[K](),
+		NewDayFactory[K](),
+		NewDecodeFactory[K](),
 		NewDoubleFactory[K](),
 		NewDurationFactory[K](),
+		NewExtractGrokPatternsFactory[K](),
 		NewExtractPatternsFactory[K](),
 		NewFnvFactory[K](),
+		NewFormatTimeFactory[K](),
+		NewHexFactory[K](),
 		NewHourFactory[K](),
 		NewHoursFactory[K](),
 		NewIntFactory[K](),
+		NewIndexFactory[K](),
 		NewIsBoolFactory[K](),
 		NewIsDoubleFactory[K](),
+		NewIsInCIDRFactory[K](),
 		NewIsIntFactory[K](),
+		NewIsListFactory[K](),
 		NewIsMapFactory[K](),
 		NewIsMatchFactory[K](),
 		NewIsStringFactory[K](),
+		NewJoinFactory[K](),
 		NewLenFactory[K](),
 		NewLogFactory[K](),
+		NewMD5Factory[K](),
 		NewMicrosecondsFactory[K](),
 		NewMillisecondsFactory[K](),
+		NewMinuteFactory[K](),
 		NewMinutesFactory[K](),
+		NewMonthFactory[K](),
+		NewMurmur3HashFactory[K](),
+		NewMurmur3Hash128Factory[K](),
+		NewNanosecondFactory[K](),
 		NewNanosecondsFactory[K](),
 		NewNowFactory[K](),
 		NewParseCSVFactory[K](),
 		NewParseJSONFactory[K](),
 		NewParseKeyValueFactory[K](),
 		NewParseXMLFactory[K](),
+		NewSecondFactory[K](),
 		NewSecondsFactory[K](),
 		NewSHA1Factory[K](),
 		NewSHA256Factory[K](),
+		NewSHA512Factory[K](),
+		NewSliceFactory[K](),
+		NewSortFactory[K](),
 		NewSpanIDFactory[K](),
 		NewSplitFactory[K](),
 		NewSubstringFactory[K](),
 		NewTimeFactory[K](),
 		NewTruncateTimeFactory[K](),
 		NewTraceIDFactory[K](),
+		NewUniqueFactory[K](),
 		NewUnixFactory[K](),
 		NewUnixMicroFactory[K](),
 		NewUnixMilliFactory[K](),
 		NewUnixNanoFactory[K](),
 		NewUnixSecondsFactory[K](),
+		NewURLFactory[K](),
+		NewUserAgentFactory[K](),
 		NewUUIDFactory[K](),
+		NewWeekdayFactory[K](),
+		NewYearFactory[K](),
 	}
 }
@@ -91,6 +91,27 @@ func TestThresholdGreater(t *testing.T) {
 	))
 }
 
+func TestUnsignedConversions(t *testing.T) {
+	require.Equal(t, AlwaysSampleThreshold, must(UnsignedToThreshold(0)))
+	require.Equal(t, NeverSampleThreshold, must(UnsignedToThreshold(MaxAdjustedCount)))
+	require.Equal(t, must(TValueToThreshold("8")), must(UnsignedToThreshold(MaxAdjustedCount/2)))
+	require.Error(t, mustNot(UnsignedToThreshold(MaxAdjustedCount+1)))
+
+	require.Equal(t, uint64(MaxAdjustedCount/2), must(TValueToThreshold("8")).Unsigned())
+	require.Equal(t, "", NeverSampleThreshold.TValue())
+
+	require.Equal(t, must(RValueToRandomness("80000000000000")), must(UnsignedToRandomness(MaxAdjustedCount/2)))
+	require.Equal(t, uint64(MaxAdjustedCount-1), must(UnsignedToRandomness(MaxAdjustedCount-1)).Unsigned())
+	require.Error(t, mustNot(UnsignedToRandomness(MaxAdjustedCount)))
+}
+
+func TestThresholdAdjustedCount(t *testing.T) {
+	require.Equal(t, 1.0, AlwaysSampleThreshold.AdjustedCount())
+	require.Equal(t, 0.0, NeverSampleThreshold.AdjustedCount())
+	require.Equal(t, 2.0, must(TValueToThreshold("8")).AdjustedCount())
+	require.Equal(t, 4.0, must(TValueToThreshold("c")).AdjustedCount())
+}
+
 func TestInvalidprobabilityToTValue(t *testing.T) {
 	// Too small
 	require.Error(t, mustNot(probabilityToTValue(0x1p-57)))
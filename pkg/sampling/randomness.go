@@ -67,6 +67,24 @@ func RValueToRandomness(s string) (Randomness, error) {
 	}, nil
 }
 
+// UnsignedToRandomness constructs a Randomness from 56 bits of
+// unsigned value, returning ErrRValueSize when the value exceeds
+// the range.
+func UnsignedToRandomness(x uint64) (Randomness, error) {
+	if x >= MaxAdjustedCount {
+		return Randomness{}, ErrRValueSize
+	}
+	return Randomness{unsigned: x}, nil
+}
+
+// Unsigned returns the unsigned representation of the random value.
+// Items of data SHOULD be sampled when:
+//
+//	Threshold.Unsigned() <= Randomness.Unsigned()
+func (rnd Randomness) Unsigned() uint64 {
+	return rnd.unsigned
+}
+
 // RValue formats the r-value encoding.
 func (rnd Randomness) RValue() string {
 	// The important part here is to format a full 14-byte hex
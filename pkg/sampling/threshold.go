@@ -51,6 +51,10 @@ var (
 
 	// AlwaysSampleThreshold represents 100% sampling.
 	AlwaysSampleThreshold = Threshold{unsigned: 0}
+
+	// NeverSampleThreshold is a threshold value that will always not sample.
+	// The TValue() corresponding with this threshold is an empty string.
+	NeverSampleThreshold = Threshold{unsigned: MaxAdjustedCount}
 )
 
 // TValueToThreshold returns a Threshold.  Because TValue strings
@@ -79,6 +83,16 @@ func TValueToThreshold(s string) (Threshold, error) {
 	}, nil
 }
 
+// UnsignedToThreshold constructs a threshold expressed in terms
+// defined by number of rejections out of MaxAdjustedCount, which
+// equals the number of randomness values.
+func UnsignedToThreshold(unsigned uint64) (Threshold, error) {
+	if unsigned > MaxAdjustedCount {
+		return NeverSampleThreshold, ErrTValueSize
+	}
+	return Threshold{unsigned: unsigned}, nil
+}
+
 // TValue encodes a threshold, which is a variable-length hex string
 // up to 14 characters.  The empty string is returned for 100%
 // sampling.
@@ -88,6 +102,11 @@ func (th Threshold) TValue() string {
 	if th == AlwaysSampleThreshold {
 		return "0"
 	}
+	// Never-sample has no encoding, it is represented by the
+	// absence of a threshold.
+	if th == NeverSampleThreshold {
+		return ""
+	}
 	// For thresholds other than the extremes, format a full-width
 	// (14 digit) unsigned value with leading zeros, then, remove
 	// the trailing zeros.  Use the logic for (Randomness).RValue().
@@ -103,6 +122,23 @@ func (th Threshold) ShouldSample(rnd Randomness) bool {
 	return rnd.unsigned >= th.unsigned
 }
 
+// Unsigned expresses the number of Randomness values (out of
+// MaxAdjustedCount) that are rejected or not sampled.  0 means 100%
+// sampling.
+func (th Threshold) Unsigned() uint64 {
+	return th.unsigned
+}
+
+// AdjustedCount returns the effective count for this threshold.
+// This is the inverse of the sampling probability.  Zero is
+// returned for the never-sample threshold.
+func (th Threshold) AdjustedCount() float64 {
+	if th == NeverSampleThreshold {
+		return 0
+	}
+	return 1.0 / th.Probability()
+}
+
 // ThresholdGreater allows direct comparison of Threshold values.
 // Greater thresholds equate with smaller sampling probabilities.
 func ThresholdGreater(a, b Threshold) bool {
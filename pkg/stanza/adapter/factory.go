@@ -44,6 +44,11 @@ func createLogsReceiver(logReceiverType LogReceiverType) rcvr.CreateLogsFunc {
 		baseCfg := logReceiverType.BaseConfig(cfg)
 
 		operators := append([]operator.Config{inputCfg}, baseCfg.Operators...)
+		for _, op := range operators {
+			if s, ok := op.Builder.(operator.MeterProviderSetter); ok {
+				s.SetMeterProvider(params.MeterProvider)
+			}
+		}
 
 		emitterOpts := []emitterOption{}
 		if baseCfg.maxBatchSize > 0 {
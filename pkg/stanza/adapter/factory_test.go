@@ -11,6 +11,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/json"
@@ -31,6 +33,23 @@ func TestCreateReceiver(t *testing.T) {
 		require.NotNil(t, receiver, "receiver creation failed")
 	})
 
+	t.Run("SetsMeterProvider", func(t *testing.T) {
+		factory := NewFactory(TestReceiverType{}, component.StabilityLevelDevelopment)
+		cfg := factory.CreateDefaultConfig().(*TestConfig)
+		builder := &meterProviderRecorder{Config: json.NewConfig()}
+		cfg.Operators = []operator.Config{
+			{
+				Builder: builder,
+			},
+		}
+		set := receivertest.NewNopCreateSettings()
+		set.MeterProvider = sdkmetric.NewMeterProvider()
+		receiver, err := factory.CreateLogsReceiver(context.Background(), set, cfg, consumertest.NewNop())
+		require.NoError(t, err, "receiver creation failed")
+		require.NotNil(t, receiver, "receiver creation failed")
+		require.Same(t, set.MeterProvider, builder.meterProvider)
+	})
+
 	t.Run("DecodeOperatorConfigsFailureMissingFields", func(t *testing.T) {
 		factory := NewFactory(TestReceiverType{}, component.StabilityLevelDevelopment)
 		badCfg := factory.CreateDefaultConfig().(*TestConfig)
@@ -44,3 +63,12 @@ func TestCreateReceiver(t *testing.T) {
 		require.Nil(t, receiver, "receiver creation should fail if parser configs aren't valid")
 	})
 }
+
+type meterProviderRecorder struct {
+	*json.Config
+	meterProvider metric.MeterProvider
+}
+
+func (r *meterProviderRecorder) SetMeterProvider(mp metric.MeterProvider) {
+	r.meterProvider = mp
+}
@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileconsumer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/emittest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/filetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func TestReadCompressedFiles(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		compression     string
+		fileCompression string
+	}{
+		{compression: "gzip", fileCompression: "gzip"},
+		{compression: "zstd", fileCompression: "zstd"},
+		{compression: "auto", fileCompression: "gzip"},
+		{compression: "auto", fileCompression: "zstd"},
+		{compression: "auto"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(fmt.Sprintf("%s_%s", tc.compression, tc.fileCompression), func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			cfg := NewConfig().includeDir(tempDir)
+			cfg.StartAt = "beginning"
+			cfg.Compression = tc.compression
+			operator, sink := testManager(t, cfg)
+
+			writeCompressedFile(t, filepath.Join(tempDir, "app.log"), tc.fileCompression, "testlog1\ntestlog2\n")
+
+			operator.poll(context.Background())
+			sink.ExpectTokens(t, []byte("testlog1"), []byte("testlog2"))
+		})
+	}
+}
+
+func TestReadCompressedFilesStartAtEnd(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.Compression = "auto"
+	operator, sink := testManager(t, cfg)
+
+	file := filepath.Join(tempDir, "app.log.gz")
+	writeCompressedFile(t, file, "gzip", "testlog1\n")
+	operator.poll(context.Background())
+	sink.ExpectNoCalls(t)
+
+	// a gzip file can be made of several members, which are decompressed one after the other
+	appendCompressedFile(t, file, "gzip", "testlog2\n")
+	operator.poll(context.Background())
+	sink.ExpectToken(t, []byte("testlog2"))
+}
+
+// TestRotatedToCompressedFile tests that a file which is rotated and compressed is identified
+// by its decompressed content, and that only the logs written after the last poll are read from it
+func TestRotatedToCompressedFile(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = "auto"
+	operator, sink := testManager(t, cfg)
+	operator.persister = testutil.NewUnscopedMockPersister()
+
+	logFile := filepath.Join(tempDir, "app.log")
+	temp := filetest.OpenFile(t, logFile)
+	filetest.WriteString(t, temp, "testlog1\ntestlog2\n")
+	operator.poll(context.Background())
+	sink.ExpectTokens(t, []byte("testlog1"), []byte("testlog2"))
+
+	filetest.WriteString(t, temp, "testlog3\n")
+	require.NoError(t, temp.Close())
+	content, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+	writeCompressedFile(t, logFile+".1.gz", "gzip", string(content))
+	require.NoError(t, os.Remove(logFile))
+
+	operator.poll(context.Background())
+	sink.ExpectToken(t, []byte("testlog3"))
+	operator.poll(context.Background())
+	sink.ExpectNoCalls(t)
+}
+
+// TestReadPartiallyWrittenCompressedFile tests that a compressed file is read as it is written
+func TestReadPartiallyWrittenCompressedFile(t *testing.T) {
+	t.Parallel()
+
+	for _, compression := range []string{"gzip", "zstd"} {
+		compression := compression
+		t.Run(compression, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			cfg := NewConfig().includeDir(tempDir)
+			cfg.StartAt = "beginning"
+			cfg.Compression = compression
+			sink := emittest.NewSink(emittest.WithCallBuffer(1000))
+			operator := testManagerWithSink(t, cfg, sink)
+
+			var content bytes.Buffer
+			for i := 0; i < 1000; i++ {
+				content.WriteString(fmt.Sprintf("testlog%d\n", i))
+			}
+			compressed := compressContent(t, compression, content.String())
+
+			file := filetest.OpenFile(t, filepath.Join(tempDir, "app.log"))
+			for _, part := range [][]byte{compressed[:len(compressed)/2], compressed[len(compressed)/2:]} {
+				_, err := file.Write(part)
+				require.NoError(t, err)
+				operator.poll(context.Background())
+			}
+			for i := 0; i < 1000; i++ {
+				sink.ExpectToken(t, []byte(fmt.Sprintf("testlog%d", i)))
+			}
+			sink.ExpectNoCalls(t)
+		})
+	}
+}
+
+// TestCompressedFileCheckpoints tests that the offsets of compressed files are restored after a restart
+func TestCompressedFileCheckpoints(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = "zstd"
+	persister := testutil.NewUnscopedMockPersister()
+
+	file := filepath.Join(tempDir, "app.log.zst")
+	writeCompressedFile(t, file, "zstd", "testlog1\n")
+
+	operator, sink := testManager(t, cfg)
+	require.NoError(t, operator.Start(persister))
+	sink.ExpectToken(t, []byte("testlog1"))
+	require.NoError(t, operator.Stop())
+
+	// zstd frames can be concatenated as well
+	appendCompressedFile(t, file, "zstd", "testlog2\n")
+
+	operator, sink = testManager(t, cfg)
+	require.NoError(t, operator.Start(persister))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+	sink.ExpectToken(t, []byte("testlog2"))
+	sink.ExpectNoCalls(t)
+}
+
+func compressContent(t *testing.T, compression string, content string) []byte {
+	var buf bytes.Buffer
+	switch compression {
+	case "gzip":
+		w := gzip.NewWriter(&buf)
+		_, err := w.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+	case "zstd":
+		w, err := zstd.NewWriter(&buf)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+	default:
+		buf.WriteString(content)
+	}
+	return buf.Bytes()
+}
+
+func writeCompressedFile(t *testing.T, path string, compression string, content string) {
+	require.NoError(t, os.WriteFile(path, compressContent(t, compression, content), 0600))
+}
+
+func appendCompressedFile(t *testing.T, path string, compression string, content string) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = file.Write(compressContent(t, compression, content))
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+}
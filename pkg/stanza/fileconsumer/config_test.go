@@ -402,6 +402,14 @@ func TestUnmarshal(t *testing.T) {
 					return newMockOperatorConfig(cfg)
 				}(),
 			},
+			{
+				Name: "compression_auto",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.Compression = "auto"
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
 			{
 				Name: "header_config",
 				Expect: func() *mockOperatorConfig {
@@ -554,6 +562,14 @@ func TestBuild(t *testing.T) {
 			require.Error,
 			nil,
 		},
+		{
+			"InvalidCompression",
+			func(cfg *Config) {
+				cfg.Compression = "bzip2"
+			},
+			require.Error,
+			nil,
+		},
 		{
 			"ValidMaxBatches",
 			func(cfg *Config) {
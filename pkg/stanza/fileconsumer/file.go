@@ -195,6 +195,7 @@ func (m *Manager) makeFingerprint(path string) (*fingerprint.Fingerprint, *os.Fi
 
 	fp, err := m.readerFactory.NewFingerprint(file)
 	if err != nil {
+		m.Debugw("Failed to create fingerprint", zap.Error(err))
 		if err = file.Close(); err != nil {
 			m.Debugw("problem closing file", zap.Error(err))
 		}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reader // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/reader"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
+)
+
+const (
+	CompressionAuto = "auto"
+	CompressionGzip = "gzip"
+	CompressionZstd = "zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// detectCompression returns the compression of the file, or an empty string if it is read as it is.
+// With the auto compression, the compression is detected from the first bytes of the file.
+func detectCompression(file *os.File, compression string) (string, error) {
+	if compression != CompressionAuto {
+		return compression, nil
+	}
+
+	magic := make([]byte, len(zstdMagic))
+	n, err := file.ReadAt(magic, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("reading magic bytes: %w", err)
+	}
+	switch {
+	case bytes.HasPrefix(magic[:n], gzipMagic):
+		return CompressionGzip, nil
+	case bytes.HasPrefix(magic[:n], zstdMagic):
+		return CompressionZstd, nil
+	default:
+		return "", nil
+	}
+}
+
+// newDecompressor returns a reader of the decompressed content of the file, from its beginning.
+// It does not move the offset of the file.
+func newDecompressor(file *os.File, compression string) (io.ReadCloser, error) {
+	compressed := io.NewSectionReader(file, 0, math.MaxInt64)
+	switch compression {
+	case CompressionGzip:
+		return gzip.NewReader(compressed)
+	case CompressionZstd:
+		decoder, err := zstd.NewReader(compressed, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return decoder.IOReadCloser(), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression %q", compression)
+	}
+}
+
+// newFingerprint creates the fingerprint of the file. The fingerprint of a compressed file is made of
+// its first decompressed bytes, so that it matches the fingerprint of the file before it was compressed.
+func newFingerprint(file *os.File, compression string, size int) (*fingerprint.Fingerprint, error) {
+	compression, err := detectCompression(file, compression)
+	if err != nil {
+		return nil, err
+	}
+	if compression == "" {
+		return fingerprint.NewFromFile(file, size)
+	}
+
+	decompressor, err := newDecompressor(file, compression)
+	if isIncomplete(err) {
+		// the file is empty, or its header is not fully written yet
+		return fingerprint.New([]byte{}), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decompressing fingerprint bytes: %w", err)
+	}
+	defer decompressor.Close()
+
+	buf := make([]byte, size)
+	n, err := io.ReadFull(decompressor, buf)
+	if err != nil && !isIncomplete(err) {
+		return nil, fmt.Errorf("decompressing fingerprint bytes: %w", err)
+	}
+	return fingerprint.New(buf[:n]), nil
+}
+
+// decompressedSize returns the number of bytes which can be decompressed from the file
+func decompressedSize(file *os.File, compression string) (int64, error) {
+	decompressor, err := newDecompressor(file, compression)
+	if isIncomplete(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer decompressor.Close()
+
+	n, err := io.Copy(io.Discard, decompressor)
+	if isIncomplete(err) {
+		err = nil
+	}
+	return n, err
+}
+
+// isIncomplete returns true if the error is caused by the end of a compressed file,
+// which might still be written
+func isIncomplete(err error) bool {
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reader
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/filetest"
+)
+
+func appendGzip(t *testing.T, path string, content string) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = file.Write(buf.Bytes())
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+}
+
+// TestCompressedFileNotDecompressedAgain tests that a compressed file which was read to the end
+// is only decompressed again once it changes
+func TestCompressedFileNotDecompressedAgain(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "app.log.gz")
+	appendGzip(t, path, "testlog1\ntestlog2\n")
+
+	f, sink := testFactory(t)
+	f.Compression = CompressionAuto
+	fp, err := f.NewFingerprint(filetest.OpenFile(t, path))
+	require.NoError(t, err)
+
+	r, err := f.NewReader(filetest.OpenFile(t, path), fp)
+	require.NoError(t, err)
+	r.ReadToEnd(context.Background())
+	sink.ExpectTokens(t, []byte("testlog1"), []byte("testlog2"))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, info.Size(), r.CompressedSize)
+
+	// the next polls create a reader from the metadata of the previous one
+	r, err = f.NewReaderFromMetadata(filetest.OpenFile(t, path), r.Close())
+	require.NoError(t, err)
+	r.ReadToEnd(context.Background())
+	require.Nil(t, r.decompressor)
+	sink.ExpectNoCalls(t)
+
+	appendGzip(t, path, "testlog3\n")
+	r, err = f.NewReaderFromMetadata(filetest.OpenFile(t, path), r.Close())
+	require.NoError(t, err)
+	r.ReadToEnd(context.Background())
+	require.NotNil(t, r.decompressor)
+	sink.ExpectToken(t, []byte("testlog3"))
+	r.Close()
+}
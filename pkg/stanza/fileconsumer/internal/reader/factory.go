@@ -41,10 +41,11 @@ type Factory struct {
 	EmitFunc          emit.Callback
 	Attributes        attrs.Resolver
 	DeleteAtEOF       bool
+	Compression       string
 }
 
 func (f *Factory) NewFingerprint(file *os.File) (*fingerprint.Fingerprint, error) {
-	return fingerprint.NewFromFile(file, f.FingerprintSize)
+	return newFingerprint(file, f.Compression, f.FingerprintSize)
 }
 
 func (f *Factory) NewReader(file *os.File, fp *fingerprint.Fingerprint) (*Reader, error) {
@@ -60,6 +61,11 @@ func (f *Factory) NewReader(file *os.File, fp *fingerprint.Fingerprint) (*Reader
 }
 
 func (f *Factory) NewReaderFromMetadata(file *os.File, m *Metadata) (r *Reader, err error) {
+	compression, err := detectCompression(file, f.Compression)
+	if err != nil {
+		return nil, err
+	}
+
 	r = &Reader{
 		Metadata:          m,
 		logger:            f.SugaredLogger.With("path", file.Name()),
@@ -71,11 +77,12 @@ func (f *Factory) NewReaderFromMetadata(file *os.File, m *Metadata) (r *Reader,
 		decoder:           decode.New(f.Encoding),
 		lineSplitFunc:     f.SplitFunc,
 		deleteAtEOF:       f.DeleteAtEOF,
+		compression:       compression,
 	}
 
 	if r.Fingerprint.Len() > r.fingerprintSize {
 		// User has reconfigured fingerprint_size
-		shorter, rereadErr := fingerprint.NewFromFile(file, r.fingerprintSize)
+		shorter, rereadErr := newFingerprint(file, r.compression, r.fingerprintSize)
 		if rereadErr != nil {
 			return nil, fmt.Errorf("reread fingerprint: %w", err)
 		}
@@ -90,7 +97,15 @@ func (f *Factory) NewReaderFromMetadata(file *os.File, m *Metadata) (r *Reader,
 		if info, err = r.file.Stat(); err != nil {
 			return nil, fmt.Errorf("stat: %w", err)
 		}
-		r.Offset = info.Size()
+		if r.compression != "" {
+			// the offsets of compressed files are in the decompressed content
+			if r.Offset, err = decompressedSize(file, r.compression); err != nil {
+				return nil, fmt.Errorf("decompress: %w", err)
+			}
+			r.CompressedSize = info.Size()
+		} else {
+			r.Offset = info.Size()
+		}
 	}
 
 	flushFunc := m.FlushState.Func(f.SplitFunc, f.FlushTimeout)
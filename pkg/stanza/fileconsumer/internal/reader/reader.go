@@ -7,6 +7,7 @@ import (
 	"bufio"
 	"context"
 	"errors"
+	"io"
 	"os"
 
 	"go.uber.org/zap"
@@ -19,12 +20,23 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/flush"
 )
 
+var (
+	// errOffsetNotDecompressed is returned when the offset is beyond the end of the decompressed content,
+	// which happens when a compressed file is not fully written yet
+	errOffsetNotDecompressed = errors.New("offset not decompressed yet")
+	// errNotModified is returned when a compressed file did not change since it was read to the end,
+	// so that it is not decompressed again
+	errNotModified = errors.New("compressed file not modified")
+)
+
 type Metadata struct {
 	Fingerprint     *fingerprint.Fingerprint
 	Offset          int64
 	FileAttributes  map[string]any
 	HeaderFinalized bool
 	FlushState      *flush.State
+	// CompressedSize is the size of a compressed file when its decompressed content was last read to the end
+	CompressedSize int64
 }
 
 // Reader manages a single file
@@ -44,12 +56,20 @@ type Reader struct {
 	emitFunc               emit.Callback
 	deleteAtEOF            bool
 	needsUpdateFingerprint bool
+	compression            string
+	decompressor           io.ReadCloser
+	// compressedSize is the size of the compressed file when the decompressor was created
+	compressedSize int64
+	// decompressedOffset is the offset of the decompressor in the decompressed content
+	decompressedOffset int64
 }
 
 // ReadToEnd will read until the end of the file
 func (r *Reader) ReadToEnd(ctx context.Context) {
-	if _, err := r.file.Seek(r.Offset, 0); err != nil {
-		r.logger.Errorw("Failed to seek", zap.Error(err))
+	if err := r.seek(); err != nil {
+		if !errors.Is(err, errOffsetNotDecompressed) && !errors.Is(err, errNotModified) {
+			r.logger.Errorw("Failed to seek", zap.Error(err))
+		}
 		return
 	}
 
@@ -73,7 +93,13 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 		if !ok {
 			if err := s.Error(); err != nil {
 				r.logger.Errorw("Failed during scan", zap.Error(err))
-			} else if r.deleteAtEOF {
+				return
+			}
+			if r.decompressor != nil && r.Offset == r.decompressedOffset {
+				// all the decompressed content was emitted
+				r.CompressedSize = r.compressedSize
+			}
+			if r.deleteAtEOF {
 				r.delete()
 			}
 			return
@@ -112,7 +138,7 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 		// Recreate the scanner with the normal split func.
 		// Do not use the updated offset from the old scanner, as the most recent token
 		// could be split differently with the new splitter.
-		if _, err = r.file.Seek(r.Offset, 0); err != nil {
+		if err = r.seek(); err != nil {
 			r.logger.Errorw("Failed to seek post-header", zap.Error(err))
 			return
 		}
@@ -120,6 +146,49 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 	}
 }
 
+// seek moves the file to the offset. Compressed files are decompressed again from their beginning,
+// and the decompressed bytes before the offset are skipped, unless they did not change since their
+// decompressed content was read to the end.
+func (r *Reader) seek() error {
+	if r.compression == "" {
+		_, err := r.file.Seek(r.Offset, 0)
+		return err
+	}
+
+	info, err := r.file.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() == r.CompressedSize {
+		return errNotModified
+	}
+
+	r.closeDecompressor()
+	r.compressedSize = info.Size()
+	decompressor, err := newDecompressor(r.file, r.compression)
+	if isIncomplete(err) {
+		return errOffsetNotDecompressed
+	}
+	if err != nil {
+		return err
+	}
+	r.decompressor = decompressor
+	r.decompressedOffset, err = io.CopyN(io.Discard, r.decompressor, r.Offset)
+	if isIncomplete(err) {
+		return errOffsetNotDecompressed
+	}
+	return err
+}
+
+func (r *Reader) closeDecompressor() {
+	if r.decompressor != nil {
+		if err := r.decompressor.Close(); err != nil {
+			r.logger.Debugw("Problem closing decompressor", zap.Error(err))
+		}
+		r.decompressor = nil
+	}
+}
+
 // Delete will close and delete the file
 func (r *Reader) delete() {
 	r.close()
@@ -137,6 +206,7 @@ func (r *Reader) Close() *Metadata {
 }
 
 func (r *Reader) close() {
+	r.closeDecompressor()
 	if r.file != nil {
 		if err := r.file.Close(); err != nil {
 			r.logger.Debugw("Problem closing reader", zap.Error(err))
@@ -153,7 +223,16 @@ func (r *Reader) close() {
 
 // Read from the file and update the fingerprint if necessary
 func (r *Reader) Read(dst []byte) (n int, err error) {
-	n, err = r.file.Read(dst)
+	if r.decompressor != nil {
+		n, err = r.decompressor.Read(dst)
+		r.decompressedOffset += int64(n)
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			// the rest of the file might not be written yet
+			err = io.EOF
+		}
+	} else {
+		n, err = r.file.Read(dst)
+	}
 	if n == 0 || err != nil {
 		return
 	}
@@ -173,7 +252,7 @@ func (r *Reader) Validate() bool {
 	if r.file == nil {
 		return false
 	}
-	refreshedFingerprint, err := fingerprint.NewFromFile(r.file, r.fingerprintSize)
+	refreshedFingerprint, err := newFingerprint(r.file, r.compression, r.fingerprintSize)
 	if err != nil {
 		return false
 	}
@@ -192,7 +271,7 @@ func (r *Reader) updateFingerprint() {
 	if r.file == nil {
 		return
 	}
-	refreshedFingerprint, err := fingerprint.NewFromFile(r.file, r.fingerprintSize)
+	refreshedFingerprint, err := newFingerprint(r.file, r.compression, r.fingerprintSize)
 	if err != nil {
 		return
 	}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 )
 
@@ -29,6 +30,12 @@ type Builder interface {
 	SetID(string)
 }
 
+// MeterProviderSetter is implemented by the builders of operators which report metrics.
+// It is used to provide them with the meter provider of the component running the operators.
+type MeterProviderSetter interface {
+	SetMeterProvider(metric.MeterProvider)
+}
+
 // UnmarshalJSON will unmarshal a config from JSON.
 func (c *Config) UnmarshalJSON(bytes []byte) error {
 	var typeUnmarshaller struct {
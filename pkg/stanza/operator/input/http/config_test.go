@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"path/filepath"
+	"testing"
+
+	"go.opentelemetry.io/collector/config/configtls"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/operatortest"
+)
+
+func TestUnmarshal(t *testing.T) {
+	operatortest.ConfigUnmarshalTests{
+		DefaultConfig: NewConfig(),
+		TestsFile:     filepath.Join(".", "testdata", "config.yaml"),
+		Tests: []operatortest.ConfigUnmarshalTest{
+			{
+				Name:      "default",
+				ExpectErr: false,
+				Expect:    NewConfig(),
+			},
+			{
+				Name:      "all",
+				ExpectErr: false,
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.ListenAddress = "10.0.0.1:9000"
+					cfg.Path = "/logs"
+					cfg.MaxRequestBodySize = 1000000
+					cfg.Format = FormatJSON
+					cfg.AddAttributes = true
+					bodyField := entry.NewBodyField("message")
+					cfg.BodyField = &bodyField
+					timeParser := helper.NewTimeParser()
+					timeField := entry.NewBodyField("time")
+					timeParser.ParseFrom = &timeField
+					timeParser.LayoutType = helper.GotimeKey
+					timeParser.Layout = "2006-01-02T15:04:05Z07:00"
+					cfg.TimeParser = &timeParser
+					severity := helper.NewSeverityConfig()
+					severityField := entry.NewBodyField("level")
+					severity.ParseFrom = &severityField
+					cfg.SeverityConfig = &severity
+					cfg.TLS = &configtls.ServerConfig{
+						TLSSetting: configtls.Config{
+							CertFile: "foo",
+							KeyFile:  "foo2",
+						},
+					}
+					return cfg
+				}(),
+			},
+		},
+	}.Run(t)
+}
@@ -0,0 +1,451 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package http // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/http"
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	operatorType = "http_input"
+
+	// DefaultMaxRequestBodySize is the max size of the request bodies
+	// if MaxRequestBodySize is not set
+	DefaultMaxRequestBodySize = 10 * 1024 * 1024
+
+	// FormatAuto detects the format of the request bodies from their Content-Type header
+	FormatAuto = "auto"
+	// FormatJSON reads the request bodies as JSON values, such as objects, arrays or newline delimited objects
+	FormatJSON = "json"
+	// FormatText reads the request bodies as lines of text
+	FormatText = "text"
+
+	readHeaderTimeout = 20 * time.Second
+)
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new HTTP input config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new HTTP input config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		InputConfig: helper.NewInputConfig(operatorID, operatorType),
+		BaseConfig: BaseConfig{
+			Path:   "/",
+			Format: FormatAuto,
+		},
+	}
+}
+
+// Config is the configuration of an HTTP input operator.
+type Config struct {
+	helper.InputConfig `mapstructure:",squash"`
+	BaseConfig         `mapstructure:",squash"`
+}
+
+// BaseConfig is the detailed configuration of an HTTP input operator.
+type BaseConfig struct {
+	ListenAddress      string                  `mapstructure:"listen_address,omitempty"`
+	Path               string                  `mapstructure:"path,omitempty"`
+	TLS                *configtls.ServerConfig `mapstructure:"tls,omitempty"`
+	MaxRequestBodySize helper.ByteSize         `mapstructure:"max_request_body_size,omitempty"`
+	Format             string                  `mapstructure:"format,omitempty"`
+	AddAttributes      bool                    `mapstructure:"add_attributes,omitempty"`
+	BodyField          *entry.Field            `mapstructure:"body,omitempty"`
+	TimeParser         *helper.TimeParser      `mapstructure:"timestamp,omitempty"`
+	SeverityConfig     *helper.SeverityConfig  `mapstructure:"severity,omitempty"`
+}
+
+// Build will build an HTTP input operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	inputOperator, err := c.InputConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	// If MaxRequestBodySize not set, set sane default
+	if c.MaxRequestBodySize == 0 {
+		c.MaxRequestBodySize = DefaultMaxRequestBodySize
+	}
+
+	if c.MaxRequestBodySize < 0 {
+		return nil, fmt.Errorf("invalid value for parameter 'max_request_body_size', must be positive")
+	}
+
+	if c.ListenAddress == "" {
+		return nil, fmt.Errorf("missing required parameter 'listen_address'")
+	}
+
+	// validate the input address
+	if _, err = net.ResolveTCPAddr("tcp", c.ListenAddress); err != nil {
+		return nil, fmt.Errorf("failed to resolve listen_address: %w", err)
+	}
+
+	if !strings.HasPrefix(c.Path, "/") {
+		return nil, fmt.Errorf("invalid value '%s' for parameter 'path', must start with '/'", c.Path)
+	}
+
+	switch c.Format {
+	case FormatAuto, FormatJSON, FormatText:
+	default:
+		return nil, fmt.Errorf("invalid value '%s' for parameter 'format'", c.Format)
+	}
+
+	httpInput := &Input{
+		InputOperator:      inputOperator,
+		address:            c.ListenAddress,
+		path:               c.Path,
+		maxRequestBodySize: int64(c.MaxRequestBodySize),
+		format:             c.Format,
+		addAttributes:      c.AddAttributes,
+		bodyField:          c.BodyField,
+	}
+
+	if c.TimeParser != nil {
+		if err := c.TimeParser.Validate(); err != nil {
+			return nil, err
+		}
+		httpInput.timeParser = c.TimeParser
+	}
+
+	if c.SeverityConfig != nil {
+		severityParser, err := c.SeverityConfig.Build(logger)
+		if err != nil {
+			return nil, err
+		}
+		httpInput.severityParser = &severityParser
+	}
+
+	if c.TLS != nil {
+		httpInput.tls, err = c.TLS.LoadTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return httpInput, nil
+}
+
+// Input is an operator that receives log entries in the bodies of HTTP requests.
+type Input struct {
+	helper.InputOperator
+	address            string
+	path               string
+	maxRequestBodySize int64
+	format             string
+	addAttributes      bool
+	bodyField          *entry.Field
+	timeParser         *helper.TimeParser
+	severityParser     *helper.SeverityParser
+
+	listener net.Listener
+	server   *http.Server
+	wg       sync.WaitGroup
+	tls      *tls.Config
+}
+
+// Start will start listening for HTTP requests.
+func (h *Input) Start(_ operator.Persister) error {
+	if err := h.configureListener(); err != nil {
+		return fmt.Errorf("failed to listen on interface: %w", err)
+	}
+
+	h.server = &http.Server{
+		Handler:           h,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		if err := h.server.Serve(h.listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			h.Errorw("HTTP server error", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+func (h *Input) configureListener() error {
+	if h.tls == nil {
+		listener, err := net.Listen("tcp", h.address)
+		if err != nil {
+			return fmt.Errorf("failed to configure http listener: %w", err)
+		}
+		h.listener = listener
+		return nil
+	}
+
+	h.tls.Time = time.Now
+	h.tls.Rand = rand.Reader
+
+	listener, err := tls.Listen("tcp", h.address, h.tls)
+	if err != nil {
+		return fmt.Errorf("failed to configure tls listener: %w", err)
+	}
+
+	h.listener = listener
+	return nil
+}
+
+// ServeHTTP splits the body of a request into log entries and writes them to the output operators.
+func (h *Input) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path != h.path {
+		http.NotFound(w, req)
+		return
+	}
+
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, fmt.Sprintf("method %s not allowed", req.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, status, err := h.readBody(w, req)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	records, err := h.splitRecords(req, body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	for _, record := range records {
+		h.handleRecord(req, record)
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// readBody reads the decompressed body of a request, limited to max_request_body_size
+func (h *Input) readBody(w http.ResponseWriter, req *http.Request) ([]byte, int, error) {
+	var r io.Reader = http.MaxBytesReader(w, req.Body, h.maxRequestBodySize)
+
+	switch req.Header.Get("Content-Encoding") {
+	case "", "identity":
+	case "gzip", "x-gzip":
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, readErrorStatus(err), fmt.Errorf("failed to read gzip body: %w", err)
+		}
+		defer gr.Close()
+		// The decompressed body is limited as well, so that small gzip bodies can't exhaust the memory
+		r = io.LimitReader(gr, h.maxRequestBodySize+1)
+	default:
+		return nil, http.StatusUnsupportedMediaType, fmt.Errorf("unsupported content encoding %q", req.Header.Get("Content-Encoding"))
+	}
+
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return nil, readErrorStatus(err), fmt.Errorf("failed to read body: %w", err)
+	}
+	if int64(len(body)) > h.maxRequestBodySize {
+		return nil, http.StatusRequestEntityTooLarge, fmt.Errorf("body exceeds %d bytes", h.maxRequestBodySize)
+	}
+	return body, http.StatusOK, nil
+}
+
+func readErrorStatus(err error) int {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
+
+// splitRecords splits the body of a request into one value per log entry, according to its format
+func (h *Input) splitRecords(req *http.Request, body []byte) ([]any, error) {
+	format := h.format
+	if format == FormatAuto {
+		format = formatFromContentType(req.Header.Get("Content-Type"))
+	}
+
+	if format == FormatText {
+		return splitLines(body), nil
+	}
+	return splitJSON(body)
+}
+
+// formatFromContentType returns the format of the JSON and NDJSON media types, and the text format otherwise
+func formatFromContentType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return FormatText
+	}
+	switch {
+	case mediaType == "application/json",
+		mediaType == "application/x-ndjson",
+		mediaType == "application/ndjson",
+		mediaType == "application/jsonl",
+		mediaType == "application/x-jsonlines",
+		strings.HasSuffix(mediaType, "+json"):
+		return FormatJSON
+	}
+	return FormatText
+}
+
+// splitJSON reads a sequence of JSON values, such as a single object or newline delimited objects,
+// and expands the arrays into their elements
+func splitJSON(body []byte) ([]any, error) {
+	var records []any
+	dec := json.NewDecoder(bytes.NewReader(body))
+	// numbers are decoded as integers when they are, so that large ones such as epoch timestamps
+	// in nanoseconds don't lose precision as floats
+	dec.UseNumber()
+	for {
+		var value any
+		if err := dec.Decode(&value); err != nil {
+			if errors.Is(err, io.EOF) {
+				return records, nil
+			}
+			return nil, fmt.Errorf("failed to parse json body: %w", err)
+		}
+		value = convertNumbers(value)
+		if values, ok := value.([]any); ok {
+			records = append(records, values...)
+			continue
+		}
+		records = append(records, value)
+	}
+}
+
+// convertNumbers replaces the json.Number values of a decoded value with int64 values, or float64
+// values for the numbers which are not integers or overflow int64
+func convertNumbers(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case map[string]any:
+		for key, item := range v {
+			v[key] = convertNumbers(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = convertNumbers(item)
+		}
+	}
+	return value
+}
+
+// splitLines returns the non-empty lines of a body
+func splitLines(body []byte) []any {
+	var records []any
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), len(body)+1)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			records = append(records, line)
+		}
+	}
+	return records
+}
+
+func (h *Input) handleRecord(req *http.Request, record any) {
+	entry, err := h.NewEntry(record)
+	if err != nil {
+		h.Errorw("Failed to create entry", zap.Error(err))
+		return
+	}
+
+	if h.timeParser != nil {
+		if err := h.timeParser.Parse(entry); err != nil {
+			h.Warnw("Failed to parse timestamp", zap.Error(err))
+		}
+	}
+
+	if h.severityParser != nil {
+		if err := h.severityParser.Parse(entry); err != nil {
+			h.Warnw("Failed to parse severity", zap.Error(err))
+		}
+	}
+
+	if h.bodyField != nil {
+		h.setBody(entry)
+	}
+
+	if h.addAttributes {
+		entry.AddAttribute("net.transport", "IP.TCP")
+		if host, port, err := net.SplitHostPort(req.RemoteAddr); err == nil {
+			entry.AddAttribute("net.peer.ip", host)
+			entry.AddAttribute("net.peer.port", port)
+		}
+		if addr, ok := req.Context().Value(http.LocalAddrContextKey).(*net.TCPAddr); ok {
+			entry.AddAttribute("net.host.ip", addr.IP.String())
+			entry.AddAttribute("net.host.port", strconv.FormatInt(int64(addr.Port), 10))
+		}
+	}
+
+	h.Write(req.Context(), entry)
+}
+
+// setBody replaces the body of the entry with the value of the body field,
+// and moves the other fields of a structured body to the attributes
+func (h *Input) setBody(entry *entry.Entry) {
+	value, ok := entry.Delete(h.bodyField)
+	if !ok {
+		return
+	}
+
+	if fields, ok := entry.Body.(map[string]any); ok {
+		if entry.Attributes == nil {
+			entry.Attributes = make(map[string]any, len(fields))
+		}
+		for k, v := range fields {
+			entry.Attributes[k] = v
+		}
+	}
+	entry.Body = value
+}
+
+// Stop will stop listening for HTTP requests.
+func (h *Input) Stop() error {
+	if h.server == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := h.server.Shutdown(ctx); err != nil {
+		h.Errorf("failed to shutdown HTTP server: %s", err)
+	}
+
+	h.wg.Wait()
+	return nil
+}
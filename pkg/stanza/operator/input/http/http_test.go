@@ -0,0 +1,370 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func startInput(t *testing.T, cfg *Config) (*Input, *testutil.FakeOutput, string) {
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	httpInput := op.(*Input)
+	httpInput.InputOperator.OutputOperators = []operator.Operator{fake}
+
+	require.NoError(t, httpInput.Start(testutil.NewUnscopedMockPersister()))
+	t.Cleanup(func() {
+		require.NoError(t, httpInput.Stop(), "expected to stop http input operator without error")
+	})
+	return httpInput, fake, "http://" + httpInput.listener.Addr().String()
+}
+
+func post(t *testing.T, url, contentType, contentEncoding string, body []byte) int {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	require.NoError(t, err)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if contentEncoding != "" {
+		req.Header.Set("Content-Encoding", contentEncoding)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	return resp.StatusCode
+}
+
+func gzipped(t *testing.T, data []byte) []byte {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	_, err := gw.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func TestInput(t *testing.T) {
+	cases := []struct {
+		name            string
+		format          string
+		contentType     string
+		contentEncoding string
+		body            string
+		expected        []any
+	}{
+		{
+			name:        "JSONObject",
+			contentType: "application/json",
+			body:        `{"message":"hello","count":2}`,
+			expected: []any{
+				map[string]any{"message": "hello", "count": int64(2)},
+			},
+		},
+		{
+			name:        "JSONNumbers",
+			contentType: "application/json",
+			body:        `{"time":1711015200123456789,"ratio":0.5,"sizes":[1,2.5],"huge":1e300,"overflow":92233720368547758070}`,
+			expected: []any{
+				map[string]any{
+					"time":     int64(1711015200123456789),
+					"ratio":    0.5,
+					"sizes":    []any{int64(1), 2.5},
+					"huge":     1e300,
+					"overflow": 92233720368547758070.0,
+				},
+			},
+		},
+		{
+			name:        "JSONArray",
+			contentType: "application/json; charset=utf-8",
+			body:        `[{"message":"first"},{"message":"second"},"third"]`,
+			expected: []any{
+				map[string]any{"message": "first"},
+				map[string]any{"message": "second"},
+				"third",
+			},
+		},
+		{
+			name:        "NDJSON",
+			contentType: "application/x-ndjson",
+			body:        "{\"message\":\"first\"}\n{\"message\":\"second\"}\n\n{\"message\":\"third\"}\n",
+			expected: []any{
+				map[string]any{"message": "first"},
+				map[string]any{"message": "second"},
+				map[string]any{"message": "third"},
+			},
+		},
+		{
+			name:        "Text",
+			contentType: "text/plain",
+			body:        "first line\r\nsecond line\n\nthird line",
+			expected:    []any{"first line", "second line", "third line"},
+		},
+		{
+			name:     "TextWithoutContentType",
+			body:     `{"message":"not parsed"}`,
+			expected: []any{`{"message":"not parsed"}`},
+		},
+		{
+			name:            "GzipJSON",
+			contentType:     "application/json",
+			contentEncoding: "gzip",
+			body:            `[{"message":"first"},{"message":"second"}]`,
+			expected: []any{
+				map[string]any{"message": "first"},
+				map[string]any{"message": "second"},
+			},
+		},
+		{
+			name:            "GzipText",
+			contentType:     "text/plain",
+			contentEncoding: "gzip",
+			body:            "first line\nsecond line\n",
+			expected:        []any{"first line", "second line"},
+		},
+		{
+			name:        "FormatJSON",
+			format:      FormatJSON,
+			contentType: "text/plain",
+			body:        `{"message":"hello"}`,
+			expected: []any{
+				map[string]any{"message": "hello"},
+			},
+		},
+		{
+			name:        "FormatText",
+			format:      FormatText,
+			contentType: "application/json",
+			body:        `{"message":"hello"}`,
+			expected:    []any{`{"message":"hello"}`},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test_id")
+			cfg.ListenAddress = "localhost:0"
+			if tc.format != "" {
+				cfg.Format = tc.format
+			}
+			_, fake, url := startInput(t, cfg)
+
+			body := []byte(tc.body)
+			if tc.contentEncoding == "gzip" {
+				body = gzipped(t, body)
+			}
+			require.Equal(t, http.StatusOK, post(t, url+"/", tc.contentType, tc.contentEncoding, body))
+
+			for _, expected := range tc.expected {
+				fake.ExpectBody(t, expected)
+			}
+			fake.ExpectNoEntry(t, 100*time.Millisecond)
+		})
+	}
+}
+
+func TestInputFieldMapping(t *testing.T) {
+	cfg := NewConfigWithID("test_id")
+	cfg.ListenAddress = "localhost:0"
+	bodyField := entry.NewBodyField("message")
+	cfg.BodyField = &bodyField
+	timeParser := helper.NewTimeParser()
+	timeField := entry.NewBodyField("time")
+	timeParser.ParseFrom = &timeField
+	timeParser.LayoutType = helper.GotimeKey
+	timeParser.Layout = time.RFC3339
+	cfg.TimeParser = &timeParser
+	severity := helper.NewSeverityConfig()
+	severityField := entry.NewBodyField("level")
+	severity.ParseFrom = &severityField
+	cfg.SeverityConfig = &severity
+	cfg.Attributes = map[string]helper.ExprStringConfig{"source": "http"}
+	_, fake, url := startInput(t, cfg)
+
+	body := `{"time":"2024-03-21T10:00:00Z","level":"error","message":"boom","service":"api"}
+{"level":"info","service":"api"}`
+	require.Equal(t, http.StatusOK, post(t, url, "application/x-ndjson", "", []byte(body)))
+
+	select {
+	case e := <-fake.Received:
+		require.Equal(t, "boom", e.Body)
+		require.Equal(t, time.Date(2024, time.March, 21, 10, 0, 0, 0, time.UTC), e.Timestamp.UTC())
+		require.Equal(t, entry.Error, e.Severity)
+		require.Equal(t, "error", e.SeverityText)
+		require.Equal(t, map[string]any{
+			"source":  "http",
+			"time":    "2024-03-21T10:00:00Z",
+			"level":   "error",
+			"service": "api",
+		}, e.Attributes)
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for entry to be processed")
+	}
+
+	// The body is kept when the entry doesn't contain the body field
+	select {
+	case e := <-fake.Received:
+		require.Equal(t, map[string]any{"level": "info", "service": "api"}, e.Body)
+		require.Equal(t, entry.Info, e.Severity)
+		require.Equal(t, map[string]any{"source": "http"}, e.Attributes)
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for entry to be processed")
+	}
+}
+
+func TestInputEpochNanoseconds(t *testing.T) {
+	cfg := NewConfigWithID("test_id")
+	cfg.ListenAddress = "localhost:0"
+	timeParser := helper.NewTimeParser()
+	timeField := entry.NewBodyField("time")
+	timeParser.ParseFrom = &timeField
+	timeParser.LayoutType = helper.EpochKey
+	timeParser.Layout = "ns"
+	cfg.TimeParser = &timeParser
+	_, fake, url := startInput(t, cfg)
+
+	require.Equal(t, http.StatusOK, post(t, url, "application/json", "", []byte(`{"time":1711015200123456789}`)))
+
+	select {
+	case e := <-fake.Received:
+		// the nanoseconds would be rounded if the number was decoded as a float64
+		require.Equal(t, time.Unix(0, 1711015200123456789).UTC(), e.Timestamp.UTC())
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for entry to be processed")
+	}
+}
+
+func TestInputAddAttributes(t *testing.T) {
+	cfg := NewConfigWithID("test_id")
+	cfg.ListenAddress = "127.0.0.1:0"
+	cfg.AddAttributes = true
+	httpInput, fake, url := startInput(t, cfg)
+
+	require.Equal(t, http.StatusOK, post(t, url, "text/plain", "", []byte("hello")))
+
+	select {
+	case e := <-fake.Received:
+		require.Equal(t, "hello", e.Body)
+		require.Equal(t, "IP.TCP", e.Attributes["net.transport"])
+		require.Equal(t, "127.0.0.1", e.Attributes["net.peer.ip"])
+		require.NotEmpty(t, e.Attributes["net.peer.port"])
+		require.Equal(t, "127.0.0.1", e.Attributes["net.host.ip"])
+		require.Equal(t, httpInput.listener.Addr().String(), "127.0.0.1:"+e.Attributes["net.host.port"].(string))
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for entry to be processed")
+	}
+}
+
+func TestInputRejectedRequests(t *testing.T) {
+	cfg := NewConfigWithID("test_id")
+	cfg.ListenAddress = "localhost:0"
+	cfg.Path = "/logs"
+	cfg.MaxRequestBodySize = 64
+	_, fake, url := startInput(t, cfg)
+
+	t.Run("NotFound", func(t *testing.T) {
+		require.Equal(t, http.StatusNotFound, post(t, url+"/other", "text/plain", "", []byte("hello")))
+	})
+
+	t.Run("MethodNotAllowed", func(t *testing.T) {
+		resp, err := http.Get(url + "/logs")
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+		require.Equal(t, http.MethodPost, resp.Header.Get("Allow"))
+	})
+
+	t.Run("InvalidJSON", func(t *testing.T) {
+		require.Equal(t, http.StatusBadRequest, post(t, url+"/logs", "application/json", "", []byte(`{"message":"first"}{"message":`)))
+	})
+
+	t.Run("BodyTooLarge", func(t *testing.T) {
+		require.Equal(t, http.StatusRequestEntityTooLarge, post(t, url+"/logs", "text/plain", "", []byte(strings.Repeat("a", 65))))
+	})
+
+	t.Run("DecompressedBodyTooLarge", func(t *testing.T) {
+		body := gzipped(t, []byte(strings.Repeat("a", 1024)))
+		require.Less(t, len(body), 64)
+		require.Equal(t, http.StatusRequestEntityTooLarge, post(t, url+"/logs", "text/plain", "gzip", body))
+	})
+
+	t.Run("InvalidGzip", func(t *testing.T) {
+		require.Equal(t, http.StatusBadRequest, post(t, url+"/logs", "text/plain", "gzip", []byte("hello")))
+	})
+
+	t.Run("UnsupportedEncoding", func(t *testing.T) {
+		require.Equal(t, http.StatusUnsupportedMediaType, post(t, url+"/logs", "text/plain", "br", []byte("hello")))
+	})
+
+	// None of the records of a rejected request are written
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+}
+
+func TestBuild(t *testing.T) {
+	cases := []struct {
+		name      string
+		inputBody func(*Config)
+		errorText string
+	}{
+		{
+			"MissingListenAddress",
+			func(_ *Config) {},
+			"missing required parameter 'listen_address'",
+		},
+		{
+			"InvalidListenAddress",
+			func(cfg *Config) {
+				cfg.ListenAddress = "localhost:notaport"
+			},
+			"failed to resolve listen_address",
+		},
+		{
+			"InvalidPath",
+			func(cfg *Config) {
+				cfg.ListenAddress = "localhost:0"
+				cfg.Path = "logs"
+			},
+			"invalid value 'logs' for parameter 'path', must start with '/'",
+		},
+		{
+			"InvalidFormat",
+			func(cfg *Config) {
+				cfg.ListenAddress = "localhost:0"
+				cfg.Format = "xml"
+			},
+			"invalid value 'xml' for parameter 'format'",
+		},
+		{
+			"NegativeMaxRequestBodySize",
+			func(cfg *Config) {
+				cfg.ListenAddress = "localhost:0"
+				cfg.MaxRequestBodySize = -1
+			},
+			"invalid value for parameter 'max_request_body_size', must be positive",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test_id")
+			tc.inputBody(cfg)
+			_, err := cfg.Build(testutil.Logger(t))
+			require.ErrorContains(t, err, tc.errorText)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package container // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/container"
+
+import (
+	"fmt"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/attrs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/recombine"
+)
+
+const operatorType = "container"
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new container parser config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new container parser config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		ParserConfig:            helper.NewParserConfig(operatorID, operatorType),
+		AddMetadataFromFilePath: true,
+		ForceFlushTimeout:       5 * time.Second,
+	}
+}
+
+// Config is the configuration of a container parser operator.
+type Config struct {
+	helper.ParserConfig `mapstructure:",squash"`
+
+	Format                  string          `mapstructure:"format"`
+	AddMetadataFromFilePath bool            `mapstructure:"add_metadata_from_filepath"`
+	MaxLogSize              helper.ByteSize `mapstructure:"max_log_size,omitempty"`
+	ForceFlushTimeout       time.Duration   `mapstructure:"force_flush_period"`
+}
+
+// Build will build a container parser operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	parserOperator, err := c.ParserConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	// The partial lines are joined based on the log tag, which is always
+	// parsed to the attributes.
+	if c.ParseTo.String() != entry.NewAttributeField().String() {
+		return nil, fmt.Errorf("`parse_to` must be attributes for the container parser")
+	}
+
+	switch c.Format {
+	case "", dockerFormat, crioFormat, containerdFormat:
+	default:
+		return nil, fmt.Errorf("invalid format '%s', must be one of '%s', '%s' or '%s'", c.Format, dockerFormat, crioFormat, containerdFormat)
+	}
+
+	p := &Parser{
+		ParserOperator:          parserOperator,
+		json:                    jsoniter.ConfigFastest,
+		format:                  c.Format,
+		addMetadataFromFilePath: c.AddMetadataFromFilePath,
+	}
+
+	// The partial lines are joined by embedded recombine operators, whose
+	// output is handed back to the parser. The CRI formats tag the last part of
+	// a line, while docker keeps the newline that ends it.
+	p.criRecombine, err = c.buildRecombine(logger, p, "_recombine", fmt.Sprintf("attributes[%q] == %q", attributeLogTag, logTagFull), false)
+	if err != nil {
+		return nil, err
+	}
+	p.dockerRecombine, err = c.buildRecombine(logger, p, "_docker_recombine", `body endsWith "\n"`, true)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// buildRecombine builds a recombine operator joining the partial lines until
+// the last part of a line, which matches isLastEntry.
+func (c Config) buildRecombine(logger *zap.SugaredLogger, p *Parser, suffix string, isLastEntry string, docker bool) (*recombine.Transformer, error) {
+	recombineConfig := recombine.NewConfigWithID(c.ID() + suffix)
+	recombineConfig.IsLastEntry = isLastEntry
+	recombineConfig.CombineField = entry.NewBodyField()
+	recombineConfig.CombineWith = ""
+	recombineConfig.OverwriteWith = "newest"
+	recombineConfig.SourceIdentifier = entry.NewAttributeField(attrs.LogFilePath)
+	recombineConfig.MaxLogSize = c.MaxLogSize
+	recombineConfig.ForceFlushTimeout = c.ForceFlushTimeout
+	recombineOperator, err := recombineConfig.Build(logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build recombine operator: %w", err)
+	}
+
+	outputConfig := helper.NewTransformerConfig(c.ID()+suffix+"_output", operatorType)
+	outputOperator, err := outputConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	transformer := recombineOperator.(*recombine.Transformer)
+	transformer.OutputOperators = []operator.Operator{&recombineOutput{
+		TransformerOperator: outputOperator,
+		parser:              p,
+		docker:              docker,
+	}}
+	return transformer, nil
+}
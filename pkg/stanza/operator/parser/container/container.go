@@ -0,0 +1,259 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package container // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/container"
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/errors"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/attrs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/recombine"
+)
+
+const (
+	dockerFormat     = "docker"
+	crioFormat       = "crio"
+	containerdFormat = "containerd"
+
+	// attributeStream holds the stream, stdout or stderr, a line was written to.
+	attributeStream = "log.iostream"
+	// attributeLogTag holds the tag of the CRI formats, P for a partial line
+	// and F for the last part of a line.
+	attributeLogTag = "logtag"
+	logTagFull      = "F"
+
+	// The resource attributes set from the path of the log file.
+	attributeNamespace    = "k8s.namespace.name"
+	attributePodName      = "k8s.pod.name"
+	attributePodUID       = "k8s.pod.uid"
+	attributeContainer    = "k8s.container.name"
+	attributeRestartCount = "k8s.container.restart_count"
+)
+
+var (
+	// The containerd timestamps are always in UTC, while those of CRI-O
+	// usually have a numeric time zone, which tells the formats apart when
+	// the format is detected.
+	containerdMatcher = regexp.MustCompile(`^(?P<time>[^ Z]+Z) (?P<stream>stdout|stderr) (?P<logtag>[^ ]*) ?(?P<log>.*)$`)
+	crioMatcher       = regexp.MustCompile(`^(?P<time>[^ ]+) (?P<stream>stdout|stderr) (?P<logtag>[^ ]*) ?(?P<log>.*)$`)
+
+	// logPathMatcher matches the paths of the log files written by the kubelet,
+	// /var/log/pods/<namespace>_<pod_name>_<pod_uid>/<container_name>/<restart_count>.log
+	logPathMatcher = regexp.MustCompile(`^.*/(?P<namespace>[^_/]+)_(?P<pod_name>[^_/]+)_(?P<uid>[a-f0-9\-]+)/(?P<container_name>[^._/]+)/(?P<restart_count>\d+)\.log$`)
+)
+
+// Parser is an operator that parses the logs written by container runtimes.
+type Parser struct {
+	helper.ParserOperator
+	json                    jsoniter.API
+	format                  string
+	addMetadataFromFilePath bool
+	criRecombine            *recombine.Transformer
+	dockerRecombine         *recombine.Transformer
+}
+
+// dockerLog is a line of the json-file logging driver of docker.
+type dockerLog struct {
+	Log    string `json:"log"`
+	Stream string `json:"stream"`
+	Time   string `json:"time"`
+}
+
+// Start will start the operator.
+func (p *Parser) Start(persister operator.Persister) error {
+	if err := p.criRecombine.Start(persister); err != nil {
+		return err
+	}
+	return p.dockerRecombine.Start(persister)
+}
+
+// Stop will stop the operator, flushing the partial lines.
+func (p *Parser) Stop() error {
+	return multierr.Combine(p.criRecombine.Stop(), p.dockerRecombine.Stop())
+}
+
+// Process will parse an entry written by a container runtime.
+func (p *Parser) Process(ctx context.Context, e *entry.Entry) error {
+	// Short circuit if the "if" condition does not match
+	skip, err := p.Skip(ctx, e)
+	if err != nil {
+		return p.HandleEntryError(ctx, e, err)
+	}
+	if skip {
+		p.Write(ctx, e)
+		return nil
+	}
+
+	format := p.format
+	if format == "" {
+		if format, err = p.detectFormat(e); err != nil {
+			return p.HandleEntryError(ctx, e, err)
+		}
+	}
+
+	var line parsedLine
+	parse := func(value any) (any, error) {
+		var err error
+		line, err = p.parse(format, value)
+		if err != nil {
+			return nil, err
+		}
+		return line.attributes, nil
+	}
+	if err = p.ParseWith(ctx, e, parse); err != nil {
+		return err
+	}
+	e.Body = line.log
+	e.Timestamp = line.timestamp
+
+	if p.addMetadataFromFilePath {
+		if err = addMetadataFromFilePath(e); err != nil {
+			return p.HandleEntryError(ctx, e, err)
+		}
+	}
+
+	if format == dockerFormat {
+		return p.dockerRecombine.Process(ctx, e)
+	}
+	return p.criRecombine.Process(ctx, e)
+}
+
+// detectFormat returns the format of the entry from the value to parse.
+func (p *Parser) detectFormat(e *entry.Entry) (string, error) {
+	value, ok := e.Get(p.ParseFrom)
+	if !ok {
+		return "", errors.NewError(
+			"Entry is missing the expected parse_from field.",
+			"Ensure that all incoming entries contain the parse_from field.",
+			"parse_from", p.ParseFrom.String(),
+		)
+	}
+
+	raw, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("type '%T' cannot be parsed as container log", value)
+	}
+	switch {
+	case strings.HasPrefix(raw, "{"):
+		return dockerFormat, nil
+	case containerdMatcher.MatchString(raw):
+		return containerdFormat, nil
+	case crioMatcher.MatchString(raw):
+		return crioFormat, nil
+	}
+	return "", fmt.Errorf("failed to detect the format of the container log")
+}
+
+// parsedLine is a line of a container log once parsed.
+type parsedLine struct {
+	log        string
+	timestamp  time.Time
+	attributes map[string]any
+}
+
+// parse parses a line in the given format.
+func (p *Parser) parse(format string, value any) (parsedLine, error) {
+	raw, ok := value.(string)
+	if !ok {
+		return parsedLine{}, fmt.Errorf("type '%T' cannot be parsed as container log", value)
+	}
+
+	var line parsedLine
+	var rawTime string
+	switch format {
+	case dockerFormat:
+		var parsed dockerLog
+		if err := p.json.UnmarshalFromString(raw, &parsed); err != nil {
+			return parsedLine{}, err
+		}
+		// The docker lines keep the newline that ended them, which is missing
+		// from the partial lines. It is removed once the lines are joined.
+		line.log = parsed.Log
+		line.attributes = map[string]any{attributeStream: parsed.Stream}
+		rawTime = parsed.Time
+	default:
+		matcher := crioMatcher
+		if format == containerdFormat {
+			matcher = containerdMatcher
+		}
+		matches := matcher.FindStringSubmatch(raw)
+		if matches == nil {
+			return parsedLine{}, fmt.Errorf("line does not match the %s format", format)
+		}
+		line.log = matches[matcher.SubexpIndex("log")]
+		line.attributes = map[string]any{
+			attributeStream: matches[matcher.SubexpIndex("stream")],
+			attributeLogTag: matches[matcher.SubexpIndex("logtag")],
+		}
+		rawTime = matches[matcher.SubexpIndex("time")]
+	}
+
+	var err error
+	line.timestamp, err = time.Parse(time.RFC3339Nano, rawTime)
+	if err != nil {
+		return parsedLine{}, fmt.Errorf("failed to parse time: %w", err)
+	}
+	return line, nil
+}
+
+// addMetadataFromFilePath sets the resource attributes found in the path of
+// the log file written by the kubelet.
+func addMetadataFromFilePath(e *entry.Entry) error {
+	var path string
+	if err := e.Read(entry.NewAttributeField(attrs.LogFilePath), &path); err != nil {
+		return fmt.Errorf("failed to read the log file path: %w", err)
+	}
+
+	matches := logPathMatcher.FindStringSubmatch(path)
+	if matches == nil {
+		return fmt.Errorf("log file path '%s' does not match the kubernetes pods logs layout", path)
+	}
+	restartCount, err := strconv.Atoi(matches[logPathMatcher.SubexpIndex("restart_count")])
+	if err != nil {
+		return fmt.Errorf("failed to parse the restart count: %w", err)
+	}
+
+	if e.Resource == nil {
+		e.Resource = map[string]any{}
+	}
+	e.Resource[attributeNamespace] = matches[logPathMatcher.SubexpIndex("namespace")]
+	e.Resource[attributePodName] = matches[logPathMatcher.SubexpIndex("pod_name")]
+	e.Resource[attributePodUID] = matches[logPathMatcher.SubexpIndex("uid")]
+	e.Resource[attributeContainer] = matches[logPathMatcher.SubexpIndex("container_name")]
+	e.Resource[attributeRestartCount] = restartCount
+	return nil
+}
+
+// recombineOutput receives the lines joined by a recombine operator and
+// writes them to the outputs of the parser.
+type recombineOutput struct {
+	helper.TransformerOperator
+	parser *Parser
+	docker bool
+}
+
+// Process will write the joined line to the outputs of the parser.
+func (o *recombineOutput) Process(ctx context.Context, e *entry.Entry) error {
+	if o.docker {
+		if log, ok := e.Body.(string); ok {
+			e.Body = strings.TrimSuffix(log, "\n")
+		}
+	} else {
+		// The joined line is complete, whichever part its fields were taken from.
+		e.Attributes[attributeLogTag] = logTagFull
+	}
+	o.parser.Write(ctx, e)
+	return nil
+}
@@ -0,0 +1,273 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+const testLogPath = "/var/log/pods/some_my-pod_49cc7c1fd3702c40b2686ea7486091d3/app/1.log"
+
+func newTestParser(t *testing.T, cfg *Config) (*Parser, *testutil.FakeOutput) {
+	cfg.OutputIDs = []string{"fake"}
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+	require.NoError(t, op.Start(testutil.NewUnscopedMockPersister()))
+	t.Cleanup(func() { require.NoError(t, op.Stop()) })
+	return op.(*Parser), fake
+}
+
+func newTestEntry(body string) *entry.Entry {
+	e := entry.New()
+	e.Body = body
+	e.Attributes = map[string]any{"log.file.path": testLogPath}
+	return e
+}
+
+func expectedResource() map[string]any {
+	return map[string]any{
+		"k8s.namespace.name":          "some",
+		"k8s.pod.name":                "my-pod",
+		"k8s.pod.uid":                 "49cc7c1fd3702c40b2686ea7486091d3",
+		"k8s.container.name":          "app",
+		"k8s.container.restart_count": 1,
+	}
+}
+
+func TestConfigBuild(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	require.IsType(t, &Parser{}, op)
+}
+
+func TestConfigBuildFailure(t *testing.T) {
+	t.Run("format", func(t *testing.T) {
+		cfg := NewConfigWithID("test")
+		cfg.Format = "invalid"
+		_, err := cfg.Build(testutil.Logger(t))
+		require.ErrorContains(t, err, "invalid format 'invalid'")
+	})
+	t.Run("parse_to", func(t *testing.T) {
+		cfg := NewConfigWithID("test")
+		cfg.ParseTo = entry.RootableField{Field: entry.NewBodyField()}
+		_, err := cfg.Build(testutil.Logger(t))
+		require.ErrorContains(t, err, "`parse_to` must be attributes")
+	})
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name   string
+		format string
+		input  string
+		expect *entry.Entry
+	}{
+		{
+			name:  "docker",
+			input: `{"log":"INFO: log line here\n","stream":"stdout","time":"2029-03-30T08:31:20.545192187Z"}`,
+			expect: &entry.Entry{
+				Timestamp: time.Date(2029, time.March, 30, 8, 31, 20, 545192187, time.UTC),
+				Body:      "INFO: log line here",
+				Attributes: map[string]any{
+					"log.file.path": testLogPath,
+					"log.iostream":  "stdout",
+				},
+			},
+		},
+		{
+			name:  "containerd",
+			input: "2023-06-22T10:27:25.813799277Z stderr F multiline containerd line",
+			expect: &entry.Entry{
+				Timestamp: time.Date(2023, time.June, 22, 10, 27, 25, 813799277, time.UTC),
+				Body:      "multiline containerd line",
+				Attributes: map[string]any{
+					"log.file.path": testLogPath,
+					"log.iostream":  "stderr",
+					"logtag":        "F",
+				},
+			},
+		},
+		{
+			name:  "crio",
+			input: "2024-04-13T07:59:37.505201169-05:00 stdout F standalone crio line",
+			expect: &entry.Entry{
+				Timestamp: time.Date(2024, time.April, 13, 7, 59, 37, 505201169, time.FixedZone("", -5*3600)),
+				Body:      "standalone crio line",
+				Attributes: map[string]any{
+					"log.file.path": testLogPath,
+					"log.iostream":  "stdout",
+					"logtag":        "F",
+				},
+			},
+		},
+		{
+			name:   "crio_configured",
+			format: "crio",
+			input:  "2024-04-13T12:59:37.505201169Z stdout F crio line in UTC",
+			expect: &entry.Entry{
+				Timestamp: time.Date(2024, time.April, 13, 12, 59, 37, 505201169, time.UTC),
+				Body:      "crio line in UTC",
+				Attributes: map[string]any{
+					"log.file.path": testLogPath,
+					"log.iostream":  "stdout",
+					"logtag":        "F",
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.Format = tc.format
+			parser, fake := newTestParser(t, cfg)
+
+			input := newTestEntry(tc.input)
+			tc.expect.ObservedTimestamp = input.ObservedTimestamp
+			tc.expect.Resource = expectedResource()
+
+			require.NoError(t, parser.Process(context.Background(), input))
+			received := <-fake.Received
+			require.True(t, tc.expect.Timestamp.Equal(received.Timestamp))
+			tc.expect.Timestamp = received.Timestamp
+			require.Equal(t, tc.expect, received)
+		})
+	}
+}
+
+func TestParsePartialLines(t *testing.T) {
+	parser, fake := newTestParser(t, NewConfigWithID("test"))
+	ctx := context.Background()
+
+	require.NoError(t, parser.Process(ctx, newTestEntry("2023-06-22T10:27:25.813799277Z stdout P first part, ")))
+	require.NoError(t, parser.Process(ctx, newTestEntry("2023-06-22T10:27:25.813799278Z stdout P second part, ")))
+	select {
+	case e := <-fake.Received:
+		require.FailNow(t, "Received unexpected entry: ", e)
+	default:
+	}
+
+	require.NoError(t, parser.Process(ctx, newTestEntry("2023-06-22T10:27:25.813799279Z stdout F last part")))
+	received := <-fake.Received
+	require.Equal(t, "first part, second part, last part", received.Body)
+	require.Equal(t, "F", received.Attributes["logtag"])
+	require.Equal(t, expectedResource(), received.Resource)
+}
+
+func TestParseDockerPartialLines(t *testing.T) {
+	parser, fake := newTestParser(t, NewConfigWithID("test"))
+	ctx := context.Background()
+
+	// docker splits the long lines, only the last part keeping the newline
+	require.NoError(t, parser.Process(ctx, newTestEntry(`{"log":"first part, ","stream":"stdout","time":"2029-03-30T08:31:20.545192187Z"}`)))
+	require.NoError(t, parser.Process(ctx, newTestEntry(`{"log":"second part, ","stream":"stdout","time":"2029-03-30T08:31:20.545192188Z"}`)))
+	select {
+	case e := <-fake.Received:
+		require.FailNow(t, "Received unexpected entry: ", e)
+	default:
+	}
+
+	require.NoError(t, parser.Process(ctx, newTestEntry(`{"log":"last part\n","stream":"stdout","time":"2029-03-30T08:31:20.545192189Z"}`)))
+	received := <-fake.Received
+	require.Equal(t, "first part, second part, last part", received.Body)
+	require.Equal(t, map[string]any{
+		"log.file.path": testLogPath,
+		"log.iostream":  "stdout",
+	}, received.Attributes)
+	require.Equal(t, expectedResource(), received.Resource)
+}
+
+func TestParsePartialLinesFlushedOnStop(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.OutputIDs = []string{"fake"}
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+	require.NoError(t, op.Start(testutil.NewUnscopedMockPersister()))
+
+	require.NoError(t, op.Process(context.Background(), newTestEntry("2023-06-22T10:27:25.813799277Z stdout P never finished")))
+	require.NoError(t, op.Stop())
+
+	select {
+	case e := <-fake.Received:
+		require.Equal(t, "never finished", e.Body)
+	default:
+		require.FailNow(t, "Partial line was not flushed on stop")
+	}
+}
+
+func TestParseWithoutMetadata(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.AddMetadataFromFilePath = false
+	parser, fake := newTestParser(t, cfg)
+
+	input := entry.New()
+	input.Body = `{"log":"hello\n","stream":"stderr","time":"2029-03-30T08:31:20.545192187Z"}`
+	require.NoError(t, parser.Process(context.Background(), input))
+	received := <-fake.Received
+	require.Equal(t, "hello", received.Body)
+	require.Nil(t, received.Resource)
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []struct {
+		name   string
+		format string
+		input  string
+		path   string
+		err    string
+	}{
+		{
+			name:  "unknown_format",
+			input: "not a container log",
+			path:  testLogPath,
+			err:   "failed to detect the format of the container log",
+		},
+		{
+			name:   "format_mismatch",
+			format: "containerd",
+			input:  "2024-04-13T07:59:37.505201169-05:00 stdout F crio line",
+			path:   testLogPath,
+			err:    "line does not match the containerd format",
+		},
+		{
+			name:  "invalid_time",
+			input: `{"log":"hello","stream":"stdout","time":"yesterday"}`,
+			path:  testLogPath,
+			err:   "failed to parse time",
+		},
+		{
+			name:  "invalid_path",
+			input: `{"log":"hello","stream":"stdout","time":"2029-03-30T08:31:20.545192187Z"}`,
+			path:  "/var/lib/docker/containers/abc/abc-json.log",
+			err:   "does not match the kubernetes pods logs layout",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.Format = tc.format
+			cfg.OnError = "drop"
+			parser, _ := newTestParser(t, cfg)
+
+			input := entry.New()
+			input.Body = tc.input
+			input.Attributes = map[string]any{"log.file.path": tc.path}
+			require.ErrorContains(t, parser.Process(context.Background(), input), tc.err)
+		})
+	}
+}
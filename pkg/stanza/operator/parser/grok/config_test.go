@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+package grok
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/operatortest"
+)
+
+func TestParserGoldenConfig(t *testing.T) {
+	operatortest.ConfigUnmarshalTests{
+		DefaultConfig: NewConfig(),
+		TestsFile:     filepath.Join(".", "testdata", "config.yaml"),
+		Tests: []operatortest.ConfigUnmarshalTest{
+			{
+				Name:   "default",
+				Expect: NewConfig(),
+			},
+			{
+				Name: "named_captures_only",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Pattern = "%{WORD} %{INT:code}"
+					cfg.NamedCapturesOnly = false
+					return cfg
+				}(),
+			},
+			{
+				Name: "on_error_drop",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.OnError = "drop"
+					return cfg
+				}(),
+			},
+			{
+				Name: "parse_from_simple",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.ParseFrom = entry.NewBodyField("from")
+					return cfg
+				}(),
+			},
+			{
+				Name: "parse_to_simple",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.ParseTo = entry.RootableField{Field: entry.NewBodyField("log")}
+					return cfg
+				}(),
+			},
+			{
+				Name: "pattern",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Pattern = "%{COMBINEDAPACHELOG}"
+					return cfg
+				}(),
+			},
+			{
+				Name: "pattern_definitions",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Pattern = "%{QUEUE:queue} %{GREEDYDATA:message}"
+					cfg.PatternDefinitions = map[string]string{"QUEUE": "queue-%{INT}"}
+					return cfg
+				}(),
+			},
+			{
+				Name: "timestamp",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Pattern = "%{HTTPDATE:timestamp} %{GREEDYDATA:message}"
+					parseField := entry.NewAttributeField("timestamp")
+					cfg.TimeParser = &helper.TimeParser{
+						LayoutType: "strptime",
+						Layout:     "%d/%b/%Y:%H:%M:%S %z",
+						ParseFrom:  &parseField,
+					}
+					return cfg
+				}(),
+			},
+		},
+	}.Run(t)
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package grok // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/grok"
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/grok"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/errors"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const operatorType = "grok_parser"
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new grok parser config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new grok parser config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		ParserConfig:      helper.NewParserConfig(operatorID, operatorType),
+		NamedCapturesOnly: true,
+	}
+}
+
+// Config is the configuration of a grok parser operator.
+type Config struct {
+	helper.ParserConfig `mapstructure:",squash"`
+
+	Pattern            string            `mapstructure:"pattern"`
+	PatternDefinitions map[string]string `mapstructure:"pattern_definitions"`
+	NamedCapturesOnly  bool              `mapstructure:"named_captures_only"`
+}
+
+// Build will build a grok parser operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	parserOperator, err := c.ParserConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Pattern == "" {
+		return nil, fmt.Errorf("missing required field 'pattern'")
+	}
+
+	r, err := grok.Compile(c.Pattern, c.PatternDefinitions, c.NamedCapturesOnly)
+	if err != nil {
+		return nil, fmt.Errorf("compiling grok pattern: %w", err)
+	}
+
+	if len(r.Names()) == 0 {
+		return nil, errors.NewError(
+			"no named captures in grok pattern",
+			"use named captures like '%{WORD:my_key}' to specify the key name for the parsed field",
+		)
+	}
+
+	return &Parser{
+		ParserOperator: parserOperator,
+		grok:           r,
+	}, nil
+}
+
+// Parser is an operator that parses grok patterns in an entry.
+type Parser struct {
+	helper.ParserOperator
+	grok *grok.Regexp
+}
+
+// Process will parse an entry for a grok pattern.
+func (p *Parser) Process(ctx context.Context, entry *entry.Entry) error {
+	return p.ParserOperator.ProcessWith(ctx, entry, p.parse)
+}
+
+// parse will parse a value using the grok pattern.
+func (p *Parser) parse(value any) (any, error) {
+	var raw string
+	switch m := value.(type) {
+	case string:
+		raw = m
+	default:
+		return nil, fmt.Errorf("type '%T' cannot be parsed as grok", value)
+	}
+
+	parsedValues, ok := p.grok.Match(raw)
+	if !ok {
+		return nil, fmt.Errorf("grok pattern does not match")
+	}
+	return parsedValues, nil
+}
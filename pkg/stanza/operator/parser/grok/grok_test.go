@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package grok
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func newTestParser(t *testing.T, pattern string) *Parser {
+	cfg := NewConfigWithID("test")
+	cfg.Pattern = pattern
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	return op.(*Parser)
+}
+
+func TestParserBuildFailure(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		err       string
+	}{
+		{
+			name:      "invalid on_error",
+			configure: func(cfg *Config) { cfg.Pattern = "%{WORD:word}"; cfg.OnError = "invalid_on_error" },
+			err:       "invalid `on_error` field",
+		},
+		{
+			name:      "missing pattern",
+			configure: func(_ *Config) {},
+			err:       "missing required field 'pattern'",
+		},
+		{
+			name:      "unknown pattern",
+			configure: func(cfg *Config) { cfg.Pattern = "%{UNKNOWN:word}" },
+			err:       `compiling grok pattern: unknown grok pattern "UNKNOWN"`,
+		},
+		{
+			name:      "no named captures",
+			configure: func(cfg *Config) { cfg.Pattern = "%{WORD} %{INT}" },
+			err:       "no named captures in grok pattern",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			tc.configure(cfg)
+			_, err := cfg.Build(testutil.Logger(t))
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestParserByteFailure(t *testing.T) {
+	parser := newTestParser(t, "%{WORD:word}")
+	_, err := parser.parse([]byte("invalid"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "type '[]uint8' cannot be parsed as grok")
+}
+
+func TestParserStringFailure(t *testing.T) {
+	parser := newTestParser(t, "^%{IPV4:ip}$")
+	_, err := parser.parse("invalid")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "grok pattern does not match")
+}
+
+func TestParserGrok(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		input     *entry.Entry
+		expected  *entry.Entry
+	}{
+		{
+			name: "combined_apache_log",
+			configure: func(cfg *Config) {
+				cfg.Pattern = "%{COMBINEDAPACHELOG}"
+			},
+			input: &entry.Entry{
+				Body: `127.0.0.1 - - [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326 "-" "curl/8.4.0"`,
+			},
+			expected: &entry.Entry{
+				Body: `127.0.0.1 - - [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326 "-" "curl/8.4.0"`,
+				Attributes: map[string]any{
+					"clientip":    "127.0.0.1",
+					"ident":       "-",
+					"auth":        "-",
+					"timestamp":   "10/Oct/2000:13:55:36 -0700",
+					"verb":        "GET",
+					"request":     "/apache_pb.gif",
+					"httpversion": "1.0",
+					"response":    "200",
+					"bytes":       "2326",
+					"referrer":    `"-"`,
+					"agent":       `"curl/8.4.0"`,
+				},
+			},
+		},
+		{
+			name: "pattern_definitions_and_types",
+			configure: func(cfg *Config) {
+				cfg.Pattern = "%{QUEUE:queue} %{INT:size:int} %{NUMBER:duration:float}"
+				cfg.PatternDefinitions = map[string]string{"QUEUE": "queue-%{INT}"}
+			},
+			input: &entry.Entry{
+				Body: "queue-12 100 0.5",
+			},
+			expected: &entry.Entry{
+				Body: "queue-12 100 0.5",
+				Attributes: map[string]any{
+					"queue":    "queue-12",
+					"size":     int64(100),
+					"duration": 0.5,
+				},
+			},
+		},
+		{
+			name: "unnamed_captures",
+			configure: func(cfg *Config) {
+				cfg.Pattern = "%{WORD} %{INT:code}"
+				cfg.NamedCapturesOnly = false
+			},
+			input: &entry.Entry{
+				Body: "error 42",
+			},
+			expected: &entry.Entry{
+				Body: "error 42",
+				Attributes: map[string]any{
+					"WORD": "error",
+					"code": "42",
+				},
+			},
+		},
+		{
+			name: "parse_to_body",
+			configure: func(cfg *Config) {
+				cfg.Pattern = "%{SYSLOGBASE} %{GREEDYDATA:message}"
+				cfg.ParseTo = entry.RootableField{Field: entry.NewBodyField()}
+			},
+			input: &entry.Entry{
+				Body: "Mar  7 00:00:01 myhost sshd[1234]: session opened",
+			},
+			expected: &entry.Entry{
+				Body: map[string]any{
+					"timestamp": "Mar  7 00:00:01",
+					"logsource": "myhost",
+					"program":   "sshd",
+					"pid":       "1234",
+					"message":   "session opened",
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.OutputIDs = []string{"fake"}
+			tc.configure(cfg)
+
+			op, err := cfg.Build(testutil.Logger(t))
+			require.NoError(t, err)
+
+			fake := testutil.NewFakeOutput(t)
+			require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+			ots := time.Now()
+			tc.input.ObservedTimestamp = ots
+			tc.expected.ObservedTimestamp = ots
+
+			err = op.Process(context.Background(), tc.input)
+			require.NoError(t, err)
+
+			fake.ExpectEntry(t, tc.expected)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package w3c
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/operatortest"
+)
+
+func TestConfig(t *testing.T) {
+	operatortest.ConfigUnmarshalTests{
+		DefaultConfig: NewConfig(),
+		TestsFile:     filepath.Join(".", "testdata", "config.yaml"),
+		Tests: []operatortest.ConfigUnmarshalTest{
+			{
+				Name: "fields",
+				Expect: func() *Config {
+					p := NewConfig()
+					p.Fields = "#Fields: date time c-ip cs-method cs-uri-stem sc-status"
+					return p
+				}(),
+			},
+			{
+				Name: "fields_attribute",
+				Expect: func() *Config {
+					p := NewConfig()
+					p.FieldsAttribute = "w3c.fields"
+					return p
+				}(),
+			},
+			{
+				Name: "no_decode",
+				Expect: func() *Config {
+					p := NewConfig()
+					p.FieldsAttribute = "w3c.fields"
+					p.Decode = false
+					return p
+				}(),
+			},
+			{
+				Name: "no_semconv_mapping",
+				Expect: func() *Config {
+					p := NewConfig()
+					p.FieldsAttribute = "w3c.fields"
+					p.SemConvMapping = false
+					return p
+				}(),
+			},
+		},
+	}.Run(t)
+}
@@ -0,0 +1,303 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package w3c // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/w3c"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	operatorType = "w3c_parser"
+
+	fieldsDirective = "#Fields:"
+	// emptyValue is written in place of the fields which have no value
+	emptyValue = "-"
+)
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new w3c parser config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new w3c parser config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		ParserConfig:   helper.NewParserConfig(operatorID, operatorType),
+		Decode:         true,
+		SemConvMapping: true,
+	}
+}
+
+// Config is the configuration of a w3c parser operator.
+type Config struct {
+	helper.ParserConfig `mapstructure:",squash"`
+
+	Fields          string `mapstructure:"fields"`
+	FieldsAttribute string `mapstructure:"fields_attribute"`
+	Decode          bool   `mapstructure:"decode"`
+	SemConvMapping  bool   `mapstructure:"semconv_mapping"`
+}
+
+// Build will build a w3c parser operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	parserOperator, err := c.ParserConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Fields == "" && c.FieldsAttribute == "" {
+		return nil, errors.New("missing required field 'fields' or 'fields_attribute'")
+	}
+
+	if c.Fields != "" && c.FieldsAttribute != "" {
+		return nil, errors.New("only one fields parameter can be set: 'fields' or 'fields_attribute'")
+	}
+
+	p := &Parser{
+		ParserOperator:  parserOperator,
+		fieldsAttribute: c.FieldsAttribute,
+		decode:          c.Decode,
+		semConvMapping:  c.SemConvMapping,
+	}
+
+	if c.Fields != "" {
+		if p.fields = parseFieldsDirective(c.Fields); len(p.fields) == 0 {
+			return nil, fmt.Errorf("invalid 'fields': '%s'", c.Fields)
+		}
+	}
+
+	return p, nil
+}
+
+// Parser is an operator that parses W3C extended log lines in an entry.
+type Parser struct {
+	helper.ParserOperator
+	fields          []string
+	fieldsAttribute string
+	decode          bool
+	semConvMapping  bool
+}
+
+// Process will parse an entry for a W3C extended log line.
+// The directive lines, which start with '#', are dropped.
+func (p *Parser) Process(ctx context.Context, e *entry.Entry) error {
+	if value, ok := e.Get(p.ParseFrom); ok {
+		if line, ok := value.(string); ok && strings.HasPrefix(line, "#") {
+			return nil
+		}
+	}
+
+	fields := p.fields
+	if fields == nil {
+		// Dynamically read the fields from an attribute, typically set from the header of the file
+		h, ok := e.Attributes[p.fieldsAttribute]
+		if !ok {
+			err := fmt.Errorf("failed to read fields attribute %s", p.fieldsAttribute)
+			p.Error(err)
+			return err
+		}
+		fieldsString, ok := h.(string)
+		if !ok {
+			err := fmt.Errorf("fields attribute is expected to be a string but is %T", h)
+			p.Error(err)
+			return err
+		}
+		fields = parseFieldsDirective(fieldsString)
+	}
+
+	var timestamp time.Time
+	parse := func(value any) (any, error) {
+		line, err := valueAsString(value)
+		if err != nil {
+			return nil, err
+		}
+		values, err := splitLine(line)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) != len(fields) {
+			return nil, fmt.Errorf("wrong number of fields: expected %d, found %d", len(fields), len(values))
+		}
+
+		parsed := make(map[string]any, len(fields))
+		for i, field := range fields {
+			if values[i].value == emptyValue && !values[i].quoted {
+				continue
+			}
+			v := values[i].value
+			if p.decode && !values[i].quoted {
+				v = decodeValue(v)
+			}
+			p.setField(parsed, field, v)
+		}
+		timestamp = parseTimestamp(parsed)
+		return parsed, nil
+	}
+
+	// The date and time fields are the timestamp of the entry, unless it is parsed by the timestamp block
+	return p.ProcessWithCallback(ctx, e, parse, func(e *entry.Entry) error {
+		if p.TimeParser == nil && !timestamp.IsZero() {
+			e.Timestamp = timestamp
+		}
+		return nil
+	})
+}
+
+// setField sets the value of a field, under its semantic conventions name if it has one
+func (p *Parser) setField(parsed map[string]any, field string, value string) {
+	if !p.semConvMapping {
+		parsed[field] = value
+		return
+	}
+	mapping, ok := semConvFields[strings.ToLower(field)]
+	if !ok {
+		parsed[field] = value
+		return
+	}
+	if mapping.integer {
+		if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+			parsed[mapping.name] = i
+			return
+		}
+	}
+	parsed[mapping.name] = value
+}
+
+type semConvField struct {
+	name    string
+	integer bool
+}
+
+// semConvFields maps the well-known W3C fields, in lower case, to the semantic conventions attributes
+var semConvFields = map[string]semConvField{
+	"c-ip":            {name: "client.address"},
+	"c-port":          {name: "client.port", integer: true},
+	"s-ip":            {name: "network.local.address"},
+	"s-port":          {name: "server.port", integer: true},
+	"cs-host":         {name: "server.address"},
+	"cs(host)":        {name: "server.address"},
+	"x-host-header":   {name: "server.address"},
+	"cs-method":       {name: "http.request.method"},
+	"cs-uri-stem":     {name: "url.path"},
+	"cs-uri-query":    {name: "url.query"},
+	"cs-protocol":     {name: "url.scheme"},
+	"sc-status":       {name: "http.response.status_code", integer: true},
+	"cs-user-agent":   {name: "user_agent.original"},
+	"cs(user-agent)":  {name: "user_agent.original"},
+	"cs-referer":      {name: "http.request.header.referer"},
+	"cs(referer)":     {name: "http.request.header.referer"},
+	"cs-username":     {name: "enduser.id"},
+	"cs-bytes":        {name: "http.request.size", integer: true},
+	"sc-bytes":        {name: "http.response.size", integer: true},
+	"x-forwarded-for": {name: "http.request.header.x-forwarded-for"},
+}
+
+// parseFieldsDirective returns the names of the fields listed by a #Fields directive.
+// The directive name is optional.
+func parseFieldsDirective(directive string) []string {
+	directive = strings.TrimSpace(directive)
+	directive = strings.TrimPrefix(directive, fieldsDirective)
+	return strings.Fields(directive)
+}
+
+// parseTimestamp returns the time of the date and time fields, which are in UTC
+func parseTimestamp(parsed map[string]any) time.Time {
+	date, ok := parsed["date"].(string)
+	if !ok {
+		return time.Time{}
+	}
+	clock, ok := parsed["time"].(string)
+	if !ok {
+		return time.Time{}
+	}
+	timestamp, err := time.Parse("2006-01-02 15:04:05", date+" "+clock)
+	if err != nil {
+		return time.Time{}
+	}
+	return timestamp
+}
+
+type lineValue struct {
+	value  string
+	quoted bool
+}
+
+// splitLine splits a line on spaces and tabs. Values can be quoted with double quotes,
+// in which case a double quote is escaped by another double quote.
+func splitLine(line string) ([]lineValue, error) {
+	var values []lineValue
+	for i := 0; i < len(line); {
+		switch line[i] {
+		case ' ', '\t':
+			i++
+			continue
+		case '"':
+			var value strings.Builder
+			i++
+			for {
+				end := strings.IndexByte(line[i:], '"')
+				if end < 0 {
+					return nil, errors.New("unterminated quoted value")
+				}
+				value.WriteString(line[i : i+end])
+				i += end + 1
+				if i < len(line) && line[i] == '"' {
+					value.WriteByte('"')
+					i++
+					continue
+				}
+				break
+			}
+			values = append(values, lineValue{value: value.String(), quoted: true})
+		default:
+			end := strings.IndexAny(line[i:], " \t")
+			if end < 0 {
+				end = len(line) - i
+			}
+			values = append(values, lineValue{value: line[i : i+end]})
+			i += end
+		}
+	}
+	return values, nil
+}
+
+// decodeValue decodes the '+' and '%' escapes of a value. The values which are not valid escapes are kept.
+func decodeValue(value string) string {
+	if !strings.ContainsAny(value, "+%") {
+		return value
+	}
+	decoded, err := url.QueryUnescape(value)
+	if err != nil {
+		return value
+	}
+	return decoded
+}
+
+// valueAsString interprets the given value as a string.
+func valueAsString(value any) (string, error) {
+	switch t := value.(type) {
+	case string:
+		return t, nil
+	case []byte:
+		return string(t), nil
+	default:
+		return "", fmt.Errorf("type '%T' cannot be parsed as w3c", value)
+	}
+}
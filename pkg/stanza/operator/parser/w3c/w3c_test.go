@@ -0,0 +1,272 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package w3c
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+const iisFields = "#Fields: date time s-ip cs-method cs-uri-stem cs-uri-query s-port cs-username c-ip cs(User-Agent) cs(Referer) sc-status sc-substatus sc-win32-status time-taken"
+
+func TestParserBuildFailure(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		err       string
+	}{
+		{
+			name:      "invalid on_error",
+			configure: func(cfg *Config) { cfg.Fields = iisFields; cfg.OnError = "invalid_on_error" },
+			err:       "invalid `on_error` field",
+		},
+		{
+			name:      "no fields",
+			configure: func(_ *Config) {},
+			err:       "missing required field 'fields' or 'fields_attribute'",
+		},
+		{
+			name:      "both fields",
+			configure: func(cfg *Config) { cfg.Fields = iisFields; cfg.FieldsAttribute = "w3c.fields" },
+			err:       "only one fields parameter can be set: 'fields' or 'fields_attribute'",
+		},
+		{
+			name:      "empty fields directive",
+			configure: func(cfg *Config) { cfg.Fields = "#Fields:" },
+			err:       "invalid 'fields': '#Fields:'",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			tc.configure(cfg)
+			_, err := cfg.Build(testutil.Logger(t))
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestParser(t *testing.T) {
+	ts := time.Date(2024, time.March, 4, 10, 15, 30, 0, time.UTC)
+
+	cases := []struct {
+		name             string
+		configure        func(*Config)
+		input            *entry.Entry
+		expected         *entry.Entry
+		expectProcessErr bool
+	}{
+		{
+			name: "iis",
+			configure: func(cfg *Config) {
+				cfg.Fields = iisFields
+			},
+			input: &entry.Entry{
+				Body: "2024-03-04 10:15:30 10.0.0.5 GET /index.html a=1&b=x%20y 443 - 192.168.1.10 Mozilla/5.0+(Windows+NT+10.0) https://example.com/ 200 0 0 15",
+			},
+			expected: &entry.Entry{
+				Timestamp: ts,
+				Body:      "2024-03-04 10:15:30 10.0.0.5 GET /index.html a=1&b=x%20y 443 - 192.168.1.10 Mozilla/5.0+(Windows+NT+10.0) https://example.com/ 200 0 0 15",
+				Attributes: map[string]any{
+					"date":                        "2024-03-04",
+					"time":                        "10:15:30",
+					"network.local.address":       "10.0.0.5",
+					"http.request.method":         "GET",
+					"url.path":                    "/index.html",
+					"url.query":                   "a=1&b=x y",
+					"server.port":                 int64(443),
+					"client.address":              "192.168.1.10",
+					"user_agent.original":         "Mozilla/5.0 (Windows NT 10.0)",
+					"http.request.header.referer": "https://example.com/",
+					"http.response.status_code":   int64(200),
+					"sc-substatus":                "0",
+					"sc-win32-status":             "0",
+					"time-taken":                  "15",
+				},
+			},
+		},
+		{
+			name: "fields_attribute",
+			configure: func(cfg *Config) {
+				cfg.FieldsAttribute = "w3c.fields"
+			},
+			input: &entry.Entry{
+				Attributes: map[string]any{
+					"w3c.fields": "date\ttime\tx-edge-location\tsc-bytes\tc-ip",
+				},
+				Body: "2024-03-04\t10:15:30.250\tIAD89-C1\t2390\t192.0.2.1",
+			},
+			expected: &entry.Entry{
+				Timestamp: ts.Add(250 * time.Millisecond),
+				Attributes: map[string]any{
+					"w3c.fields":         "date\ttime\tx-edge-location\tsc-bytes\tc-ip",
+					"date":               "2024-03-04",
+					"time":               "10:15:30.250",
+					"x-edge-location":    "IAD89-C1",
+					"http.response.size": int64(2390),
+					"client.address":     "192.0.2.1",
+				},
+				Body: "2024-03-04\t10:15:30.250\tIAD89-C1\t2390\t192.0.2.1",
+			},
+		},
+		{
+			name: "no_decode_no_semconv_mapping",
+			configure: func(cfg *Config) {
+				cfg.Fields = "cs-uri-query cs(User-Agent) sc-status"
+				cfg.Decode = false
+				cfg.SemConvMapping = false
+			},
+			input: &entry.Entry{
+				Body: "a=x%20y Mozilla/5.0+(X11) 404",
+			},
+			expected: &entry.Entry{
+				Attributes: map[string]any{
+					"cs-uri-query":   "a=x%20y",
+					"cs(User-Agent)": "Mozilla/5.0+(X11)",
+					"sc-status":      "404",
+				},
+				Body: "a=x%20y Mozilla/5.0+(X11) 404",
+			},
+		},
+		{
+			name: "quoted_values",
+			configure: func(cfg *Config) {
+				cfg.Fields = "cs-method cs(User-Agent) x-comment sc-status"
+			},
+			input: &entry.Entry{
+				Body: `GET "Mozilla/5.0 (X11)" "said ""hi"" 100%" abc`,
+			},
+			expected: &entry.Entry{
+				Attributes: map[string]any{
+					"http.request.method":       "GET",
+					"user_agent.original":       "Mozilla/5.0 (X11)",
+					"x-comment":                 `said "hi" 100%`,
+					"http.response.status_code": "abc",
+				},
+				Body: `GET "Mozilla/5.0 (X11)" "said ""hi"" 100%" abc`,
+			},
+		},
+		{
+			name: "invalid_escape",
+			configure: func(cfg *Config) {
+				cfg.Fields = "cs-uri-query"
+			},
+			input: &entry.Entry{
+				Body: "discount=100%",
+			},
+			expected: &entry.Entry{
+				Attributes: map[string]any{
+					"url.query": "discount=100%",
+				},
+				Body: "discount=100%",
+			},
+		},
+		{
+			name: "timestamp_block",
+			configure: func(cfg *Config) {
+				cfg.Fields = "date time x-timestamp"
+				cfg.TimeParser = &helper.TimeParser{
+					ParseFrom:  func() *entry.Field { f := entry.NewAttributeField("x-timestamp"); return &f }(),
+					LayoutType: helper.GotimeKey,
+					Layout:     time.RFC3339,
+				}
+			},
+			input: &entry.Entry{
+				Body: "2024-03-04 10:15:30 2024-03-04T11:00:00Z",
+			},
+			expected: &entry.Entry{
+				Timestamp: time.Date(2024, time.March, 4, 11, 0, 0, 0, time.UTC),
+				Attributes: map[string]any{
+					"date":        "2024-03-04",
+					"time":        "10:15:30",
+					"x-timestamp": "2024-03-04T11:00:00Z",
+				},
+				Body: "2024-03-04 10:15:30 2024-03-04T11:00:00Z",
+			},
+		},
+		{
+			name: "wrong_number_of_fields",
+			configure: func(cfg *Config) {
+				cfg.Fields = iisFields
+			},
+			input: &entry.Entry{
+				Body: "2024-03-04 10:15:30",
+			},
+			expectProcessErr: true,
+		},
+		{
+			name: "unterminated_quote",
+			configure: func(cfg *Config) {
+				cfg.Fields = "cs-method cs(User-Agent)"
+			},
+			input: &entry.Entry{
+				Body: `GET "Mozilla`,
+			},
+			expectProcessErr: true,
+		},
+		{
+			name: "missing_fields_attribute",
+			configure: func(cfg *Config) {
+				cfg.FieldsAttribute = "w3c.fields"
+			},
+			input: &entry.Entry{
+				Body: "GET",
+			},
+			expectProcessErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.OutputIDs = []string{"fake"}
+			tc.configure(cfg)
+
+			op, err := cfg.Build(testutil.Logger(t))
+			require.NoError(t, err)
+
+			fake := testutil.NewFakeOutput(t)
+			require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+			ots := time.Now()
+			tc.input.ObservedTimestamp = ots
+			err = op.Process(context.Background(), tc.input)
+			if tc.expectProcessErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			tc.expected.ObservedTimestamp = ots
+			fake.ExpectEntry(t, tc.expected)
+		})
+	}
+}
+
+func TestParserDropsDirectives(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.OutputIDs = []string{"fake"}
+	cfg.Fields = iisFields
+
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+	for _, directive := range []string{"#Software: Microsoft Internet Information Services 10.0", "#Version: 1.0", iisFields} {
+		e := entry.New()
+		e.Body = directive
+		require.NoError(t, op.Process(context.Background(), e))
+	}
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+}
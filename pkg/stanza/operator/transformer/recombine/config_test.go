@@ -6,6 +6,7 @@ package recombine
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/operatortest"
@@ -84,6 +85,33 @@ func TestUnmarshal(t *testing.T) {
 					return cfg
 				}(),
 			},
+			{
+				Name:      "preset",
+				ExpectErr: false,
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Preset = "java_stacktrace"
+					return cfg
+				}(),
+			},
+			{
+				Name:      "merge_attributes",
+				ExpectErr: false,
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.MergeAttributes = true
+					return cfg
+				}(),
+			},
+			{
+				Name:      "source_idle_timeout",
+				ExpectErr: false,
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.SourceIdleTimeout = 10 * time.Second
+					return cfg
+				}(),
+			},
 		},
 	}.Run(t)
 }
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package recombine // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/recombine"
+
+import (
+	"regexp"
+)
+
+const (
+	presetJavaStacktrace  = "java_stacktrace"
+	presetPythonTraceback = "python_traceback"
+	presetGoPanic         = "go_panic"
+	presetDotnetException = "dotnet_exception"
+)
+
+// continueFunc reports whether line continues the multiline log whose last line is prev.
+type continueFunc func(prev, line string) bool
+
+// presets maps the supported values of the preset parameter to their detection rules.
+var presets = map[string]continueFunc{
+	presetJavaStacktrace:  javaStacktraceContinues,
+	presetPythonTraceback: pythonTracebackContinues,
+	presetGoPanic:         goPanicContinues,
+	presetDotnetException: dotnetExceptionContinues,
+}
+
+var (
+	// indentedRegex matches the frames and the other indented lines of a stack trace
+	indentedRegex = regexp.MustCompile(`^\s+\S`)
+
+	// javaContinuationRegex matches the frames of a Java stack trace, their elided frames and the
+	// headers of their causes and suppressed exceptions
+	javaContinuationRegex = regexp.MustCompile(`^(?:\s+at\s|\s*\.\.\. \d+ (?:more|common frames omitted)\s*$|\s*Caused by: |\s*Suppressed: )`)
+	// javaExceptionRegex matches the header of a Java exception, such as the one following the message of a logger
+	javaExceptionRegex = regexp.MustCompile(`^(?:[a-zA-Z_$][\w$]*\.)+[A-Z][\w$]*(?:Exception|Error|Throwable)(?::|$)`)
+
+	// pythonContinuationRegex matches the header of a traceback and the messages chaining exceptions
+	pythonContinuationRegex = regexp.MustCompile(`^(?:Traceback \(most recent call last\):|During handling of the above exception, another exception occurred:|The above exception was the direct cause of the following exception:)$`)
+	// pythonExceptionRegex matches the last line of a traceback, naming the exception and its message
+	pythonExceptionRegex = regexp.MustCompile(`^[A-Za-z_][\w.]*(?:: .*)?$`)
+
+	// goroutineRegex matches the header of the stack of a goroutine
+	goroutineRegex = regexp.MustCompile(`^goroutine \d+ \[[^\]]+\]:$`)
+	// goContinuationRegex matches the signal details, the goroutine creators and the exit status following a panic
+	goContinuationRegex = regexp.MustCompile(`^(?:\[signal |created by |exit status \d+$)`)
+	// goFunctionRegex matches the function calls of a goroutine stack
+	goFunctionRegex = regexp.MustCompile(`^\S+\(.*\)$`)
+
+	// dotnetContinuationRegex matches the frames of a .NET stack trace, its inner exceptions and the end of their stack traces
+	dotnetContinuationRegex = regexp.MustCompile(`^(?:\s+at\s|\s*---> |\s*--- End of .* ---$)`)
+	// dotnetExceptionRegex matches the header of a .NET exception, such as the one following the message of a logger
+	dotnetExceptionRegex = regexp.MustCompile(`^(?:[A-Za-z_]\w*\.)+[A-Z]\w*Exception(?::|$)`)
+)
+
+func javaStacktraceContinues(_, line string) bool {
+	return javaContinuationRegex.MatchString(line) || javaExceptionRegex.MatchString(line)
+}
+
+func pythonTracebackContinues(prev, line string) bool {
+	switch {
+	case line == "", indentedRegex.MatchString(line), pythonContinuationRegex.MatchString(line):
+		return true
+	}
+	// The exception closing a traceback directly follows the indented source lines of its last frame
+	return indentedRegex.MatchString(prev) && pythonExceptionRegex.MatchString(line)
+}
+
+func goPanicContinues(prev, line string) bool {
+	switch {
+	case line == "", indentedRegex.MatchString(line), goroutineRegex.MatchString(line), goContinuationRegex.MatchString(line):
+		return true
+	}
+	// The function calls follow the goroutine header or the source location of the previous call
+	return (goroutineRegex.MatchString(prev) || indentedRegex.MatchString(prev)) && goFunctionRegex.MatchString(line)
+}
+
+func dotnetExceptionContinues(_, line string) bool {
+	return dotnetContinuationRegex.MatchString(line) || dotnetExceptionRegex.MatchString(line)
+}
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package recombine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPresets(t *testing.T) {
+	cases := []struct {
+		preset string
+		prev   string
+		line   string
+		want   bool
+	}{
+		{presetJavaStacktrace, "java.lang.IllegalStateException: boom", "\tat com.example.Main.main(Main.java:10)", true},
+		{presetJavaStacktrace, "\tat com.example.Main.main(Main.java:10)", "\t... 12 more", true},
+		{presetJavaStacktrace, "\tat com.example.Main.main(Main.java:10)", "\t... 3 common frames omitted", true},
+		{presetJavaStacktrace, "\tat com.example.Main.main(Main.java:10)", "Caused by: java.io.IOException: broken pipe", true},
+		{presetJavaStacktrace, "\tat com.example.Main.main(Main.java:10)", "\tSuppressed: java.io.IOException: close failed", true},
+		{presetJavaStacktrace, "ERROR Request failed", "java.lang.IllegalStateException: boom", true},
+		{presetJavaStacktrace, "ERROR Request failed", "com.example.ValidationError", true},
+		{presetJavaStacktrace, "\tat com.example.Main.main(Main.java:10)", "Exception in thread \"main\" java.lang.IllegalStateException: boom", false},
+		{presetJavaStacktrace, "\tat com.example.Main.main(Main.java:10)", "2024-03-21 10:00:01 INFO Request served", false},
+		{presetJavaStacktrace, "ERROR Request failed", "", false},
+
+		{presetPythonTraceback, "ERROR:root:Request failed", "Traceback (most recent call last):", true},
+		{presetPythonTraceback, "Traceback (most recent call last):", `  File "/app/main.py", line 10, in <module>`, true},
+		{presetPythonTraceback, `  File "/app/main.py", line 10, in <module>`, "    handle()", true},
+		{presetPythonTraceback, "    handle()", "ValueError: boom", true},
+		{presetPythonTraceback, "    handle()", "KeyboardInterrupt", true},
+		{presetPythonTraceback, "    handle()", "requests.exceptions.ConnectionError: refused", true},
+		{presetPythonTraceback, "ValueError: boom", "", true},
+		{presetPythonTraceback, "", "During handling of the above exception, another exception occurred:", true},
+		{presetPythonTraceback, "", "The above exception was the direct cause of the following exception:", true},
+		{presetPythonTraceback, "ValueError: boom", "INFO:root:Request served", false},
+		{presetPythonTraceback, "ValueError: boom", "RuntimeError: again", false},
+		{presetPythonTraceback, "    handle()", "2024-03-21 10:00:01,123 INFO Request served", false},
+
+		{presetGoPanic, "panic: boom", "", true},
+		{presetGoPanic, "", "goroutine 1 [running]:", true},
+		{presetGoPanic, "goroutine 1 [running]:", "main.main()", true},
+		{presetGoPanic, "main.main()", "\t/app/main.go:10 +0x25", true},
+		{presetGoPanic, "\t/app/main.go:10 +0x25", "main.(*Server).serve(0xc000010000, {0x4b1b50, 0xc00001c0a0})", true},
+		{presetGoPanic, "\t/app/main.go:10 +0x25", "created by main.main in goroutine 1", true},
+		{presetGoPanic, "\t/app/main.go:10 +0x25", "exit status 2", true},
+		{presetGoPanic, "panic: runtime error: invalid memory address or nil pointer dereference", "[signal SIGSEGV: segmentation violation code=0x1 addr=0x0 pc=0x45e2a0]", true},
+		{presetGoPanic, "\t/app/main.go:10 +0x25", "panic: boom", false},
+		{presetGoPanic, "2024/03/21 10:00:00 starting", "panic: boom", false},
+		{presetGoPanic, "2024/03/21 10:00:00 starting", "main.main()", false},
+		{presetGoPanic, "\t/app/main.go:10 +0x25", "2024/03/21 10:00:01 served", false},
+
+		{presetDotnetException, "Unhandled exception. System.InvalidOperationException: boom", " ---> System.IO.IOException: broken pipe", true},
+		{presetDotnetException, " ---> System.IO.IOException: broken pipe", "   at Example.Client.Send() in /app/Client.cs:line 7", true},
+		{presetDotnetException, "   at Example.Client.Send() in /app/Client.cs:line 7", "   --- End of inner exception stack trace ---", true},
+		{presetDotnetException, "   at Example.Client.Send() in /app/Client.cs:line 7", "--- End of stack trace from previous location ---", true},
+		{presetDotnetException, "fail: Example.Program[0] Request failed", "System.InvalidOperationException: boom", true},
+		{presetDotnetException, "   at Example.Client.Send() in /app/Client.cs:line 7", "Unhandled exception. System.InvalidOperationException: boom", false},
+		{presetDotnetException, "   at Example.Client.Send() in /app/Client.cs:line 7", "info: Example.Program[0] Request served", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.preset+"/"+tc.line, func(t *testing.T) {
+			continues, ok := presets[tc.preset]
+			require.True(t, ok)
+			require.Equal(t, tc.want, continues(tc.prev, tc.line))
+		})
+	}
+}
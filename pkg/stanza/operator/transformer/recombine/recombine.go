@@ -7,11 +7,15 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/expr-lang/expr"
 	"github.com/expr-lang/expr/vm"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
@@ -22,6 +26,8 @@ import (
 const (
 	operatorType       = "recombine"
 	defaultCombineWith = "\n"
+	scopeName          = "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/recombine"
+	operatorKey        = "operator"
 )
 
 func init() {
@@ -51,14 +57,24 @@ type Config struct {
 	helper.TransformerConfig `mapstructure:",squash"`
 	IsFirstEntry             string          `mapstructure:"is_first_entry"`
 	IsLastEntry              string          `mapstructure:"is_last_entry"`
+	Preset                   string          `mapstructure:"preset"`
 	MaxBatchSize             int             `mapstructure:"max_batch_size"`
 	CombineField             entry.Field     `mapstructure:"combine_field"`
 	CombineWith              string          `mapstructure:"combine_with"`
+	MergeAttributes          bool            `mapstructure:"merge_attributes"`
 	SourceIdentifier         entry.Field     `mapstructure:"source_identifier"`
 	OverwriteWith            string          `mapstructure:"overwrite_with"`
 	ForceFlushTimeout        time.Duration   `mapstructure:"force_flush_period"`
+	SourceIdleTimeout        time.Duration   `mapstructure:"source_idle_timeout"`
 	MaxSources               int             `mapstructure:"max_sources"`
 	MaxLogSize               helper.ByteSize `mapstructure:"max_log_size,omitempty"`
+
+	meterProvider metric.MeterProvider
+}
+
+// SetMeterProvider sets the meter provider to which the operator reports its metrics
+func (c *Config) SetMeterProvider(mp metric.MeterProvider) {
+	c.meterProvider = mp
 }
 
 // Build creates a new Transformer from a config
@@ -72,19 +88,31 @@ func (c *Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		return nil, fmt.Errorf("only one of is_first_entry and is_last_entry can be set")
 	}
 
-	if c.IsLastEntry == "" && c.IsFirstEntry == "" {
-		return nil, fmt.Errorf("one of is_first_entry and is_last_entry must be set")
+	if c.Preset != "" && (c.IsLastEntry != "" || c.IsFirstEntry != "") {
+		return nil, fmt.Errorf("preset cannot be set with is_first_entry or is_last_entry")
+	}
+
+	if c.IsLastEntry == "" && c.IsFirstEntry == "" && c.Preset == "" {
+		return nil, fmt.Errorf("one of is_first_entry, is_last_entry and preset must be set")
 	}
 
 	var matchesFirst bool
 	var prog *vm.Program
-	if c.IsFirstEntry != "" {
+	var continues continueFunc
+	switch {
+	case c.Preset != "":
+		var ok bool
+		if continues, ok = presets[c.Preset]; !ok {
+			return nil, fmt.Errorf("invalid value '%s' for parameter 'preset'", c.Preset)
+		}
+		matchesFirst = true
+	case c.IsFirstEntry != "":
 		matchesFirst = true
 		prog, err = helper.ExprCompileBool(c.IsFirstEntry)
 		if err != nil {
 			return nil, fmt.Errorf("failed to compile is_first_entry: %w", err)
 		}
-	} else {
+	default:
 		matchesFirst = false
 		prog, err = helper.ExprCompileBool(c.IsLastEntry)
 		if err != nil {
@@ -106,10 +134,43 @@ func (c *Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		return nil, fmt.Errorf("invalid value '%s' for parameter 'overwrite_with'", c.OverwriteWith)
 	}
 
+	if c.SourceIdleTimeout < 0 {
+		return nil, fmt.Errorf("invalid value '%s' for parameter 'source_idle_timeout'", c.SourceIdleTimeout)
+	}
+
+	// The idle sources are flushed instead of the sources waiting for longer than force_flush_period
+	flushTimeout := c.ForceFlushTimeout
+	if c.SourceIdleTimeout > 0 {
+		flushTimeout = c.SourceIdleTimeout
+	}
+
+	mp := c.meterProvider
+	if mp == nil {
+		mp = noop.NewMeterProvider()
+	}
+	meter := mp.Meter(scopeName)
+	forcedFlushes, err := meter.Int64Counter(
+		operatorType+"_forced_flushes",
+		metric.WithDescription("Number of combined logs flushed because their source timed out"),
+		metric.WithUnit("{logs}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	truncations, err := meter.Int64Counter(
+		operatorType+"_truncations",
+		metric.WithDescription("Number of combined logs flushed before their last entry because they exceeded max_log_size"),
+		metric.WithUnit("{logs}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Transformer{
 		TransformerOperator: transformer,
 		matchFirstLine:      matchesFirst,
 		prog:                prog,
+		continues:           continues,
 		maxBatchSize:        c.MaxBatchSize,
 		maxSources:          c.MaxSources,
 		overwriteWithOldest: overwriteWithOldest,
@@ -123,11 +184,16 @@ func (c *Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		},
 		combineField:      c.CombineField,
 		combineWith:       c.CombineWith,
-		forceFlushTimeout: c.ForceFlushTimeout,
-		ticker:            time.NewTicker(c.ForceFlushTimeout),
+		mergeAttributes:   c.MergeAttributes,
+		forceFlushTimeout: flushTimeout,
+		flushWhenIdle:     c.SourceIdleTimeout > 0,
+		ticker:            time.NewTicker(flushTimeout),
 		chClose:           make(chan struct{}),
 		sourceIdentifier:  c.SourceIdentifier,
 		maxLogSize:        int64(c.MaxLogSize),
+		forcedFlushes:     forcedFlushes,
+		truncations:       truncations,
+		metricAttributes:  metric.WithAttributes(attribute.String(operatorKey, transformer.ID())),
 	}, nil
 }
 
@@ -137,13 +203,16 @@ type Transformer struct {
 	helper.TransformerOperator
 	matchFirstLine      bool
 	prog                *vm.Program
+	continues           continueFunc
 	maxBatchSize        int
 	maxSources          int
 	overwriteWithOldest bool
 	combineField        entry.Field
 	combineWith         string
+	mergeAttributes     bool
 	ticker              *time.Ticker
 	forceFlushTimeout   time.Duration
+	flushWhenIdle       bool
 	chClose             chan struct{}
 	sourceIdentifier    entry.Field
 
@@ -151,6 +220,10 @@ type Transformer struct {
 	batchPool  sync.Pool
 	batchMap   map[string]*sourceBatch
 	maxLogSize int64
+
+	forcedFlushes    metric.Int64Counter
+	truncations      metric.Int64Counter
+	metricAttributes metric.MeasurementOption
 }
 
 // sourceBatch contains the status info of a batch
@@ -158,7 +231,10 @@ type sourceBatch struct {
 	baseEntry              *entry.Entry
 	numEntries             int
 	recombined             *bytes.Buffer
+	lastLine               string
+	attributes             map[string]any
 	firstEntryObservedTime time.Time
+	lastEntryTime          time.Time
 }
 
 func (r *Transformer) Start(_ operator.Persister) error {
@@ -174,10 +250,14 @@ func (r *Transformer) flushLoop() {
 			r.Lock()
 			timeNow := time.Now()
 			for source, batch := range r.batchMap {
-				timeSinceFirstEntry := timeNow.Sub(batch.firstEntryObservedTime)
-				if timeSinceFirstEntry < r.forceFlushTimeout {
+				since := batch.firstEntryObservedTime
+				if r.flushWhenIdle {
+					since = batch.lastEntryTime
+				}
+				if timeNow.Sub(since) < r.forceFlushTimeout {
 					continue
 				}
+				r.forcedFlushes.Add(context.Background(), 1, r.metricAttributes)
 				if err := r.flushSource(context.Background(), source); err != nil {
 					r.Errorf("there was error flushing combined logs %s", err)
 				}
@@ -215,18 +295,22 @@ func (r *Transformer) Process(ctx context.Context, e *entry.Entry) error {
 	// In the future, we may want to provide access to the currently
 	// batched entries so users can do comparisons to other entries
 	// rather than just use absolute rules.
-	env := helper.GetExprEnv(e)
-	defer helper.PutExprEnv(env)
+	var matches bool
+	if r.prog != nil {
+		env := helper.GetExprEnv(e)
+		defer helper.PutExprEnv(env)
 
-	m, err := expr.Run(r.prog, env)
-	if err != nil {
-		return r.HandleEntryError(ctx, e, err)
+		m, err := expr.Run(r.prog, env)
+		if err != nil {
+			return r.HandleEntryError(ctx, e, err)
+		}
+
+		// this is guaranteed to be a boolean because of expr.AsBool
+		matches = m.(bool)
 	}
 
-	// this is guaranteed to be a boolean because of expr.AsBool
-	matches := m.(bool)
 	var s string
-	err = e.Read(r.sourceIdentifier, &s)
+	err := e.Read(r.sourceIdentifier, &s)
 	if err != nil {
 		r.Warn("entry does not contain the source_identifier, so it may be pooled with other sources")
 		s = DefaultSourceIdentifier
@@ -236,6 +320,10 @@ func (r *Transformer) Process(ctx context.Context, e *entry.Entry) error {
 		s = DefaultSourceIdentifier
 	}
 
+	if r.continues != nil {
+		matches = !r.continuesBatch(e, s)
+	}
+
 	switch {
 	// This is the first entry in the next batch
 	case matches && r.matchFirstLine:
@@ -259,6 +347,20 @@ func (r *Transformer) Process(ctx context.Context, e *entry.Entry) error {
 	return nil
 }
 
+// continuesBatch returns whether the entry continues the multiline log batched for the source,
+// according to the rules of the preset
+func (r *Transformer) continuesBatch(e *entry.Entry, source string) bool {
+	batch, ok := r.batchMap[source]
+	if !ok {
+		return false
+	}
+	var line string
+	if err := e.Read(r.combineField, &line); err != nil {
+		return false
+	}
+	return r.continues(batch.lastLine, line)
+}
+
 // addToBatch adds the current entry to the current batch of entries that will be combined
 func (r *Transformer) addToBatch(ctx context.Context, e *entry.Entry, source string) {
 	batch, ok := r.batchMap[source]
@@ -270,11 +372,26 @@ func (r *Transformer) addToBatch(ctx context.Context, e *entry.Entry, source str
 		batch = r.addNewBatch(source, e)
 	} else {
 		batch.numEntries++
+		batch.lastEntryTime = time.Now()
 		if r.overwriteWithOldest {
 			batch.baseEntry = e
 		}
 	}
 
+	if r.mergeAttributes {
+		if batch.attributes == nil {
+			batch.attributes = make(map[string]any, len(e.Attributes))
+		}
+		// The attributes of the entry whose fields are kept take precedence
+		isBase := batch.baseEntry == e
+		for k, v := range e.Attributes {
+			if _, ok := batch.attributes[k]; ok && !isBase {
+				continue
+			}
+			batch.attributes[k] = v
+		}
+	}
+
 	// Combine the combineField of each entry in the batch,
 	// separated by newlines
 	var s string
@@ -287,8 +404,13 @@ func (r *Transformer) addToBatch(ctx context.Context, e *entry.Entry, source str
 		batch.recombined.WriteString(r.combineWith)
 	}
 	batch.recombined.WriteString(s)
+	batch.lastLine = s[strings.LastIndexByte(s, '\n')+1:]
 
-	if (r.maxLogSize > 0 && int64(batch.recombined.Len()) > r.maxLogSize) || batch.numEntries >= r.maxBatchSize {
+	exceedsMaxLogSize := r.maxLogSize > 0 && int64(batch.recombined.Len()) > r.maxLogSize
+	if exceedsMaxLogSize {
+		r.truncations.Add(ctx, 1, r.metricAttributes)
+	}
+	if exceedsMaxLogSize || batch.numEntries >= r.maxBatchSize {
 		if err := r.flushSource(ctx, source); err != nil {
 			r.Errorf("there was error flushing combined logs %s", err)
 		}
@@ -328,6 +450,10 @@ func (r *Transformer) flushSource(ctx context.Context, source string) error {
 		return err
 	}
 
+	if r.mergeAttributes && len(batch.attributes) > 0 {
+		batch.baseEntry.Attributes = batch.attributes
+	}
+
 	r.Write(ctx, batch.baseEntry)
 	r.removeBatch(source)
 	return nil
@@ -339,7 +465,10 @@ func (r *Transformer) addNewBatch(source string, e *entry.Entry) *sourceBatch {
 	batch.baseEntry = e
 	batch.numEntries = 1
 	batch.recombined.Reset()
+	batch.lastLine = ""
+	batch.attributes = nil
 	batch.firstEntryObservedTime = e.ObservedTimestamp
+	batch.lastEntryTime = time.Now()
 	r.batchMap[source] = batch
 	return batch
 }
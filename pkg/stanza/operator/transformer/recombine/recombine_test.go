@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
@@ -496,6 +498,169 @@ func TestTransformer(t *testing.T) {
 				entryWithBodyAttr(t1, "content5\ncontent6\ncontent7\ncontent8\ncontent9", map[string]string{"file.path": "file1"}),
 			},
 		},
+		{
+			"PresetJavaStacktrace",
+			func() *Config {
+				cfg := NewConfig()
+				cfg.CombineField = entry.NewBodyField()
+				cfg.Preset = "java_stacktrace"
+				cfg.OutputIDs = []string{"fake"}
+				cfg.ForceFlushTimeout = 10 * time.Millisecond
+				return cfg
+			}(),
+			[]*entry.Entry{
+				entryWithBody(t1, "2024-03-21 10:00:00 ERROR Request failed"),
+				entryWithBody(t1, "java.lang.IllegalStateException: boom"),
+				entryWithBody(t1, "\tat com.example.Service.handle(Service.java:42)"),
+				entryWithBody(t1, "\tat com.example.Main.main(Main.java:10)"),
+				entryWithBody(t1, "Caused by: java.io.IOException: broken pipe"),
+				entryWithBody(t1, "\tat com.example.Client.send(Client.java:7)"),
+				entryWithBody(t1, "\t... 2 more"),
+				entryWithBody(t2, "2024-03-21 10:00:01 INFO Request served"),
+			},
+			[]*entry.Entry{
+				entryWithBody(t1, "2024-03-21 10:00:00 ERROR Request failed\n"+
+					"java.lang.IllegalStateException: boom\n"+
+					"\tat com.example.Service.handle(Service.java:42)\n"+
+					"\tat com.example.Main.main(Main.java:10)\n"+
+					"Caused by: java.io.IOException: broken pipe\n"+
+					"\tat com.example.Client.send(Client.java:7)\n"+
+					"\t... 2 more"),
+				entryWithBody(t2, "2024-03-21 10:00:01 INFO Request served"),
+			},
+		},
+		{
+			"PresetPythonTraceback",
+			func() *Config {
+				cfg := NewConfig()
+				cfg.CombineField = entry.NewBodyField()
+				cfg.Preset = "python_traceback"
+				cfg.OutputIDs = []string{"fake"}
+				cfg.ForceFlushTimeout = 10 * time.Millisecond
+				return cfg
+			}(),
+			[]*entry.Entry{
+				entryWithBody(t1, "ERROR:root:Request failed"),
+				entryWithBody(t1, "Traceback (most recent call last):"),
+				entryWithBody(t1, `  File "/app/main.py", line 10, in <module>`),
+				entryWithBody(t1, "    handle()"),
+				entryWithBody(t1, `  File "/app/main.py", line 6, in handle`),
+				entryWithBody(t1, `    raise ValueError("boom")`),
+				entryWithBody(t1, "ValueError: boom"),
+				entryWithBody(t2, "INFO:root:Request served"),
+			},
+			[]*entry.Entry{
+				entryWithBody(t1, "ERROR:root:Request failed\n"+
+					"Traceback (most recent call last):\n"+
+					"  File \"/app/main.py\", line 10, in <module>\n"+
+					"    handle()\n"+
+					"  File \"/app/main.py\", line 6, in handle\n"+
+					"    raise ValueError(\"boom\")\n"+
+					"ValueError: boom"),
+				entryWithBody(t2, "INFO:root:Request served"),
+			},
+		},
+		{
+			"PresetGoPanic",
+			func() *Config {
+				cfg := NewConfig()
+				cfg.CombineField = entry.NewBodyField()
+				cfg.Preset = "go_panic"
+				cfg.OutputIDs = []string{"fake"}
+				cfg.ForceFlushTimeout = 10 * time.Millisecond
+				return cfg
+			}(),
+			[]*entry.Entry{
+				entryWithBody(t1, "2024/03/21 10:00:00 starting"),
+				entryWithBody(t2, "panic: boom"),
+				entryWithBody(t2, ""),
+				entryWithBody(t2, "goroutine 1 [running]:"),
+				entryWithBody(t2, "main.handle(...)"),
+				entryWithBody(t2, "\t/app/main.go:6"),
+				entryWithBody(t2, "main.main()"),
+				entryWithBody(t2, "\t/app/main.go:10 +0x25"),
+				entryWithBody(t2, "exit status 2"),
+			},
+			[]*entry.Entry{
+				entryWithBody(t1, "2024/03/21 10:00:00 starting"),
+				entryWithBody(t2, "panic: boom\n"+
+					"\n"+
+					"goroutine 1 [running]:\n"+
+					"main.handle(...)\n"+
+					"\t/app/main.go:6\n"+
+					"main.main()\n"+
+					"\t/app/main.go:10 +0x25\n"+
+					"exit status 2"),
+			},
+		},
+		{
+			"PresetDotnetException",
+			func() *Config {
+				cfg := NewConfig()
+				cfg.CombineField = entry.NewBodyField()
+				cfg.Preset = "dotnet_exception"
+				cfg.OutputIDs = []string{"fake"}
+				cfg.ForceFlushTimeout = 10 * time.Millisecond
+				return cfg
+			}(),
+			[]*entry.Entry{
+				entryWithBody(t1, "Unhandled exception. System.InvalidOperationException: boom"),
+				entryWithBody(t1, " ---> System.IO.IOException: broken pipe"),
+				entryWithBody(t1, "   at Example.Client.Send() in /app/Client.cs:line 7"),
+				entryWithBody(t1, "   --- End of inner exception stack trace ---"),
+				entryWithBody(t1, "   at Example.Service.Handle() in /app/Service.cs:line 42"),
+				entryWithBody(t1, "   at Example.Program.Main(String[] args) in /app/Program.cs:line 10"),
+				entryWithBody(t2, "Request served"),
+			},
+			[]*entry.Entry{
+				entryWithBody(t1, "Unhandled exception. System.InvalidOperationException: boom\n"+
+					" ---> System.IO.IOException: broken pipe\n"+
+					"   at Example.Client.Send() in /app/Client.cs:line 7\n"+
+					"   --- End of inner exception stack trace ---\n"+
+					"   at Example.Service.Handle() in /app/Service.cs:line 42\n"+
+					"   at Example.Program.Main(String[] args) in /app/Program.cs:line 10"),
+				entryWithBody(t2, "Request served"),
+			},
+		},
+		{
+			"MergeAttributes",
+			func() *Config {
+				cfg := NewConfig()
+				cfg.CombineField = entry.NewBodyField()
+				cfg.IsFirstEntry = "body == 'start'"
+				cfg.MergeAttributes = true
+				cfg.OutputIDs = []string{"fake"}
+				return cfg
+			}(),
+			[]*entry.Entry{
+				entryWithBodyAttr(t1, "start", map[string]string{"file.path": "file1", "level": "error"}),
+				entryWithBodyAttr(t2, "content1", map[string]string{"file.path": "file1", "level": "info", "thread": "main"}),
+				entryWithBodyAttr(t2, "content2", map[string]string{"file.path": "file1", "span_id": "abc"}),
+				entryWithBodyAttr(t2, "start", map[string]string{"file.path": "file1"}),
+			},
+			[]*entry.Entry{
+				entryWithBodyAttr(t1, "start\ncontent1\ncontent2", map[string]string{"file.path": "file1", "level": "error", "thread": "main", "span_id": "abc"}),
+			},
+		},
+		{
+			"MergeAttributesOldest",
+			func() *Config {
+				cfg := NewConfig()
+				cfg.CombineField = entry.NewBodyField()
+				cfg.IsLastEntry = "body == 'end'"
+				cfg.MergeAttributes = true
+				cfg.OverwriteWith = "oldest"
+				cfg.OutputIDs = []string{"fake"}
+				return cfg
+			}(),
+			[]*entry.Entry{
+				entryWithBodyAttr(t1, "content1", map[string]string{"file.path": "file1", "level": "error", "thread": "main"}),
+				entryWithBodyAttr(t2, "end", map[string]string{"file.path": "file1", "level": "info"}),
+			},
+			[]*entry.Entry{
+				entryWithBodyAttr(t2, "content1\nend", map[string]string{"file.path": "file1", "level": "info", "thread": "main"}),
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -779,3 +944,165 @@ func TestSourceBatchDelete(t *testing.T) {
 	fake.ExpectEntry(t, expect)
 	require.NoError(t, recombine.Stop())
 }
+
+func TestSourceIdleTimeout(t *testing.T) {
+	t.Parallel()
+
+	cfg := NewConfig()
+	cfg.CombineField = entry.NewBodyField()
+	cfg.IsFirstEntry = "body == 'start'"
+	cfg.CombineWith = ""
+	cfg.OutputIDs = []string{"fake"}
+	cfg.ForceFlushTimeout = 50 * time.Millisecond
+	cfg.SourceIdleTimeout = 200 * time.Millisecond
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	recombine := op.(*Transformer)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, recombine.SetOutputs([]operator.Operator{fake}))
+
+	start := entry.New()
+	start.Timestamp = time.Now()
+	start.Body = "start"
+
+	next := entry.New()
+	next.Timestamp = time.Now()
+	next.Body = "next"
+
+	ctx := context.Background()
+
+	require.NoError(t, recombine.Start(nil))
+	require.NoError(t, recombine.Process(ctx, start))
+
+	// The source is kept active for longer than force_flush_period and source_idle_timeout
+	for i := 0; i < 5; i++ {
+		select {
+		case <-fake.Received:
+			t.Logf("We shouldn't receive an entry while the source is active")
+			t.FailNow()
+		case <-time.After(cfg.SourceIdleTimeout / 4):
+		}
+		require.NoError(t, recombine.Process(ctx, next))
+	}
+
+	select {
+	case e := <-fake.Received:
+		require.Equal(t, "startnextnextnextnextnext", e.Body)
+	case <-time.After(5 * time.Second):
+		t.Logf("The entry should be flushed by now")
+		t.FailNow()
+	}
+
+	require.NoError(t, recombine.Stop())
+}
+
+func TestMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	cfg := NewConfig()
+	cfg.CombineField = entry.NewBodyField()
+	cfg.IsFirstEntry = "body == 'start'"
+	cfg.OutputIDs = []string{"fake"}
+	cfg.ForceFlushTimeout = 10 * time.Millisecond
+	cfg.MaxLogSize = 10
+	cfg.SetMeterProvider(mp)
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	recombine := op.(*Transformer)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, recombine.SetOutputs([]operator.Operator{fake}))
+
+	newEntry := func(body string) *entry.Entry {
+		e := entry.New()
+		e.Timestamp = time.Now()
+		e.Body = body
+		return e
+	}
+
+	ctx := context.Background()
+
+	require.NoError(t, recombine.Start(nil))
+	require.NoError(t, recombine.Process(ctx, newEntry("start")))
+	require.NoError(t, recombine.Process(ctx, newEntry("content")))
+	fake.ExpectBody(t, "start\ncontent")
+	require.NoError(t, recombine.Process(ctx, newEntry("start")))
+	fake.ExpectBody(t, "start")
+	require.NoError(t, recombine.Stop())
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	sums := map[string]int64{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		sum, ok := m.Data.(metricdata.Sum[int64])
+		require.True(t, ok)
+		require.Len(t, sum.DataPoints, 1)
+		id, ok := sum.DataPoints[0].Attributes.Value(operatorKey)
+		require.True(t, ok)
+		require.Equal(t, "recombine", id.AsString())
+		sums[m.Name] = sum.DataPoints[0].Value
+	}
+	require.Equal(t, map[string]int64{
+		"recombine_forced_flushes": 1,
+		"recombine_truncations":    1,
+	}, sums)
+}
+
+func TestBuildInvalid(t *testing.T) {
+	cases := []struct {
+		name   string
+		config func(*Config)
+		err    string
+	}{
+		{
+			"FirstAndLastEntry",
+			func(cfg *Config) {
+				cfg.IsFirstEntry = MatchAll
+				cfg.IsLastEntry = MatchAll
+			},
+			"only one of is_first_entry and is_last_entry can be set",
+		},
+		{
+			"PresetAndFirstEntry",
+			func(cfg *Config) {
+				cfg.IsFirstEntry = MatchAll
+				cfg.Preset = "java_stacktrace"
+			},
+			"preset cannot be set with is_first_entry or is_last_entry",
+		},
+		{
+			"NoRule",
+			func(_ *Config) {},
+			"one of is_first_entry, is_last_entry and preset must be set",
+		},
+		{
+			"UnknownPreset",
+			func(cfg *Config) {
+				cfg.Preset = "ruby_backtrace"
+			},
+			"invalid value 'ruby_backtrace' for parameter 'preset'",
+		},
+		{
+			"NegativeSourceIdleTimeout",
+			func(cfg *Config) {
+				cfg.IsFirstEntry = MatchAll
+				cfg.SourceIdleTimeout = -time.Second
+			},
+			"invalid value '-1s' for parameter 'source_idle_timeout'",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfig()
+			cfg.CombineField = entry.NewBodyField()
+			tc.config(cfg)
+			_, err := cfg.Build(testutil.Logger(t))
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
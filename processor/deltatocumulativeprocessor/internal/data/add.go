@@ -3,7 +3,14 @@
 
 package data // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/data"
 
-import "go.opentelemetry.io/collector/pdata/pmetric"
+import (
+	"math"
+	"slices"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/expo"
+)
 
 func (dp Number) Add(in Number) Number {
 	switch in.ValueType() {
@@ -18,12 +25,97 @@ func (dp Number) Add(in Number) Number {
 	return dp
 }
 
-// nolint
 func (dp Histogram) Add(in Histogram) Histogram {
-	panic("todo")
+	// different bounds: the buckets cannot be added, so the cumulative
+	// histogram restarts from the new point
+	if !slices.Equal(dp.ExplicitBounds().AsRaw(), in.ExplicitBounds().AsRaw()) {
+		in.CopyTo(dp)
+		return dp
+	}
+
+	// the spec requires len(BucketCounts) == len(ExplicitBounds)+1, which is
+	// not enforced upstream. adding the buckets both points have is the best
+	// effort possible.
+	n := min(dp.BucketCounts().Len(), in.BucketCounts().Len())
+	for i := 0; i < n; i++ {
+		dp.BucketCounts().SetAt(i, dp.BucketCounts().At(i)+in.BucketCounts().At(i))
+	}
+
+	dp.SetTimestamp(in.Timestamp())
+	dp.SetCount(dp.Count() + in.Count())
+	addStats(dp.HistogramDataPoint, in.HistogramDataPoint)
+	return dp
 }
 
-// nolint
 func (dp ExpHistogram) Add(in ExpHistogram) ExpHistogram {
-	panic("todo")
+	// different scales: downscale the buckets of the point with the higher
+	// scale, the lower resolution being the only one both points can be
+	// represented in
+	if dp.Scale() != in.Scale() {
+		hi, lo := dp.ExponentialHistogramDataPoint, in.ExponentialHistogramDataPoint
+		if hi.Scale() < lo.Scale() {
+			hi, lo = lo, hi
+		}
+		from, to := expo.Scale(hi.Scale()), expo.Scale(lo.Scale())
+		expo.Downscale(hi.Positive(), from, to)
+		expo.Downscale(hi.Negative(), from, to)
+		hi.SetScale(lo.Scale())
+	}
+
+	// different zero thresholds: widen the narrower zero bucket. widening
+	// rounds up to a bucket boundary, so the other one is widened to match.
+	if dp.ZeroThreshold() != in.ZeroThreshold() {
+		hi, lo := dp.ExponentialHistogramDataPoint, in.ExponentialHistogramDataPoint
+		if hi.ZeroThreshold() < lo.ZeroThreshold() {
+			hi, lo = lo, hi
+		}
+		expo.WidenZero(lo, hi.ZeroThreshold())
+		if lo.ZeroThreshold() > hi.ZeroThreshold() {
+			expo.WidenZero(hi, lo.ZeroThreshold())
+		}
+	}
+
+	expo.Merge(dp.Positive(), in.Positive())
+	expo.Merge(dp.Negative(), in.Negative())
+
+	dp.SetTimestamp(in.Timestamp())
+	dp.SetCount(dp.Count() + in.Count())
+	dp.SetZeroCount(dp.ZeroCount() + in.ZeroCount())
+	addStats(dp.ExponentialHistogramDataPoint, in.ExponentialHistogramDataPoint)
+	return dp
+}
+
+type stats interface {
+	HasSum() bool
+	Sum() float64
+	SetSum(float64)
+	RemoveSum()
+	HasMin() bool
+	Min() float64
+	SetMin(float64)
+	RemoveMin()
+	HasMax() bool
+	Max() float64
+	SetMax(float64)
+	RemoveMax()
+}
+
+// addStats adds the sum, min and max of in to those of dp. Each of them is
+// only known if both points have it.
+func addStats[S stats](dp, in S) {
+	if dp.HasSum() && in.HasSum() {
+		dp.SetSum(dp.Sum() + in.Sum())
+	} else {
+		dp.RemoveSum()
+	}
+	if dp.HasMin() && in.HasMin() {
+		dp.SetMin(math.Min(dp.Min(), in.Min()))
+	} else {
+		dp.RemoveMin()
+	}
+	if dp.HasMax() && in.HasMax() {
+		dp.SetMax(math.Max(dp.Max(), in.Max()))
+	} else {
+		dp.RemoveMax()
+	}
 }
@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package data_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/data"
+)
+
+func histogram(ts int, bounds []float64, counts ...uint64) data.Histogram {
+	dp := pmetric.NewHistogramDataPoint()
+	dp.SetStartTimestamp(pcommon.Timestamp(ts - 10))
+	dp.SetTimestamp(pcommon.Timestamp(ts))
+	dp.ExplicitBounds().FromRaw(bounds)
+	dp.BucketCounts().FromRaw(counts)
+	var count uint64
+	for _, c := range counts {
+		count += c
+	}
+	dp.SetCount(count)
+	dp.SetSum(float64(count))
+	dp.SetMin(float64(ts))
+	dp.SetMax(float64(ts))
+	return data.Histogram{HistogramDataPoint: dp}
+}
+
+func TestHistogramAdd(t *testing.T) {
+	bounds := []float64{1, 10}
+
+	dp := histogram(10, bounds, 1, 2, 3)
+	res := dp.Add(histogram(20, bounds, 4, 5, 6))
+
+	require.Equal(t, []uint64{5, 7, 9}, res.BucketCounts().AsRaw())
+	require.Equal(t, uint64(21), res.Count())
+	require.Equal(t, 21.0, res.Sum())
+	require.Equal(t, 10.0, res.Min())
+	require.Equal(t, 20.0, res.Max())
+	require.Equal(t, pcommon.Timestamp(0), res.StartTimestamp())
+	require.Equal(t, pcommon.Timestamp(20), res.Timestamp())
+
+	// without a sum, the sum of the points is unknown
+	in := histogram(30, bounds, 1, 1, 1)
+	in.RemoveSum()
+	res = res.Add(in)
+	require.False(t, res.HasSum())
+	require.Equal(t, uint64(24), res.Count())
+}
+
+func TestHistogramAddDifferentBounds(t *testing.T) {
+	dp := histogram(10, []float64{1, 10}, 1, 2, 3)
+	res := dp.Add(histogram(20, []float64{1, 5, 10}, 4, 5, 6, 7))
+
+	// the histogram restarts from the point with the new bounds
+	require.Equal(t, []float64{1, 5, 10}, res.ExplicitBounds().AsRaw())
+	require.Equal(t, []uint64{4, 5, 6, 7}, res.BucketCounts().AsRaw())
+	require.Equal(t, uint64(22), res.Count())
+	require.Equal(t, pcommon.Timestamp(10), res.StartTimestamp())
+}
+
+func expHistogram(ts int, scale int32, zeroThreshold float64, offset int32, counts ...uint64) data.ExpHistogram {
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	dp.SetTimestamp(pcommon.Timestamp(ts))
+	dp.SetScale(scale)
+	dp.SetZeroThreshold(zeroThreshold)
+	dp.SetZeroCount(1)
+	dp.Positive().SetOffset(offset)
+	dp.Positive().BucketCounts().FromRaw(counts)
+	count := dp.ZeroCount()
+	for _, c := range counts {
+		count += c
+	}
+	dp.SetCount(count)
+	dp.SetSum(float64(count))
+	return data.ExpHistogram{ExponentialHistogramDataPoint: dp}
+}
+
+func TestExpHistogramAdd(t *testing.T) {
+	cases := []struct {
+		name string
+		dp   data.ExpHistogram
+		in   data.ExpHistogram
+
+		scale         int32
+		zeroThreshold float64
+		zeroCount     uint64
+		offset        int32
+		counts        []uint64
+	}{{
+		name:   "aligned",
+		dp:     expHistogram(10, 0, 0, 1, 1, 2, 3),
+		in:     expHistogram(20, 0, 0, 1, 1, 1, 1),
+		scale:  0,
+		offset: 1, counts: []uint64{2, 3, 4},
+		zeroCount: 2,
+	}, {
+		name:   "offsets",
+		dp:     expHistogram(10, 0, 0, 1, 1, 2),
+		in:     expHistogram(20, 0, 0, -1, 1, 1),
+		scale:  0,
+		offset: -1, counts: []uint64{1, 1, 1, 2},
+		zeroCount: 2,
+	}, {
+		name: "in at a lower scale",
+		// indexes 2..5 at scale 1 are 1,1,2,2 at scale 0
+		dp:     expHistogram(10, 1, 0, 2, 1, 2, 3, 4),
+		in:     expHistogram(20, 0, 0, 0, 5, 0, 1),
+		scale:  0,
+		offset: 0, counts: []uint64{5, 3, 8},
+		zeroCount: 2,
+	}, {
+		name:   "in at a higher scale",
+		dp:     expHistogram(10, 0, 0, 0, 5, 0, 1),
+		in:     expHistogram(20, 1, 0, 2, 1, 2, 3, 4),
+		scale:  0,
+		offset: 0, counts: []uint64{5, 3, 8},
+		zeroCount: 2,
+	}, {
+		name: "wider zero bucket",
+		// the buckets (1,2] and (2,4] of dp are moved into the zero bucket
+		dp:            expHistogram(10, 0, 0, 0, 1, 2, 3),
+		in:            expHistogram(20, 0, 3, 2, 1),
+		scale:         0,
+		zeroThreshold: 4,
+		offset:        2, counts: []uint64{4},
+		zeroCount: 1 + 1 + 1 + 2,
+	}}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			count := c.dp.Count() + c.in.Count()
+			res := c.dp.Add(c.in)
+
+			require.Equal(t, c.scale, res.Scale())
+			require.Equal(t, c.zeroThreshold, res.ZeroThreshold())
+			require.Equal(t, c.zeroCount, res.ZeroCount())
+			require.Equal(t, c.offset, res.Positive().Offset())
+			require.Equal(t, c.counts, res.Positive().BucketCounts().AsRaw())
+			require.Equal(t, count, res.Count())
+			require.Equal(t, float64(count), res.Sum())
+			require.Equal(t, pcommon.Timestamp(20), res.Timestamp())
+		})
+	}
+}
@@ -4,6 +4,8 @@
 package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/metrics"
 
 import (
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/data"
 )
 
@@ -11,6 +13,9 @@ type Data[D data.Point[D]] interface {
 	At(i int) D
 	Len() int
 	Ident() Ident
+
+	// Filter removes the points for which keep returns false
+	Filter(keep func(D) bool)
 }
 
 type Sum Metric
@@ -28,6 +33,12 @@ func (s Sum) Ident() Ident {
 	return (*Metric)(&s).Ident()
 }
 
+func (s Sum) Filter(keep func(data.Number) bool) {
+	Metric(s).Sum().DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+		return !keep(data.Number{NumberDataPoint: dp})
+	})
+}
+
 type Histogram Metric
 
 func (s Histogram) At(i int) data.Histogram {
@@ -43,6 +54,12 @@ func (s Histogram) Ident() Ident {
 	return (*Metric)(&s).Ident()
 }
 
+func (s Histogram) Filter(keep func(data.Histogram) bool) {
+	Metric(s).Histogram().DataPoints().RemoveIf(func(dp pmetric.HistogramDataPoint) bool {
+		return !keep(data.Histogram{HistogramDataPoint: dp})
+	})
+}
+
 type ExpHistogram Metric
 
 func (s ExpHistogram) At(i int) data.ExpHistogram {
@@ -57,3 +74,9 @@ func (s ExpHistogram) Len() int {
 func (s ExpHistogram) Ident() Ident {
 	return (*Metric)(&s).Ident()
 }
+
+func (s ExpHistogram) Filter(keep func(data.ExpHistogram) bool) {
+	Metric(s).ExponentialHistogram().DataPoints().RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool {
+		return !keep(data.ExpHistogram{ExponentialHistogramDataPoint: dp})
+	})
+}
@@ -27,16 +27,22 @@ func Samples[D data.Point[D]](m metrics.Data[D]) Seq[D] {
 	}
 }
 
-// Aggregate each point and replace it by the result
+// Aggregate each point and replace it by the result. The points the aggregator
+// fails to aggregate are removed, except those of the new streams which evicted
+// another stream.
 func Aggregate[D data.Point[D]](m metrics.Data[D], aggr Aggregator[D]) error {
 	var errs error
 
-	// for id, dp := range Samples(m)
-	Samples(m)(func(id Ident, dp D) bool {
+	mid := m.Ident()
+	m.Filter(func(dp D) bool {
+		id := identity.OfStream(mid, dp)
 		next, err := aggr.Aggregate(id, dp)
 		if err != nil {
 			errs = errors.Join(errs, Error(id, err))
-			return true
+			var evicted ErrEvicted
+			if !errors.As(err, &evicted) {
+				return false
+			}
 		}
 		next.CopyTo(dp)
 		return true
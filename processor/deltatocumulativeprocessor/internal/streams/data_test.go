@@ -119,6 +119,13 @@ func (l Data) Ident() metrics.Ident {
 	return l.id.Metric()
 }
 
+// Filter visits all points, but does not remove any of them from the test data
+func (l Data) Filter(keep func(data.Number) bool) {
+	for _, dp := range l.dps {
+		keep(dp)
+	}
+}
+
 type aggr func(streams.Ident, data.Number) (data.Number, error)
 
 func (a aggr) Aggregate(id streams.Ident, dp data.Number) (data.Number, error) {
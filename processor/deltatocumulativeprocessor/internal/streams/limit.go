@@ -18,12 +18,20 @@ func Limit[T any](m Map[T], max int) LimitMap[T] {
 type LimitMap[T any] struct {
 	Max int
 
+	// Total counts the streams the limit applies to. Defaults to the streams
+	// of Map, and allows several maps to share the same limit.
+	Total   Counter
 	Evictor streams.Evictor
 	streams.Map[T]
 }
 
 func (m LimitMap[T]) Store(id identity.Stream, v T) error {
-	if m.Map.Len() < m.Max {
+	var total Counter = m.Map
+	if m.Total != nil {
+		total = m.Total
+	}
+	// known streams are not limited
+	if _, ok := m.Map.Load(id); ok || total.Len() < m.Max {
 		return m.Map.Store(id, v)
 	}
 
@@ -38,6 +46,22 @@ func (m LimitMap[T]) Store(id identity.Stream, v T) error {
 	return errl
 }
 
+// Counter counts streams.
+type Counter interface {
+	Len() int
+}
+
+// Total counts the streams of all its counters.
+type Total []Counter
+
+func (t Total) Len() int {
+	n := 0
+	for _, c := range t {
+		n += c.Len()
+	}
+	return n
+}
+
 type ErrLimit int
 
 func (e ErrLimit) Error() string {
@@ -40,6 +40,13 @@ func TestLimit(t *testing.T) {
 		require.True(t, streams.AtLimit(err))
 	}
 
+	// known streams must still be accepted
+	{
+		_, dp := sum.Stream()
+		err := lim.Store(ids[1], dp)
+		require.NoError(t, err)
+	}
+
 	// after removing one, must be accepted again
 	{
 		lim.Delete(ids[0])
@@ -49,3 +56,26 @@ func TestLimit(t *testing.T) {
 		require.NoError(t, err)
 	}
 }
+
+func TestLimitTotal(t *testing.T) {
+	sum := random.Sum()
+
+	sums := make(exp.HashMap[data.Number])
+	others := make(exp.HashMap[data.Number])
+	total := streams.Total{sums, others}
+
+	lim := streams.Limit(sums, 2)
+	lim.Total = total
+
+	// streams of the other map count towards the limit
+	id, dp := sum.Stream()
+	require.NoError(t, others.Store(id, dp))
+
+	id, dp = sum.Stream()
+	require.NoError(t, lim.Store(id, dp))
+
+	id, dp = sum.Stream()
+	err := lim.Store(id, dp)
+	require.True(t, streams.AtLimit(err))
+	require.Equal(t, 2, total.Len())
+}
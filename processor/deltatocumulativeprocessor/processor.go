@@ -31,44 +31,113 @@ type Processor struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 
-	aggr  streams.Aggregator[data.Number]
-	stale *staleness.Staleness[data.Number]
+	sums  pipeline[data.Number]
+	hists pipeline[data.Histogram]
+	expos pipeline[data.ExpHistogram]
 
 	mtx sync.Mutex
 }
 
-func newProcessor(cfg *Config, log *zap.Logger, next consumer.Metrics) *Processor {
+// pipeline accumulates the points of one type of metric.
+type pipeline[D data.Point[D]] struct {
+	dps   streams.Map[D]
+	aggr  streams.Aggregator[D]
+	stale *staleness.Staleness[D]
+}
+
+func newPipeline[D data.Point[D]](cfg *Config) pipeline[D] {
+	var pipe pipeline[D]
+	pipe.dps = delta.New[D]()
+
+	if cfg.MaxStale > 0 {
+		stale := staleness.NewStaleness(cfg.MaxStale, pipe.dps)
+		pipe.stale = stale
+		pipe.dps = stale
+	}
+	return pipe
+}
+
+// limit applies the stream limit shared by the pipelines of all metric types,
+// which counts the streams of total.
+func (pipe *pipeline[D]) limit(max int, total streams.Total, evictor streams.Evictor) {
+	lim := streams.Limit(pipe.dps, max)
+	lim.Total = total
+	lim.Evictor = evictor
+	pipe.dps = lim
+}
+
+func (pipe *pipeline[D]) observe(tel *telemetry) {
+	pipe.aggr = observedAggregator[D]{Aggregator: streams.IntoAggregator(pipe.dps), tel: tel}
+}
+
+func (pipe pipeline[D]) expire() {
+	if pipe.stale != nil {
+		pipe.stale.ExpireOldEntries()
+	}
+}
+
+// staleStreams is implemented by the staleness tracking of each pipeline.
+type staleStreams interface {
+	streams.Evictor
+	Len() int
+	Next() time.Time
+}
+
+// oldestEvictor evicts the least recently updated stream of all pipelines.
+type oldestEvictor []staleStreams
+
+func (e oldestEvictor) Evict() streams.Ident {
+	var oldest staleStreams
+	for _, s := range e {
+		if s.Len() == 0 {
+			continue
+		}
+		if oldest == nil || s.Next().Before(oldest.Next()) {
+			oldest = s
+		}
+	}
+	return oldest.Evict()
+}
+
+func newProcessor(cfg *Config, set processor.CreateSettings, next consumer.Metrics) (*Processor, error) {
+	tel, err := newTelemetry(set)
+	if err != nil {
+		return nil, err
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 
 	proc := Processor{
-		log:    log,
+		log:    set.Logger,
 		ctx:    ctx,
 		cancel: cancel,
 		next:   next,
-	}
-
-	var dps streams.Map[data.Number]
-	dps = delta.New[data.Number]()
 
-	if cfg.MaxStale > 0 {
-		stale := staleness.NewStaleness(cfg.MaxStale, dps)
-		proc.stale = stale
-		dps = stale
+		sums:  newPipeline[data.Number](cfg),
+		hists: newPipeline[data.Histogram](cfg),
+		expos: newPipeline[data.ExpHistogram](cfg),
 	}
+
+	// max_streams limits the streams of all metric types together
 	if cfg.MaxStreams > 0 {
-		lim := streams.Limit(dps, cfg.MaxStreams)
-		if proc.stale != nil {
-			lim.Evictor = proc.stale
+		total := streams.Total{proc.sums.dps, proc.hists.dps, proc.expos.dps}
+		var evictor streams.Evictor
+		if cfg.MaxStale > 0 {
+			evictor = oldestEvictor{proc.sums.stale, proc.hists.stale, proc.expos.stale}
 		}
-		dps = lim
+		proc.sums.limit(cfg.MaxStreams, total, evictor)
+		proc.hists.limit(cfg.MaxStreams, total, evictor)
+		proc.expos.limit(cfg.MaxStreams, total, evictor)
 	}
 
-	proc.aggr = streams.IntoAggregator(dps)
-	return &proc
+	proc.sums.observe(tel)
+	proc.hists.observe(tel)
+	proc.expos.observe(tel)
+	return &proc, nil
 }
 
 func (p *Processor) Start(_ context.Context, _ component.Host) error {
-	if p.stale == nil {
+	if p.sums.stale == nil {
 		return nil
 	}
 
@@ -80,7 +149,9 @@ func (p *Processor) Start(_ context.Context, _ component.Host) error {
 				return
 			case <-tick.C:
 				p.mtx.Lock()
-				p.stale.ExpireOldEntries()
+				p.sums.expire()
+				p.hists.expire()
+				p.expos.expire()
 				p.mtx.Unlock()
 			}
 		}
@@ -108,19 +179,32 @@ func (p *Processor) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) erro
 		case pmetric.MetricTypeSum:
 			sum := m.Sum()
 			if sum.AggregationTemporality() == pmetric.AggregationTemporalityDelta {
-				err := streams.Aggregate[data.Number](metrics.Sum(m), p.aggr)
+				err := streams.Aggregate[data.Number](metrics.Sum(m), p.sums.aggr)
 				errs = errors.Join(errs, err)
 				sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
 			}
 		case pmetric.MetricTypeHistogram:
-			// TODO
+			hist := m.Histogram()
+			if hist.AggregationTemporality() == pmetric.AggregationTemporalityDelta {
+				err := streams.Aggregate[data.Histogram](metrics.Histogram(m), p.hists.aggr)
+				errs = errors.Join(errs, err)
+				hist.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+			}
 		case pmetric.MetricTypeExponentialHistogram:
-			// TODO
+			expo := m.ExponentialHistogram()
+			if expo.AggregationTemporality() == pmetric.AggregationTemporalityDelta {
+				err := streams.Aggregate[data.ExpHistogram](metrics.ExpHistogram(m), p.expos.aggr)
+				errs = errors.Join(errs, err)
+				expo.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+			}
 		}
 	})
 
+	// the points which could not be accumulated were removed, and are counted
+	// in the telemetry of the processor. as they may be frequent, they are
+	// only logged at the debug level
 	if errs != nil {
-		return errs
+		p.log.Debug("failed to accumulate delta data points", zap.Error(errs))
 	}
 
 	return p.next.ConsumeMetrics(ctx, md)
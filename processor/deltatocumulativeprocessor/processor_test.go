@@ -0,0 +1,239 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package deltatocumulativeprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/metadata"
+)
+
+func setupProcessor(t *testing.T, cfg *Config) (*Processor, *consumertest.MetricsSink, *sdkmetric.ManualReader) {
+	reader := sdkmetric.NewManualReader()
+	set := processortest.NewNopCreateSettings()
+	set.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	set.ID = component.NewID(metadata.Type)
+
+	sink := new(consumertest.MetricsSink)
+	proc, err := newProcessor(cfg, set, sink)
+	require.NoError(t, err)
+	return proc, sink, reader
+}
+
+// histograms returns metrics holding a delta histogram and a delta exponential
+// histogram, each with a single point.
+func histograms(start, ts int, counts ...uint64) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+
+	hist := ms.AppendEmpty()
+	hist.SetName("latency")
+	hist.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	dp := hist.Histogram().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(pcommon.Timestamp(start))
+	dp.SetTimestamp(pcommon.Timestamp(ts))
+	dp.ExplicitBounds().FromRaw([]float64{1, 10})
+	dp.BucketCounts().FromRaw(counts)
+
+	expo := ms.AppendEmpty()
+	expo.SetName("size")
+	expo.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	edp := expo.ExponentialHistogram().DataPoints().AppendEmpty()
+	edp.SetStartTimestamp(pcommon.Timestamp(start))
+	edp.SetTimestamp(pcommon.Timestamp(ts))
+	edp.Positive().BucketCounts().FromRaw(counts)
+
+	return md
+}
+
+func TestHistograms(t *testing.T) {
+	proc, sink, reader := setupProcessor(t, createDefaultConfig().(*Config))
+	ctx := context.Background()
+
+	require.NoError(t, proc.ConsumeMetrics(ctx, histograms(0, 10, 1, 2, 3)))
+	require.NoError(t, proc.ConsumeMetrics(ctx, histograms(10, 20, 1, 1, 1)))
+	// out of order, dropped
+	require.NoError(t, proc.ConsumeMetrics(ctx, histograms(10, 15, 5, 5, 5)))
+
+	all := sink.AllMetrics()
+	require.Len(t, all, 3)
+
+	ms := all[1].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	hist := ms.At(0).Histogram()
+	require.Equal(t, pmetric.AggregationTemporalityCumulative, hist.AggregationTemporality())
+	require.Equal(t, []uint64{2, 3, 4}, hist.DataPoints().At(0).BucketCounts().AsRaw())
+	require.Equal(t, pcommon.Timestamp(0), hist.DataPoints().At(0).StartTimestamp())
+	require.Equal(t, pcommon.Timestamp(20), hist.DataPoints().At(0).Timestamp())
+
+	expo := ms.At(1).ExponentialHistogram()
+	require.Equal(t, pmetric.AggregationTemporalityCumulative, expo.AggregationTemporality())
+	require.Equal(t, []uint64{2, 3, 4}, expo.DataPoints().At(0).Positive().BucketCounts().AsRaw())
+
+	// the out of order points are removed
+	ms = all[2].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Zero(t, ms.At(0).Histogram().DataPoints().Len())
+	require.Zero(t, ms.At(1).ExponentialHistogram().DataPoints().Len())
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	processorAttr := attribute.String("processor", metadata.Type.String())
+
+	processed := getMetric(t, rm, "processor/deltatocumulative/datapoints.processed")
+	metricdatatest.AssertEqual(t, metricdata.Metrics{
+		Name:        "processor/deltatocumulative/datapoints.processed",
+		Description: "Number of delta data points processed by the delta to cumulative processor",
+		Unit:        "1",
+		Data: metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+			DataPoints: []metricdata.DataPoint[int64]{
+				{Value: 6, Attributes: attribute.NewSet(processorAttr)},
+			},
+		},
+	}, processed, metricdatatest.IgnoreTimestamp())
+
+	dropped := getMetric(t, rm, "processor/deltatocumulative/datapoints.dropped")
+	metricdatatest.AssertEqual(t, metricdata.Metrics{
+		Name:        "processor/deltatocumulative/datapoints.dropped",
+		Description: "Number of delta data points dropped by the delta to cumulative processor, by reason",
+		Unit:        "1",
+		Data: metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+			DataPoints: []metricdata.DataPoint[int64]{
+				{Value: 2, Attributes: attribute.NewSet(processorAttr, attribute.String("reason", "out_of_order"))},
+			},
+		},
+	}, dropped, metricdatatest.IgnoreTimestamp())
+}
+
+func TestStreamLimit(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxStale = 0
+	cfg.MaxStreams = 1
+	proc, sink, reader := setupProcessor(t, cfg)
+	ctx := context.Background()
+
+	md := histograms(0, 10, 1)
+	dps := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram().DataPoints()
+	dps.At(0).CopyTo(dps.AppendEmpty())
+	dps.At(1).Attributes().PutStr("stream", "second")
+	require.NoError(t, proc.ConsumeMetrics(ctx, md))
+
+	ms := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 1, ms.At(0).Histogram().DataPoints().Len())
+	// the limit is shared by all types of metrics
+	require.Zero(t, ms.At(1).ExponentialHistogram().DataPoints().Len())
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	dropped := getMetric(t, rm, "processor/deltatocumulative/datapoints.dropped")
+	sum, ok := dropped.Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, sum.DataPoints, 1)
+	require.Equal(t, int64(2), sum.DataPoints[0].Value)
+	reason, _ := sum.DataPoints[0].Attributes.Value("reason")
+	require.Equal(t, "stream_limit", reason.AsString())
+}
+
+func TestStreamLimitEvict(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxStreams = 1
+	proc, sink, reader := setupProcessor(t, cfg)
+	ctx := context.Background()
+
+	// the exponential histogram evicts the stream of the histogram
+	require.NoError(t, proc.ConsumeMetrics(ctx, histograms(0, 10, 1)))
+
+	ms := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 1, ms.At(0).Histogram().DataPoints().Len())
+	require.Equal(t, 1, ms.At(1).ExponentialHistogram().DataPoints().Len())
+	require.Zero(t, proc.hists.stale.Len())
+	require.Equal(t, 1, proc.expos.stale.Len())
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	evicted := getMetric(t, rm, "processor/deltatocumulative/streams.evicted")
+	sum, ok := evicted.Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, sum.DataPoints, 1)
+	require.Equal(t, int64(1), sum.DataPoints[0].Value)
+}
+
+func TestDroppedPoints(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxStale = 0
+	cfg.MaxStreams = 2
+	proc, sink, reader := setupProcessor(t, cfg)
+	core, logs := observer.New(zapcore.DebugLevel)
+	proc.log = zap.New(core)
+	ctx := context.Background()
+
+	require.NoError(t, proc.ConsumeMetrics(ctx, histograms(10, 20, 1)))
+	// older start of the histogram, out of order exponential histogram
+	md := histograms(10, 20, 1)
+	md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram().DataPoints().At(0).SetStartTimestamp(5)
+	// a third stream exceeds the limit
+	sum := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().AppendEmpty()
+	sum.SetName("requests")
+	sum.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	sum.Sum().DataPoints().AppendEmpty().SetIntValue(1)
+	require.NoError(t, proc.ConsumeMetrics(ctx, md))
+
+	// every point removed from the batch is counted
+	ms := sink.AllMetrics()[1].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Zero(t, ms.At(0).Histogram().DataPoints().Len())
+	require.Zero(t, ms.At(1).ExponentialHistogram().DataPoints().Len())
+	require.Zero(t, ms.At(2).Sum().DataPoints().Len())
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	processorAttr := attribute.String("processor", metadata.Type.String())
+	dropped := getMetric(t, rm, "processor/deltatocumulative/datapoints.dropped")
+	metricdatatest.AssertEqual(t, metricdata.Metrics{
+		Name:        "processor/deltatocumulative/datapoints.dropped",
+		Description: "Number of delta data points dropped by the delta to cumulative processor, by reason",
+		Unit:        "1",
+		Data: metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+			DataPoints: []metricdata.DataPoint[int64]{
+				{Value: 1, Attributes: attribute.NewSet(processorAttr, attribute.String("reason", "older_start"))},
+				{Value: 1, Attributes: attribute.NewSet(processorAttr, attribute.String("reason", "out_of_order"))},
+				{Value: 1, Attributes: attribute.NewSet(processorAttr, attribute.String("reason", "stream_limit"))},
+			},
+		},
+	}, dropped, metricdatatest.IgnoreTimestamp())
+
+	// the dropped points are only reported at the debug level
+	require.Equal(t, 1, logs.Len())
+	require.Equal(t, zapcore.DebugLevel, logs.All()[0].Level)
+}
+
+func getMetric(t *testing.T, rm metricdata.ResourceMetrics, name string) metricdata.Metrics {
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == name {
+				return m
+			}
+		}
+	}
+	require.Failf(t, "metric not found", "metric %s was not recorded", name)
+	return metricdata.Metrics{}
+}
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package deltatocumulativeprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/data"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/delta"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/streams"
+)
+
+// The reasons for dropping a point, recorded in the reason attribute of the
+// datapoints.dropped metric.
+const (
+	reasonOutOfOrder  = "out_of_order"
+	reasonOlderStart  = "older_start"
+	reasonStreamLimit = "stream_limit"
+	reasonOther       = "other"
+)
+
+type telemetry struct {
+	processorAttr []attribute.KeyValue
+
+	datapointsProcessed metric.Int64Counter
+	datapointsDropped   metric.Int64Counter
+	streamsEvicted      metric.Int64Counter
+}
+
+func newTelemetry(set processor.CreateSettings) (*telemetry, error) {
+	tel := &telemetry{
+		processorAttr: []attribute.KeyValue{attribute.String("processor", set.ID.String())},
+	}
+	meter := metadata.Meter(set.TelemetrySettings)
+
+	var err error
+	tel.datapointsProcessed, err = meter.Int64Counter(
+		processorhelper.BuildCustomMetricName(metadata.Type.String(), "datapoints.processed"),
+		metric.WithDescription("Number of delta data points processed by the delta to cumulative processor"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	tel.datapointsDropped, err = meter.Int64Counter(
+		processorhelper.BuildCustomMetricName(metadata.Type.String(), "datapoints.dropped"),
+		metric.WithDescription("Number of delta data points dropped by the delta to cumulative processor, by reason"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	tel.streamsEvicted, err = meter.Int64Counter(
+		processorhelper.BuildCustomMetricName(metadata.Type.String(), "streams.evicted"),
+		metric.WithDescription("Number of streams evicted by the delta to cumulative processor to track new streams once max_streams is reached"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return tel, nil
+}
+
+// record records a point processed by an aggregator, and the outcome of its
+// aggregation.
+func (tel *telemetry) record(err error) {
+	ctx := context.Background()
+	tel.datapointsProcessed.Add(ctx, 1, metric.WithAttributes(tel.processorAttr...))
+	if err == nil {
+		return
+	}
+
+	var (
+		evicted    streams.ErrEvicted
+		limit      streams.ErrLimit
+		outOfOrder delta.ErrOutOfOrder
+		olderStart delta.ErrOlderStart
+	)
+	reason := reasonOther
+	switch {
+	case errors.As(err, &evicted):
+		// the point was aggregated, at the cost of another stream
+		tel.streamsEvicted.Add(ctx, 1, metric.WithAttributes(tel.processorAttr...))
+		return
+	case errors.As(err, &limit):
+		reason = reasonStreamLimit
+	case errors.As(err, &outOfOrder):
+		reason = reasonOutOfOrder
+	case errors.As(err, &olderStart):
+		reason = reasonOlderStart
+	}
+	tel.datapointsDropped.Add(ctx, 1, metric.WithAttributes(append(tel.processorAttr, attribute.String("reason", reason))...))
+}
+
+// observedAggregator records the outcome of each aggregation in the telemetry.
+type observedAggregator[D data.Point[D]] struct {
+	streams.Aggregator[D]
+	tel *telemetry
+}
+
+func (a observedAggregator[D]) Aggregate(id streams.Ident, dp D) (D, error) {
+	next, err := a.Aggregator.Aggregate(id, dp)
+	a.tel.record(err)
+	return next, err
+}
@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package intervalprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalprocessor"
+
+import (
+	"slices"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/expo"
+)
+
+// addNumber adds the value of the delta point src to dst.
+func addNumber(dst, src pmetric.NumberDataPoint) {
+	switch {
+	case dst.ValueType() == pmetric.NumberDataPointValueTypeInt && src.ValueType() == pmetric.NumberDataPointValueTypeInt:
+		dst.SetIntValue(dst.IntValue() + src.IntValue())
+	default:
+		dst.SetDoubleValue(numberAsDouble(dst) + numberAsDouble(src))
+	}
+	mergeTimestamps(dst, src)
+	src.Exemplars().MoveAndAppendTo(dst.Exemplars())
+}
+
+func numberAsDouble(dp pmetric.NumberDataPoint) float64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return float64(dp.IntValue())
+	}
+	return dp.DoubleValue()
+}
+
+// addHistogram adds the delta point src to dst. It returns false, leaving dst
+// unchanged, if the bounds of the buckets of the points differ.
+func addHistogram(dst, src pmetric.HistogramDataPoint) bool {
+	if !slices.Equal(dst.ExplicitBounds().AsRaw(), src.ExplicitBounds().AsRaw()) ||
+		dst.BucketCounts().Len() != src.BucketCounts().Len() {
+		return false
+	}
+
+	for i := 0; i < dst.BucketCounts().Len(); i++ {
+		dst.BucketCounts().SetAt(i, dst.BucketCounts().At(i)+src.BucketCounts().At(i))
+	}
+	dst.SetCount(dst.Count() + src.Count())
+
+	if dst.HasSum() && src.HasSum() {
+		dst.SetSum(dst.Sum() + src.Sum())
+	} else {
+		dst.RemoveSum()
+	}
+	if dst.HasMin() && src.HasMin() {
+		dst.SetMin(min(dst.Min(), src.Min()))
+	} else {
+		dst.RemoveMin()
+	}
+	if dst.HasMax() && src.HasMax() {
+		dst.SetMax(max(dst.Max(), src.Max()))
+	} else {
+		dst.RemoveMax()
+	}
+
+	mergeTimestamps(dst, src)
+	src.Exemplars().MoveAndAppendTo(dst.Exemplars())
+	return true
+}
+
+// addExpHistogram adds the delta point src to dst. The buckets of the point
+// with the higher scale are downscaled to the lower one, and the narrower zero
+// bucket is widened to the wider one.
+func addExpHistogram(dst, src pmetric.ExponentialHistogramDataPoint) {
+	if dst.Scale() != src.Scale() {
+		hi, lo := dst, src
+		if hi.Scale() < lo.Scale() {
+			hi, lo = lo, hi
+		}
+		from, to := expo.Scale(hi.Scale()), expo.Scale(lo.Scale())
+		expo.Downscale(hi.Positive(), from, to)
+		expo.Downscale(hi.Negative(), from, to)
+		hi.SetScale(lo.Scale())
+	}
+
+	// widening rounds the threshold up to a bucket boundary, so the wider zero
+	// bucket may have to be widened in turn.
+	if dst.ZeroThreshold() != src.ZeroThreshold() {
+		hi, lo := dst, src
+		if hi.ZeroThreshold() < lo.ZeroThreshold() {
+			hi, lo = lo, hi
+		}
+		expo.WidenZero(lo, hi.ZeroThreshold())
+		if lo.ZeroThreshold() > hi.ZeroThreshold() {
+			expo.WidenZero(hi, lo.ZeroThreshold())
+		}
+	}
+
+	expo.Merge(dst.Positive(), src.Positive())
+	expo.Merge(dst.Negative(), src.Negative())
+	dst.SetZeroCount(dst.ZeroCount() + src.ZeroCount())
+	dst.SetCount(dst.Count() + src.Count())
+
+	if dst.HasSum() && src.HasSum() {
+		dst.SetSum(dst.Sum() + src.Sum())
+	} else {
+		dst.RemoveSum()
+	}
+	if dst.HasMin() && src.HasMin() {
+		dst.SetMin(min(dst.Min(), src.Min()))
+	} else {
+		dst.RemoveMin()
+	}
+	if dst.HasMax() && src.HasMax() {
+		dst.SetMax(max(dst.Max(), src.Max()))
+	} else {
+		dst.RemoveMax()
+	}
+
+	mergeTimestamps(dst, src)
+	src.Exemplars().MoveAndAppendTo(dst.Exemplars())
+}
+
+type timestamped interface {
+	Timestamp() pcommon.Timestamp
+	SetTimestamp(pcommon.Timestamp)
+}
+
+// mergeTimestamps sets the timestamp of dst to the latest of the points. The start
+// timestamp of dst is kept, the sum of the points covering both of them.
+func mergeTimestamps[DP timestamped](dst, src DP) {
+	if src.Timestamp() > dst.Timestamp() {
+		dst.SetTimestamp(src.Timestamp())
+	}
+}
@@ -4,6 +4,7 @@
 package intervalprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalprocessor"
 
 import (
+	"fmt"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -13,6 +14,8 @@ var _ component.Config = (*Config)(nil)
 
 // Config defines the configuration for the processor.
 type Config struct {
+	// Interval is the time interval at which the processor exports the aggregated metrics.
+	Interval time.Duration `mapstructure:"interval"`
 	// MaxStaleness is the total time a state entry will live past the time it was last seen. Set to 0 to retain state indefinitely.
 	MaxStaleness time.Duration `mapstructure:"max_staleness"`
 }
@@ -20,5 +23,11 @@ type Config struct {
 // Validate checks whether the input configuration has all of the required fields for the processor.
 // An error is returned if there are any invalid inputs.
 func (config *Config) Validate() error {
+	if config.Interval <= 0 {
+		return fmt.Errorf("interval must be a positive duration (got %s)", config.Interval)
+	}
+	if config.MaxStaleness < 0 {
+		return fmt.Errorf("max_staleness must not be negative (got %s)", config.MaxStaleness)
+	}
 	return nil
 }
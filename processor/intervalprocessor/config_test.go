@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package intervalprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalprocessor"
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalprocessor/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id       component.ID
+		expected component.Config
+		err      string
+	}{
+		{
+			id: component.NewIDWithName(metadata.Type, "all"),
+			expected: &Config{
+				Interval:     30 * time.Second,
+				MaxStaleness: 10 * time.Minute,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "set-valid-interval"),
+			expected: &Config{
+				Interval: 15 * time.Second,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "set-valid-max_staleness"),
+			expected: &Config{
+				Interval:     60 * time.Second,
+				MaxStaleness: time.Hour,
+			},
+		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "invalid-interval"),
+			err: "interval must be a positive duration (got 0s)",
+		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "invalid-max_staleness"),
+			err: "max_staleness must not be negative (got -1m0s)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			if tt.err != "" {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.err)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
@@ -5,13 +5,19 @@ package intervalprocessor // import "github.com/open-telemetry/opentelemetry-col
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/processor"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/staleness"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/streams"
 )
 
 var _ processor.Metrics = (*Processor)(nil)
@@ -20,30 +26,80 @@ type Processor struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 	log    *zap.Logger
+	tel    *telemetry
+
+	interval time.Duration
 
-	maxStaleness time.Duration
+	mtx sync.Mutex
+	// streams holds the aggregated point of each stream, expired after
+	// max_staleness when stale is set.
+	streams streams.Map[*stream]
+	stale   *staleness.Staleness[*stream]
+	// resources and scopes hold the resources and scopes of the streams, to
+	// rebuild the metrics when they are exported.
+	resources map[identity.Resource]pmetric.ResourceMetrics
+	scopes    map[identity.Scope]pmetric.ScopeMetrics
 
 	nextConsumer consumer.Metrics
 }
 
-func newProcessor(config *Config, log *zap.Logger, nextConsumer consumer.Metrics) *Processor {
+// stream is the state of a stream of points.
+type stream struct {
+	// metric holds the metadata of the metric of the stream and its single
+	// aggregated point.
+	metric pmetric.Metric
+	// updated is true when a point was aggregated since the last export.
+	updated bool
+}
+
+func newProcessor(config *Config, set processor.CreateSettings, nextConsumer consumer.Metrics) (*Processor, error) {
+	tel, err := newTelemetry(set)
+	if err != nil {
+		return nil, err
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 
-	return &Processor{
+	p := &Processor{
 		ctx:          ctx,
 		cancel:       cancel,
-		log:          log,
-		maxStaleness: config.MaxStaleness,
+		log:          set.Logger,
+		tel:          tel,
+		interval:     config.Interval,
+		resources:    map[identity.Resource]pmetric.ResourceMetrics{},
+		scopes:       map[identity.Scope]pmetric.ScopeMetrics{},
 		nextConsumer: nextConsumer,
 	}
+
+	var items streams.Map[*stream] = streams.HashMap[*stream]{}
+	if config.MaxStaleness > 0 {
+		p.stale = staleness.NewStaleness(config.MaxStaleness, items)
+		items = p.stale
+	}
+	p.streams = items
+	return p, nil
 }
 
 func (p *Processor) Start(_ context.Context, _ component.Host) error {
+	go func() {
+		tick := time.NewTicker(p.interval)
+		defer tick.Stop()
+		for {
+			select {
+			case <-p.ctx.Done():
+				return
+			case <-tick.C:
+				p.exportMetrics(p.ctx)
+			}
+		}
+	}()
 	return nil
 }
 
-func (p *Processor) Shutdown(_ context.Context) error {
+// Shutdown stops the periodic export and exports the points aggregated since
+// the last one.
+func (p *Processor) Shutdown(ctx context.Context) error {
 	p.cancel()
+	p.exportMetrics(ctx)
 	return nil
 }
 
@@ -51,6 +107,241 @@ func (p *Processor) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: true}
 }
 
+// ConsumeMetrics aggregates the points of the sums, histograms and exponential
+// histograms, and passes the other metrics to the next consumer.
 func (p *Processor) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	p.mtx.Lock()
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		resID := identity.OfResource(rm.Resource())
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			scopeID := identity.OfScope(resID, sm.Scope())
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				if !isAggregated(m) {
+					return false
+				}
+				p.trackScope(resID, rm, scopeID, sm)
+				p.aggregateMetric(identity.OfMetric(scopeID, m), m)
+				return true
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	p.mtx.Unlock()
+
+	if md.ResourceMetrics().Len() == 0 {
+		return nil
+	}
 	return p.nextConsumer.ConsumeMetrics(ctx, md)
 }
+
+// isAggregated returns whether the points of the metric are aggregated by the
+// processor rather than passed to the next consumer.
+func isAggregated(m pmetric.Metric) bool {
+	switch m.Type() {
+	case pmetric.MetricTypeSum:
+		return m.Sum().AggregationTemporality() != pmetric.AggregationTemporalityUnspecified
+	case pmetric.MetricTypeHistogram:
+		return m.Histogram().AggregationTemporality() != pmetric.AggregationTemporalityUnspecified
+	case pmetric.MetricTypeExponentialHistogram:
+		return m.ExponentialHistogram().AggregationTemporality() != pmetric.AggregationTemporalityUnspecified
+	}
+	return false
+}
+
+// trackScope records the resource and the scope of a metric, if they are not
+// known yet.
+func (p *Processor) trackScope(resID identity.Resource, rm pmetric.ResourceMetrics, scopeID identity.Scope, sm pmetric.ScopeMetrics) {
+	if _, ok := p.resources[resID]; !ok {
+		res := pmetric.NewResourceMetrics()
+		rm.Resource().CopyTo(res.Resource())
+		res.SetSchemaUrl(rm.SchemaUrl())
+		p.resources[resID] = res
+	}
+	if _, ok := p.scopes[scopeID]; !ok {
+		scope := pmetric.NewScopeMetrics()
+		sm.Scope().CopyTo(scope.Scope())
+		scope.SetSchemaUrl(sm.SchemaUrl())
+		p.scopes[scopeID] = scope
+	}
+}
+
+// aggregateMetric aggregates each point of the metric into its stream. The
+// points of the delta metrics are added up, while those of the cumulative
+// metrics replace the older points of their streams.
+func (p *Processor) aggregateMetric(metricID identity.Metric, m pmetric.Metric) {
+	switch m.Type() {
+	case pmetric.MetricTypeSum:
+		delta := m.Sum().AggregationTemporality() == pmetric.AggregationTemporalityDelta
+		aggregatePoints(p, metricID, m, m.Sum().DataPoints(), sumPoints, delta, func(dst, src pmetric.NumberDataPoint) bool {
+			addNumber(dst, src)
+			return true
+		})
+	case pmetric.MetricTypeHistogram:
+		delta := m.Histogram().AggregationTemporality() == pmetric.AggregationTemporalityDelta
+		aggregatePoints(p, metricID, m, m.Histogram().DataPoints(), histogramPoints, delta, addHistogram)
+	case pmetric.MetricTypeExponentialHistogram:
+		delta := m.ExponentialHistogram().AggregationTemporality() == pmetric.AggregationTemporalityDelta
+		aggregatePoints(p, metricID, m, m.ExponentialHistogram().DataPoints(), expHistogramPoints, delta, func(dst, src pmetric.ExponentialHistogramDataPoint) bool {
+			addExpHistogram(dst, src)
+			return true
+		})
+	}
+}
+
+type dataPoint[Self any] interface {
+	Attributes() pcommon.Map
+	Timestamp() pcommon.Timestamp
+	CopyTo(Self)
+}
+
+type dataPoints[DP any] interface {
+	Len() int
+	At(int) DP
+	AppendEmpty() DP
+}
+
+// aggregatePoints aggregates the points into their streams. The points of a
+// delta metric are added to the point of their stream with the add function,
+// which returns false if it cannot add them. The points of a cumulative metric
+// replace the point of their stream if they are newer. So do the delta points
+// which cannot be added, resetting the sum of the interval, which is logged and
+// counted.
+func aggregatePoints[DP dataPoint[DP], DPS dataPoints[DP]](p *Processor, metricID identity.Metric, m pmetric.Metric, dps DPS, points func(pmetric.Metric) DPS, delta bool, add func(dst, src DP) bool) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		id := identity.OfStream(metricID, dp)
+
+		s, ok := p.streams.Load(id)
+		switch {
+		case !ok:
+			s = &stream{metric: newMetric(m)}
+			dp.CopyTo(points(s.metric).AppendEmpty())
+		case delta && !s.updated:
+			// The point of the stream was exported already, the point starts
+			// the sum of the next interval.
+			dp.CopyTo(points(s.metric).At(0))
+		case delta && add(points(s.metric).At(0), dp):
+		case delta:
+			p.tel.recordReset()
+			p.log.Debug("Delta point could not be added to the sum of its stream", zap.String("metric", m.Name()))
+			if dp.Timestamp() <= points(s.metric).At(0).Timestamp() {
+				continue
+			}
+			dp.CopyTo(points(s.metric).At(0))
+		case dp.Timestamp() > points(s.metric).At(0).Timestamp():
+			dp.CopyTo(points(s.metric).At(0))
+		default:
+			// Drop the points older than the point of the stream.
+			continue
+		}
+		s.updated = true
+		// Storing the stream again refreshes its staleness.
+		_ = p.streams.Store(id, s)
+	}
+}
+
+func sumPoints(m pmetric.Metric) pmetric.NumberDataPointSlice {
+	return m.Sum().DataPoints()
+}
+
+func histogramPoints(m pmetric.Metric) pmetric.HistogramDataPointSlice {
+	return m.Histogram().DataPoints()
+}
+
+func expHistogramPoints(m pmetric.Metric) pmetric.ExponentialHistogramDataPointSlice {
+	return m.ExponentialHistogram().DataPoints()
+}
+
+// newMetric returns a metric with the metadata of m and no points.
+func newMetric(m pmetric.Metric) pmetric.Metric {
+	out := pmetric.NewMetric()
+	out.SetName(m.Name())
+	out.SetDescription(m.Description())
+	out.SetUnit(m.Unit())
+	switch m.Type() {
+	case pmetric.MetricTypeSum:
+		sum := out.SetEmptySum()
+		sum.SetAggregationTemporality(m.Sum().AggregationTemporality())
+		sum.SetIsMonotonic(m.Sum().IsMonotonic())
+	case pmetric.MetricTypeHistogram:
+		out.SetEmptyHistogram().SetAggregationTemporality(m.Histogram().AggregationTemporality())
+	case pmetric.MetricTypeExponentialHistogram:
+		out.SetEmptyExponentialHistogram().SetAggregationTemporality(m.ExponentialHistogram().AggregationTemporality())
+	}
+	return out
+}
+
+// exportMetrics expires the stale streams and sends the point of each stream
+// updated since the last export to the next consumer.
+func (p *Processor) exportMetrics(ctx context.Context) {
+	md := p.collectMetrics()
+	if md.ResourceMetrics().Len() == 0 {
+		return
+	}
+	if err := p.nextConsumer.ConsumeMetrics(ctx, md); err != nil {
+		p.log.Error("Failed to export the aggregated metrics", zap.Error(err))
+	}
+}
+
+func (p *Processor) collectMetrics() pmetric.Metrics {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.stale != nil {
+		p.stale.ExpireOldEntries()
+	}
+
+	md := pmetric.NewMetrics()
+	rms := map[identity.Resource]pmetric.ResourceMetrics{}
+	sms := map[identity.Scope]pmetric.ScopeMetrics{}
+	ms := map[identity.Metric]pmetric.Metric{}
+	// The resources and scopes of the expired streams are forgotten.
+	resources := map[identity.Resource]pmetric.ResourceMetrics{}
+	scopes := map[identity.Scope]pmetric.ScopeMetrics{}
+
+	p.streams.Items()(func(id identity.Stream, s *stream) bool {
+		metricID := id.Metric()
+		scopeID := metricID.Scope()
+		resID := scopeID.Resource()
+		resources[resID] = p.resources[resID]
+		scopes[scopeID] = p.scopes[scopeID]
+		if !s.updated {
+			return true
+		}
+		s.updated = false
+
+		m, ok := ms[metricID]
+		if !ok {
+			sm, ok := sms[scopeID]
+			if !ok {
+				rm, ok := rms[resID]
+				if !ok {
+					rm = md.ResourceMetrics().AppendEmpty()
+					p.resources[resID].CopyTo(rm)
+					rms[resID] = rm
+				}
+				sm = rm.ScopeMetrics().AppendEmpty()
+				p.scopes[scopeID].CopyTo(sm)
+				sms[scopeID] = sm
+			}
+			m = sm.Metrics().AppendEmpty()
+			newMetric(s.metric).CopyTo(m)
+			ms[metricID] = m
+		}
+
+		switch m.Type() {
+		case pmetric.MetricTypeSum:
+			s.metric.Sum().DataPoints().At(0).CopyTo(m.Sum().DataPoints().AppendEmpty())
+		case pmetric.MetricTypeHistogram:
+			s.metric.Histogram().DataPoints().At(0).CopyTo(m.Histogram().DataPoints().AppendEmpty())
+		case pmetric.MetricTypeExponentialHistogram:
+			s.metric.ExponentialHistogram().DataPoints().At(0).CopyTo(m.ExponentialHistogram().DataPoints().AppendEmpty())
+		}
+		return true
+	})
+
+	p.resources = resources
+	p.scopes = scopes
+	return md
+}
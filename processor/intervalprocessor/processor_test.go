@@ -0,0 +1,395 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package intervalprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/staleness"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalprocessor/internal/metadata"
+)
+
+func newTestProcessor(maxStaleness time.Duration) (*Processor, *consumertest.MetricsSink) {
+	sink := new(consumertest.MetricsSink)
+	p, err := newProcessor(&Config{Interval: time.Minute, MaxStaleness: maxStaleness}, processortest.NewNopCreateSettings(), sink)
+	if err != nil {
+		panic(err)
+	}
+	return p, sink
+}
+
+// newMetrics returns metrics holding a single metric in a resource with the
+// given attribute.
+func newMetrics(resource string) (pmetric.Metrics, pmetric.Metric) {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", resource)
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("scope")
+	return md, sm.Metrics().AppendEmpty()
+}
+
+func sumMetrics(temporality pmetric.AggregationTemporality, points ...sumPoint) pmetric.Metrics {
+	md, m := newMetrics("svc")
+	m.SetName("requests")
+	sum := m.SetEmptySum()
+	sum.SetAggregationTemporality(temporality)
+	sum.SetIsMonotonic(true)
+	for _, p := range points {
+		dp := sum.DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("path", p.path)
+		dp.SetStartTimestamp(pcommon.Timestamp(p.start))
+		dp.SetTimestamp(pcommon.Timestamp(p.ts))
+		dp.SetIntValue(p.value)
+	}
+	return md
+}
+
+type sumPoint struct {
+	path      string
+	start, ts int64
+	value     int64
+}
+
+// sumValues returns the values of the points of the exported sums by path.
+func sumValues(t *testing.T, sink *consumertest.MetricsSink) map[string]int64 {
+	values := map[string]int64{}
+	for _, md := range sink.AllMetrics() {
+		for i := 0; i < md.ResourceMetrics().Len(); i++ {
+			sms := md.ResourceMetrics().At(i).ScopeMetrics()
+			for j := 0; j < sms.Len(); j++ {
+				ms := sms.At(j).Metrics()
+				for k := 0; k < ms.Len(); k++ {
+					dps := ms.At(k).Sum().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						path, ok := dps.At(l).Attributes().Get("path")
+						require.True(t, ok)
+						values[path.Str()] = dps.At(l).IntValue()
+					}
+				}
+			}
+		}
+	}
+	return values
+}
+
+func TestCumulativeSumKeepsLatestPoint(t *testing.T) {
+	p, sink := newTestProcessor(0)
+	ctx := context.Background()
+
+	require.NoError(t, p.ConsumeMetrics(ctx, sumMetrics(pmetric.AggregationTemporalityCumulative,
+		sumPoint{path: "/a", start: 1, ts: 10, value: 1},
+		sumPoint{path: "/b", start: 1, ts: 10, value: 5},
+	)))
+	require.NoError(t, p.ConsumeMetrics(ctx, sumMetrics(pmetric.AggregationTemporalityCumulative,
+		sumPoint{path: "/a", start: 1, ts: 20, value: 3},
+		// Older than the point of the stream, dropped.
+		sumPoint{path: "/b", start: 1, ts: 5, value: 4},
+	)))
+	assert.Zero(t, sink.DataPointCount(), "the aggregated points must not be passed through")
+
+	p.exportMetrics(ctx)
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, 2, sink.DataPointCount())
+	assert.Equal(t, map[string]int64{"/a": 3, "/b": 5}, sumValues(t, sink))
+
+	md := sink.AllMetrics()[0]
+	rm := md.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]any{"service.name": "svc"}, rm.Resource().Attributes().AsRaw())
+	assert.Equal(t, "scope", rm.ScopeMetrics().At(0).Scope().Name())
+	m := rm.ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "requests", m.Name())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, m.Sum().AggregationTemporality())
+	assert.True(t, m.Sum().IsMonotonic())
+
+	// Only the streams updated since the last export are exported.
+	sink.Reset()
+	p.exportMetrics(ctx)
+	assert.Empty(t, sink.AllMetrics())
+
+	require.NoError(t, p.ConsumeMetrics(ctx, sumMetrics(pmetric.AggregationTemporalityCumulative,
+		sumPoint{path: "/a", start: 1, ts: 15, value: 2},
+		sumPoint{path: "/b", start: 1, ts: 30, value: 8},
+	)))
+	p.exportMetrics(ctx)
+	assert.Equal(t, map[string]int64{"/b": 8}, sumValues(t, sink))
+}
+
+func TestDeltaSumIsSummedPerInterval(t *testing.T) {
+	p, sink := newTestProcessor(0)
+	ctx := context.Background()
+
+	require.NoError(t, p.ConsumeMetrics(ctx, sumMetrics(pmetric.AggregationTemporalityDelta,
+		sumPoint{path: "/a", start: 0, ts: 10, value: 1},
+		sumPoint{path: "/a", start: 10, ts: 20, value: 2},
+	)))
+	require.NoError(t, p.ConsumeMetrics(ctx, sumMetrics(pmetric.AggregationTemporalityDelta,
+		sumPoint{path: "/a", start: 20, ts: 30, value: 4},
+	)))
+	p.exportMetrics(ctx)
+	require.Equal(t, 1, sink.DataPointCount())
+	dp := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	assert.Equal(t, int64(7), dp.IntValue())
+	assert.Equal(t, pcommon.Timestamp(0), dp.StartTimestamp())
+	assert.Equal(t, pcommon.Timestamp(30), dp.Timestamp())
+
+	// The next interval starts a new sum.
+	sink.Reset()
+	require.NoError(t, p.ConsumeMetrics(ctx, sumMetrics(pmetric.AggregationTemporalityDelta,
+		sumPoint{path: "/a", start: 30, ts: 40, value: 10},
+	)))
+	p.exportMetrics(ctx)
+	assert.Equal(t, map[string]int64{"/a": 10}, sumValues(t, sink))
+}
+
+func TestHistograms(t *testing.T) {
+	p, sink := newTestProcessor(0)
+	ctx := context.Background()
+
+	histograms := func(temporality pmetric.AggregationTemporality, ts int64, counts ...uint64) pmetric.Metrics {
+		md, m := newMetrics("svc")
+		m.SetName("latency")
+		hist := m.SetEmptyHistogram()
+		hist.SetAggregationTemporality(temporality)
+		dp := hist.DataPoints().AppendEmpty()
+		dp.SetTimestamp(pcommon.Timestamp(ts))
+		dp.ExplicitBounds().FromRaw([]float64{1, 10})
+		dp.BucketCounts().FromRaw(counts)
+		var count uint64
+		for _, c := range counts {
+			count += c
+		}
+		dp.SetCount(count)
+		dp.SetSum(float64(count))
+		dp.SetMin(1)
+		dp.SetMax(float64(ts))
+		return md
+	}
+
+	require.NoError(t, p.ConsumeMetrics(ctx, histograms(pmetric.AggregationTemporalityDelta, 10, 1, 2, 3)))
+	require.NoError(t, p.ConsumeMetrics(ctx, histograms(pmetric.AggregationTemporalityDelta, 20, 1, 0, 1)))
+	require.NoError(t, p.ConsumeMetrics(ctx, histograms(pmetric.AggregationTemporalityCumulative, 10, 1, 1, 1)))
+	require.NoError(t, p.ConsumeMetrics(ctx, histograms(pmetric.AggregationTemporalityCumulative, 20, 2, 2, 2)))
+	p.exportMetrics(ctx)
+	require.Equal(t, 2, sink.DataPointCount())
+
+	ms := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		hist := ms.At(i).Histogram()
+		dp := hist.DataPoints().At(0)
+		assert.Equal(t, pcommon.Timestamp(20), dp.Timestamp())
+		assert.Equal(t, 20.0, dp.Max())
+		switch hist.AggregationTemporality() {
+		case pmetric.AggregationTemporalityDelta:
+			assert.Equal(t, []uint64{2, 2, 4}, dp.BucketCounts().AsRaw())
+			assert.Equal(t, uint64(8), dp.Count())
+			assert.Equal(t, 8.0, dp.Sum())
+		case pmetric.AggregationTemporalityCumulative:
+			assert.Equal(t, []uint64{2, 2, 2}, dp.BucketCounts().AsRaw())
+			assert.Equal(t, uint64(6), dp.Count())
+		}
+	}
+}
+
+func TestDeltaExponentialHistogramsAreDownscaled(t *testing.T) {
+	p, sink := newTestProcessor(0)
+	ctx := context.Background()
+
+	expHistogram := func(ts int64, scale int32, offset int32, counts ...uint64) pmetric.Metrics {
+		md, m := newMetrics("svc")
+		m.SetName("sizes")
+		hist := m.SetEmptyExponentialHistogram()
+		hist.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		dp := hist.DataPoints().AppendEmpty()
+		dp.SetTimestamp(pcommon.Timestamp(ts))
+		dp.SetScale(scale)
+		dp.SetZeroCount(1)
+		dp.Positive().SetOffset(offset)
+		dp.Positive().BucketCounts().FromRaw(counts)
+		count := dp.ZeroCount()
+		for _, c := range counts {
+			count += c
+		}
+		dp.SetCount(count)
+		return md
+	}
+
+	// Buckets 2 to 5 at scale 1 are buckets 1 and 2 at scale 0.
+	require.NoError(t, p.ConsumeMetrics(ctx, expHistogram(10, 1, 2, 1, 2, 3, 4)))
+	require.NoError(t, p.ConsumeMetrics(ctx, expHistogram(20, 0, 0, 5, 0, 1)))
+	p.exportMetrics(ctx)
+	require.Equal(t, 1, sink.DataPointCount())
+
+	dp := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).ExponentialHistogram().DataPoints().At(0)
+	assert.Equal(t, int32(0), dp.Scale())
+	assert.Equal(t, int32(0), dp.Positive().Offset())
+	assert.Equal(t, []uint64{5, 3, 8}, dp.Positive().BucketCounts().AsRaw())
+	assert.Equal(t, uint64(2), dp.ZeroCount())
+	assert.Equal(t, uint64(18), dp.Count())
+	assert.Equal(t, pcommon.Timestamp(20), dp.Timestamp())
+}
+
+func TestDeltaExponentialHistogramsWidenZero(t *testing.T) {
+	p, sink := newTestProcessor(0)
+	ctx := context.Background()
+
+	expHistogram := func(ts int64, zeroThreshold float64, offset int32, counts ...uint64) pmetric.Metrics {
+		md, m := newMetrics("svc")
+		m.SetName("sizes")
+		hist := m.SetEmptyExponentialHistogram()
+		hist.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		dp := hist.DataPoints().AppendEmpty()
+		dp.SetTimestamp(pcommon.Timestamp(ts))
+		dp.SetZeroThreshold(zeroThreshold)
+		dp.SetZeroCount(1)
+		dp.Positive().SetOffset(offset)
+		dp.Positive().BucketCounts().FromRaw(counts)
+		return md
+	}
+
+	// The bucket 0, (1, 2], is below the zero threshold of the second point.
+	require.NoError(t, p.ConsumeMetrics(ctx, expHistogram(10, 0, 0, 5, 0, 1)))
+	require.NoError(t, p.ConsumeMetrics(ctx, expHistogram(20, 2, 1, 2)))
+	p.exportMetrics(ctx)
+	require.Equal(t, 1, sink.DataPointCount())
+
+	dp := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).ExponentialHistogram().DataPoints().At(0)
+	assert.Equal(t, 2.0, dp.ZeroThreshold())
+	assert.Equal(t, uint64(7), dp.ZeroCount())
+	assert.Equal(t, int32(1), dp.Positive().Offset())
+	assert.Equal(t, []uint64{2, 1}, dp.Positive().BucketCounts().AsRaw())
+}
+
+func TestDeltaHistogramsWithOtherBoundsReset(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	core, logs := observer.New(zapcore.DebugLevel)
+	set := processortest.NewNopCreateSettings()
+	set.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	set.Logger = zap.New(core)
+	set.ID = component.NewID(metadata.Type)
+	sink := new(consumertest.MetricsSink)
+	p, err := newProcessor(&Config{Interval: time.Minute}, set, sink)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	histogram := func(ts int64, bounds []float64, counts ...uint64) pmetric.Metrics {
+		md, m := newMetrics("svc")
+		m.SetName("latency")
+		hist := m.SetEmptyHistogram()
+		hist.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		dp := hist.DataPoints().AppendEmpty()
+		dp.SetTimestamp(pcommon.Timestamp(ts))
+		dp.ExplicitBounds().FromRaw(bounds)
+		dp.BucketCounts().FromRaw(counts)
+		return md
+	}
+
+	require.NoError(t, p.ConsumeMetrics(ctx, histogram(10, []float64{1, 10}, 1, 2, 3)))
+	require.NoError(t, p.ConsumeMetrics(ctx, histogram(20, []float64{5}, 4, 5)))
+	// older than the point of the stream, dropped
+	require.NoError(t, p.ConsumeMetrics(ctx, histogram(15, []float64{1, 10}, 1, 1, 1)))
+	p.exportMetrics(ctx)
+
+	dp := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram().DataPoints().At(0)
+	assert.Equal(t, []float64{5}, dp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{4, 5}, dp.BucketCounts().AsRaw())
+	assert.Equal(t, pcommon.Timestamp(20), dp.Timestamp())
+
+	assert.Equal(t, 2, logs.FilterMessage("Delta point could not be added to the sum of its stream").FilterField(zap.String("metric", "latency")).Len())
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+	metricdatatest.AssertEqual(t, metricdata.Metrics{
+		Name:        "processor/interval/datapoints.reset",
+		Description: "Number of delta data points which could not be added to the point aggregated for their stream by the interval processor, resetting its sum",
+		Unit:        "1",
+		Data: metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+			DataPoints: []metricdata.DataPoint[int64]{
+				{Value: 2, Attributes: attribute.NewSet(attribute.String("processor", "interval"))},
+			},
+		},
+	}, rm.ScopeMetrics[0].Metrics[0], metricdatatest.IgnoreTimestamp())
+}
+
+func TestOtherMetricsArePassedThrough(t *testing.T) {
+	p, sink := newTestProcessor(0)
+	ctx := context.Background()
+
+	md, m := newMetrics("svc")
+	m.SetName("temperature")
+	m.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(21.5)
+	sum := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().AppendEmpty()
+	sum.SetName("requests")
+	sum.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	sum.Sum().DataPoints().AppendEmpty().SetIntValue(1)
+
+	require.NoError(t, p.ConsumeMetrics(ctx, md))
+	require.Len(t, sink.AllMetrics(), 1)
+	ms := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 1, ms.Len())
+	assert.Equal(t, "temperature", ms.At(0).Name())
+}
+
+func TestStaleStreamsExpire(t *testing.T) {
+	now := time.Now()
+	staleness.NowFunc = func() time.Time { return now }
+	t.Cleanup(func() { staleness.NowFunc = time.Now })
+
+	p, sink := newTestProcessor(time.Hour)
+	ctx := context.Background()
+
+	require.NoError(t, p.ConsumeMetrics(ctx, sumMetrics(pmetric.AggregationTemporalityCumulative,
+		sumPoint{path: "/a", ts: 20, value: 1},
+	)))
+	p.exportMetrics(ctx)
+	assert.Equal(t, 1, sink.DataPointCount())
+	assert.Equal(t, 1, p.streams.Len())
+
+	now = now.Add(2 * time.Hour)
+	p.exportMetrics(ctx)
+	assert.Zero(t, p.streams.Len())
+	assert.Empty(t, p.resources)
+	assert.Empty(t, p.scopes)
+
+	// Once expired, an older point starts the stream again.
+	sink.Reset()
+	require.NoError(t, p.ConsumeMetrics(ctx, sumMetrics(pmetric.AggregationTemporalityCumulative,
+		sumPoint{path: "/a", ts: 10, value: 1},
+	)))
+	p.exportMetrics(ctx)
+	assert.Equal(t, map[string]int64{"/a": 1}, sumValues(t, sink))
+}
+
+func TestShutdownExportsAggregatedPoints(t *testing.T) {
+	p, sink := newTestProcessor(0)
+	ctx := context.Background()
+
+	require.NoError(t, p.Start(ctx, nil))
+	require.NoError(t, p.ConsumeMetrics(ctx, sumMetrics(pmetric.AggregationTemporalityDelta,
+		sumPoint{path: "/a", ts: 10, value: 2},
+	)))
+	require.NoError(t, p.Shutdown(ctx))
+	assert.Equal(t, map[string]int64{"/a": 2}, sumValues(t, sink))
+}
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package intervalprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalprocessor"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalprocessor/internal/metadata"
+)
+
+type telemetry struct {
+	processorAttr []attribute.KeyValue
+
+	datapointsReset metric.Int64Counter
+}
+
+func newTelemetry(set processor.CreateSettings) (*telemetry, error) {
+	tel := &telemetry{
+		processorAttr: []attribute.KeyValue{attribute.String("processor", set.ID.String())},
+	}
+	meter := metadata.Meter(set.TelemetrySettings)
+
+	var err error
+	tel.datapointsReset, err = meter.Int64Counter(
+		processorhelper.BuildCustomMetricName(metadata.Type.String(), "datapoints.reset"),
+		metric.WithDescription("Number of delta data points which could not be added to the point aggregated for their stream by the interval processor, resetting its sum"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return tel, nil
+}
+
+// recordReset records a delta point which could not be added to the point of
+// its stream.
+func (tel *telemetry) recordReset() {
+	tel.datapointsReset.Add(context.Background(), 1, metric.WithAttributes(tel.processorAttr...))
+}
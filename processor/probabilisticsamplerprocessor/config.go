@@ -5,8 +5,11 @@ package probabilisticsamplerprocessor // import "github.com/open-telemetry/opent
 
 import (
 	"fmt"
+	"math"
 
 	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
 )
 
 type AttributeSource string
@@ -35,6 +38,42 @@ type Config struct {
 	// different sampling rates, configuring different seeds avoids that.
 	HashSeed uint32 `mapstructure:"hash_seed"`
 
+	// Mode selects the sampling behavior. Supported values:
+	//
+	// - "hash_seed": the legacy behavior of this processor.
+	//   Using an FNV hash combined with the HashSeed value, this
+	//   sampler performs a non-consistent probabilistic
+	//   downsampling.  The number of spans output is expected to
+	//   equal SamplingPercentage (as a ratio) times the number of
+	//   spans input.  The OpenTelemetry tracestate is not modified.
+	//
+	// - "equalizing": Using an OTel-specified consistent sampling
+	//   mechanism, this sampler selectively reduces the effective
+	//   sampling probability of arriving spans.  This can be
+	//   useful to select a small fraction of complete traces from
+	//   a stream with mixed sampling rates.  The rate of spans
+	//   passing through depends on how much sampling has already
+	//   been applied.  If an arriving span was already sampled
+	//   at the same or a lower probability it passes through
+	//   unchanged.
+	//
+	// - "proportional": Using an OTel-specified consistent sampling
+	//   mechanism, this sampler reduces the effective sampling
+	//   probability of each span by `SamplingPercentage`.
+	//
+	// The two consistent modes read the `th` and `rv` values of
+	// the OpenTelemetry tracestate and record the new threshold
+	// of sampled spans there, so that adjusted counts remain
+	// correct across several sampling stages.  They apply to
+	// traces only; logs are always sampled in "hash_seed" mode.
+	Mode SamplerMode `mapstructure:"mode"`
+
+	// SamplingPrecision is the number of hex digits used to
+	// encode the sampling threshold written to the tracestate in
+	// the "equalizing" and "proportional" modes.  Allowed values
+	// are 1 through 14.  Defaults to 4.
+	SamplingPrecision int `mapstructure:"sampling_precision"`
+
 	// AttributeSource (logs only) defines where to look for the attribute in from_attribute. The allowed values are
 	// `traceID` or `record`. Default is `traceID`.
 	AttributeSource `mapstructure:"attribute_source"`
@@ -57,5 +96,20 @@ func (cfg *Config) Validate() error {
 	if cfg.AttributeSource != "" && !validAttributeSource[cfg.AttributeSource] {
 		return fmt.Errorf("invalid attribute source: %v. Expected: %v or %v", cfg.AttributeSource, traceIDAttributeSource, recordAttributeSource)
 	}
+
+	switch cfg.Mode {
+	case modeUnset, HashSeed:
+		return nil
+	case Equalizing, Proportional:
+	default:
+		return fmt.Errorf("invalid sampler mode: %v. Expected: %v, %v or %v", cfg.Mode, HashSeed, Equalizing, Proportional)
+	}
+
+	if cfg.SamplingPrecision < 1 || cfg.SamplingPrecision > sampling.NumHexDigits {
+		return fmt.Errorf("invalid sampling precision: %d. Expected a value between 1 and %d", cfg.SamplingPrecision, sampling.NumHexDigits)
+	}
+	if ratio := math.Min(float64(cfg.SamplingPercentage), 100) / 100; ratio != 0 && ratio < sampling.MinSamplingProbability {
+		return fmt.Errorf("sampling rate is too small: %g%%", cfg.SamplingPercentage)
+	}
 	return nil
 }
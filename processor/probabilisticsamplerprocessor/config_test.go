@@ -27,6 +27,26 @@ func TestLoadConfig(t *testing.T) {
 			expected: &Config{
 				SamplingPercentage: 15.3,
 				HashSeed:           22,
+				Mode:               "hash_seed",
+				SamplingPrecision:  defaultPrecision,
+				AttributeSource:    "traceID",
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "equalizing"),
+			expected: &Config{
+				SamplingPercentage: 25,
+				Mode:               "equalizing",
+				SamplingPrecision:  defaultPrecision,
+				AttributeSource:    "traceID",
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "proportional"),
+			expected: &Config{
+				SamplingPercentage: 10,
+				Mode:               "proportional",
+				SamplingPrecision:  6,
 				AttributeSource:    "traceID",
 			},
 		},
@@ -35,6 +55,8 @@ func TestLoadConfig(t *testing.T) {
 			expected: &Config{
 				SamplingPercentage: 15.3,
 				HashSeed:           22,
+				Mode:               "hash_seed",
+				SamplingPrecision:  defaultPrecision,
 				AttributeSource:    "record",
 				FromAttribute:      "foo",
 				SamplingPriority:   "bar",
@@ -63,12 +85,24 @@ func TestLoadConfig(t *testing.T) {
 }
 
 func TestLoadInvalidConfig(t *testing.T) {
-	factories, err := otelcoltest.NopFactories()
-	require.NoError(t, err)
+	for _, test := range []struct {
+		file     string
+		contains string
+	}{
+		{"invalid.yaml", "negative sampling rate: -15.30"},
+		{"invalid_mode.yaml", "invalid sampler mode: consistent"},
+		{"invalid_precision.yaml", "invalid sampling precision: 15"},
+		{"invalid_small.yaml", "sampling rate is too small"},
+	} {
+		t.Run(test.file, func(t *testing.T) {
+			factories, err := otelcoltest.NopFactories()
+			require.NoError(t, err)
 
-	factory := NewFactory()
-	factories.Processors[metadata.Type] = factory
+			factory := NewFactory()
+			factories.Processors[metadata.Type] = factory
 
-	_, err = otelcoltest.LoadConfigAndValidate(filepath.Join("testdata", "invalid.yaml"), factories)
-	require.ErrorContains(t, err, "negative sampling rate: -15.30")
+			_, err = otelcoltest.LoadConfigAndValidate(filepath.Join("testdata", test.file), factories)
+			require.ErrorContains(t, err, test.contains)
+		})
+	}
 }
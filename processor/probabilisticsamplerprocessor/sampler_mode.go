@@ -0,0 +1,342 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package probabilisticsamplerprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor"
+
+import (
+	"errors"
+	"math"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
+)
+
+// SamplerMode determines which of several modes is used for the
+// sampling decision.
+type SamplerMode string
+
+const (
+	// HashSeed applies the hash/fnv hash function originally used
+	// in this component.
+	HashSeed SamplerMode = "hash_seed"
+
+	// Equalizing uses OpenTelemetry consistent probability
+	// sampling information (OTEP 235), applies an absolute
+	// threshold to equalize incoming sampling probabilities.
+	Equalizing SamplerMode = "equalizing"
+
+	// Proportional uses OpenTelemetry consistent probability
+	// sampling information (OTEP 235), multiplies incoming
+	// sampling probabilities.
+	Proportional SamplerMode = "proportional"
+
+	// DefaultMode is applied when the mode is unset.
+	DefaultMode SamplerMode = HashSeed
+
+	// modeUnset indicates the user has not configured the mode.
+	modeUnset SamplerMode = ""
+
+	// defaultPrecision is the number of hex digits used to encode
+	// thresholds in the consistent sampling modes.
+	defaultPrecision = 4
+
+	// numHashBucketsLg2 is the base-2 logarithm of numHashBuckets.
+	numHashBucketsLg2 = 14
+)
+
+// Policy names used to tag the sampling metrics, they describe
+// where the randomness used in a sampling decision came from.
+const (
+	traceIDHashPolicy        = "trace_id_hash"
+	traceIDW3CPolicy         = "trace_id_w3c"
+	samplingRandomnessPolicy = "sampling_randomness"
+	missingRandomnessPolicy  = "missing_randomness"
+)
+
+var (
+	// errMissingRandomness is returned when a span has neither a
+	// trace ID nor an explicit r-value.
+	errMissingRandomness = errors.New("missing randomness")
+
+	// errInconsistentArrivingTValue is returned when a span arrives
+	// with a threshold its randomness does not satisfy.
+	errInconsistentArrivingTValue = errors.New("inconsistent arriving threshold: item should not have been sampled")
+)
+
+// randomnessSource is the randomness used in a sampling decision
+// together with the name of the policy that produced it.
+type randomnessSource struct {
+	randomness sampling.Randomness
+	policy     string
+}
+
+// samplingCarrier conveys the sampling information of a span
+// through the sampling decision.
+type samplingCarrier interface {
+	// threshold returns the arriving threshold, if any.
+	threshold() (sampling.Threshold, bool)
+	// explicitRandomness returns the arriving r-value, if any.
+	explicitRandomness() (sampling.Randomness, bool)
+	// updateThreshold records a new threshold, it fails with
+	// sampling.ErrInconsistentSampling when this would raise the
+	// sampling probability.
+	updateThreshold(sampling.Threshold) error
+	// clearThreshold removes the arriving threshold.
+	clearThreshold()
+	// reserialize writes the sampling information back into the span.
+	reserialize() error
+}
+
+// dataSampler implements the sampling decision of one SamplerMode.
+type dataSampler interface {
+	// decide returns the threshold to apply given the arriving
+	// sampling information.  The carrier is nil in the legacy
+	// hash_seed mode.
+	decide(carrier samplingCarrier) sampling.Threshold
+
+	// randomnessFromSpan returns the randomness of a span and the
+	// carrier of its sampling information.
+	randomnessFromSpan(s ptrace.Span) (randomnessSource, samplingCarrier, error)
+}
+
+// tracestateCarrier is the samplingCarrier of a span, backed by the
+// W3C tracestate.
+type tracestateCarrier struct {
+	span ptrace.Span
+	sampling.W3CTraceState
+}
+
+var _ samplingCarrier = &tracestateCarrier{}
+
+func newTracestateCarrier(s ptrace.Span) (*tracestateCarrier, error) {
+	var err error
+	tsc := &tracestateCarrier{
+		span: s,
+	}
+	tsc.W3CTraceState, err = sampling.NewW3CTraceState(s.TraceState().AsRaw())
+	return tsc, err
+}
+
+func (tc *tracestateCarrier) threshold() (sampling.Threshold, bool) {
+	return tc.OTelValue().TValueThreshold()
+}
+
+func (tc *tracestateCarrier) explicitRandomness() (sampling.Randomness, bool) {
+	return tc.OTelValue().RValueRandomness()
+}
+
+func (tc *tracestateCarrier) updateThreshold(th sampling.Threshold) error {
+	return tc.OTelValue().UpdateTValueWithSampling(th, th.TValue())
+}
+
+func (tc *tracestateCarrier) clearThreshold() {
+	tc.OTelValue().ClearTValue()
+}
+
+func (tc *tracestateCarrier) reserialize() error {
+	var w strings.Builder
+	if err := tc.Serialize(&w); err != nil {
+		return err
+	}
+	tc.span.TraceState().FromRaw(w.String())
+	return nil
+}
+
+// hashingSampler is the legacy hash_seed sampler.  Its decisions
+// are expressed as a Threshold and a Randomness so that they can be
+// evaluated like those of the consistent samplers, but the
+// tracestate is never read nor modified.
+type hashingSampler struct {
+	tvalueThreshold sampling.Threshold
+	hashSeed        uint32
+}
+
+func (hs *hashingSampler) decide(_ samplingCarrier) sampling.Threshold {
+	return hs.tvalueThreshold
+}
+
+func (hs *hashingSampler) randomnessFromSpan(s ptrace.Span) (randomnessSource, samplingCarrier, error) {
+	// If one assumes random trace ids hashing may seems avoidable, however, traces can be coming from sources
+	// with various different criteria to generate trace id and perhaps were already sampled without hashing.
+	// Hashing here prevents bias due to such systems.
+	tid := s.TraceID()
+	return randomnessSource{
+		randomness: randomnessFromBytes(tid[:], hs.hashSeed),
+		policy:     traceIDHashPolicy,
+	}, nil, nil
+}
+
+// consistentTracestateCommon is shared by the samplers that use the
+// OpenTelemetry tracestate.
+type consistentTracestateCommon struct{}
+
+func (consistentTracestateCommon) randomnessFromSpan(s ptrace.Span) (randomnessSource, samplingCarrier, error) {
+	rnd := randomnessSource{policy: missingRandomnessPolicy}
+	tsc, err := newTracestateCarrier(s)
+	if err != nil {
+		// The tracestate cannot be used, the span will be
+		// sampled using its trace ID without being updated.
+		tsc = nil
+	} else if rv, has := tsc.explicitRandomness(); has {
+		// When the tracestate contains an r-value, it takes
+		// precedence over the trace ID.
+		rnd.randomness = rv
+		rnd.policy = samplingRandomnessPolicy
+		return rnd, tsc, nil
+	}
+
+	tid := s.TraceID()
+	if tid.IsEmpty() {
+		return rnd, nil, errMissingRandomness
+	}
+	rnd.randomness = sampling.TraceIDToRandomness(tid)
+	rnd.policy = traceIDW3CPolicy
+
+	if tsc == nil {
+		// The tracestate parse error is returned along with the
+		// trace ID randomness, it does not prevent a decision.
+		// Note: a nil interface value is returned, not a nil
+		// pointer, so that callers can test the carrier against nil.
+		return rnd, nil, err
+	}
+	return rnd, tsc, nil
+}
+
+// equalizingSampler applies the same threshold to every span.
+type equalizingSampler struct {
+	consistentTracestateCommon
+
+	tvalueThreshold sampling.Threshold
+}
+
+func (es *equalizingSampler) decide(_ samplingCarrier) sampling.Threshold {
+	return es.tvalueThreshold
+}
+
+// proportionalSampler multiplies the arriving sampling probability
+// by a fixed ratio.
+type proportionalSampler struct {
+	consistentTracestateCommon
+
+	ratio float64
+	prec  uint8
+}
+
+func (ps *proportionalSampler) decide(carrier samplingCarrier) sampling.Threshold {
+	incoming := 1.0
+	if carrier != nil {
+		if th, has := carrier.threshold(); has {
+			incoming = th.Probability()
+		}
+	}
+
+	// There is a potential here for the product probability to
+	// underflow, which is checked here.
+	threshold, err := sampling.ProbabilityToThresholdWithPrecision(incoming*ps.ratio, ps.prec)
+	switch {
+	case errors.Is(err, sampling.ErrProbabilityRange):
+		// The sampling probability has fallen below the minimum
+		// supported value, the span is simply not sampled.
+		return sampling.NeverSampleThreshold
+	case errors.Is(err, sampling.ErrPrecisionUnderflow):
+		// The probability is too close to 1 for the configured
+		// precision, use full precision instead.
+		threshold, _ = sampling.ProbabilityToThreshold(incoming * ps.ratio)
+	}
+	return threshold
+}
+
+// consistencyCheck clears the arriving threshold when it is not
+// satisfied by the span's randomness.
+func consistencyCheck(rnd randomnessSource, carrier samplingCarrier) error {
+	if carrier == nil {
+		return nil
+	}
+	if th, has := carrier.threshold(); has && !th.ShouldSample(rnd.randomness) {
+		carrier.clearThreshold()
+		return errInconsistentArrivingTValue
+	}
+	return nil
+}
+
+// randomnessFromBytes computes the legacy FNV hash of b with the
+// given seed and expresses it as a Randomness, such that
+//
+//	hash & bitMaskHashBuckets < scaledSamplingRate
+//
+// is equivalent to the consistent sampling test for the threshold
+// computed by hashSeedThreshold.
+func randomnessFromBytes(b []byte, hashSeed uint32) sampling.Randomness {
+	hashed32 := computeHash(b, hashSeed)
+	hashed := uint64(hashed32 & bitMaskHashBuckets)
+
+	// Ordinarily, hashed is compared against an acceptance
+	// threshold, i.e., sampled when hashed < T, with T in
+	// [0, 2^14] and hashed in [0, 2^14-1].  Here, R' and T'
+	// are computed so that the test has the form T' <= R':
+	//
+	//   T' = numHashBuckets-T
+	//   R' = numHashBuckets-1-hashed
+	//
+	// As a result, R' has the correct most-significant 14 bits
+	// for a 56-bit randomness value.
+	rprime14 := numHashBuckets - 1 - hashed
+
+	// The remaining 18 bits of the hash fill the less
+	// significant bits, they do not influence the decision.
+	unused18 := uint64(hashed32 >> numHashBucketsLg2)
+	rnd56 := rprime14<<(56-numHashBucketsLg2) | unused18<<(56-numHashBucketsLg2-18)
+
+	rnd, _ := sampling.UnsignedToRandomness(rnd56)
+	return rnd
+}
+
+// hashSeedThreshold converts a legacy scaled sampling rate into the
+// equivalent 56-bit rejection threshold, see randomnessFromBytes.
+func hashSeedThreshold(scaledSamplingRate uint32) sampling.Threshold {
+	if scaledSamplingRate >= numHashBuckets {
+		return sampling.AlwaysSampleThreshold
+	}
+	reject := uint64(numHashBuckets - scaledSamplingRate)
+	threshold, _ := sampling.UnsignedToThreshold(reject << (56 - numHashBucketsLg2))
+	return threshold
+}
+
+// makeSampler returns the dataSampler for a validated configuration.
+func makeSampler(cfg *Config) dataSampler {
+	pct := math.Min(float64(cfg.SamplingPercentage), 100)
+	if pct < 0 {
+		pct = 0
+	}
+	ratio := pct / 100
+
+	switch cfg.Mode {
+	case Equalizing:
+		// The error case below only occurs with a zero ratio,
+		// other values have been validated with the configuration.
+		threshold, err := sampling.ProbabilityToThresholdWithPrecision(ratio, uint8(cfg.SamplingPrecision))
+		if err != nil {
+			threshold = sampling.NeverSampleThreshold
+		}
+		return &equalizingSampler{
+			tvalueThreshold: threshold,
+		}
+
+	case Proportional:
+		return &proportionalSampler{
+			ratio: ratio,
+			prec:  uint8(cfg.SamplingPrecision),
+		}
+
+	default: // i.e., HashSeed
+		// Note: the scaled sampling rate is computed in 32-bit
+		// precision and rounded toward zero, as it always has
+		// been, to ensure consistency across updates.
+		return &hashingSampler{
+			tvalueThreshold: hashSeedThreshold(uint32(cfg.SamplingPercentage * percentageScaleFactor)),
+			hashSeed:        cfg.HashSeed,
+		}
+	}
+}
@@ -5,6 +5,7 @@ package probabilisticsamplerprocessor // import "github.com/open-telemetry/opent
 
 import (
 	"context"
+	"errors"
 	"strconv"
 
 	"go.opencensus.io/stats"
@@ -15,6 +16,8 @@ import (
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processorhelper"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
 )
 
 // samplingPriority has the semantic result of parsing the "sampling.priority"
@@ -42,19 +45,16 @@ const (
 )
 
 type traceSamplerProcessor struct {
-	scaledSamplingRate uint32
-	hashSeed           uint32
-	logger             *zap.Logger
+	sampler dataSampler
+	logger  *zap.Logger
 }
 
 // newTracesProcessor returns a processor.TracesProcessor that will perform head sampling according to the given
 // configuration.
 func newTracesProcessor(ctx context.Context, set processor.CreateSettings, cfg *Config, nextConsumer consumer.Traces) (processor.Traces, error) {
 	tsp := &traceSamplerProcessor{
-		// Adjust sampling percentage on private so recalculations are avoided.
-		scaledSamplingRate: uint32(cfg.SamplingPercentage * percentageScaleFactor),
-		hashSeed:           cfg.HashSeed,
-		logger:             set.Logger,
+		sampler: makeSampler(cfg),
+		logger:  set.Logger,
 	}
 
 	return processorhelper.NewTracesProcessor(
@@ -70,38 +70,7 @@ func (tsp *traceSamplerProcessor) processTraces(ctx context.Context, td ptrace.T
 	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
 		rs.ScopeSpans().RemoveIf(func(ils ptrace.ScopeSpans) bool {
 			ils.Spans().RemoveIf(func(s ptrace.Span) bool {
-				sp := parseSpanSamplingPriority(s)
-				if sp == doNotSampleSpan {
-					// The OpenTelemetry mentions this as a "hint" we take a stronger
-					// approach and do not sample the span since some may use it to
-					// remove specific spans from traces.
-					_ = stats.RecordWithTags(
-						ctx,
-						[]tag.Mutator{tag.Upsert(tagPolicyKey, "sampling_priority"), tag.Upsert(tagSampledKey, "false")},
-						statCountTracesSampled.M(int64(1)),
-					)
-					return true
-				}
-
-				_ = stats.RecordWithTags(
-					ctx,
-					[]tag.Mutator{tag.Upsert(tagPolicyKey, "sampling_priority"), tag.Upsert(tagSampledKey, "true")},
-					statCountTracesSampled.M(int64(1)),
-				)
-
-				// If one assumes random trace ids hashing may seems avoidable, however, traces can be coming from sources
-				// with various different criteria to generate trace id and perhaps were already sampled without hashing.
-				// Hashing here prevents bias due to such systems.
-				tidBytes := s.TraceID()
-				sampled := sp == mustSampleSpan ||
-					computeHash(tidBytes[:], tsp.hashSeed)&bitMaskHashBuckets < tsp.scaledSamplingRate
-
-				_ = stats.RecordWithTags(
-					ctx,
-					[]tag.Mutator{tag.Upsert(tagPolicyKey, "trace_id_hash"), tag.Upsert(tagSampledKey, strconv.FormatBool(sampled))},
-					statCountTracesSampled.M(int64(1)),
-				)
-				return !sampled
+				return !tsp.shouldSample(ctx, s)
 			})
 			// Filter out empty ScopeMetrics
 			return ils.Spans().Len() == 0
@@ -115,6 +84,67 @@ func (tsp *traceSamplerProcessor) processTraces(ctx context.Context, td ptrace.T
 	return td, nil
 }
 
+// shouldSample makes the sampling decision for a single span.  In the
+// consistent sampling modes, the tracestate of sampled spans is
+// updated with the threshold that was applied.
+func (tsp *traceSamplerProcessor) shouldSample(ctx context.Context, s ptrace.Span) bool {
+	sp := parseSpanSamplingPriority(s)
+	if sp == doNotSampleSpan {
+		// The OpenTelemetry mentions this as a "hint" we take a stronger
+		// approach and do not sample the span since some may use it to
+		// remove specific spans from traces.
+		_ = stats.RecordWithTags(
+			ctx,
+			[]tag.Mutator{tag.Upsert(tagPolicyKey, "sampling_priority"), tag.Upsert(tagSampledKey, "false")},
+			statCountTracesSampled.M(int64(1)),
+		)
+		return false
+	}
+
+	_ = stats.RecordWithTags(
+		ctx,
+		[]tag.Mutator{tag.Upsert(tagPolicyKey, "sampling_priority"), tag.Upsert(tagSampledKey, "true")},
+		statCountTracesSampled.M(int64(1)),
+	)
+
+	rnd, carrier, err := tsp.sampler.randomnessFromSpan(s)
+	if err == nil {
+		err = consistencyCheck(rnd, carrier)
+	}
+
+	threshold := sampling.NeverSampleThreshold
+	if errors.Is(err, errMissingRandomness) {
+		tsp.logger.Debug("span has no randomness, it will not be sampled", zap.Error(err))
+	} else {
+		if err != nil {
+			tsp.logger.Debug("span sampling information ignored", zap.Error(err))
+		}
+		threshold = tsp.sampler.decide(carrier)
+	}
+	if sp == mustSampleSpan {
+		threshold = sampling.AlwaysSampleThreshold
+	}
+
+	sampled := threshold.ShouldSample(rnd.randomness)
+	if sampled && carrier != nil {
+		// Note: updateThreshold prevents the threshold from being
+		// lowered, the sampling probability can only fall, never rise.
+		if err := carrier.updateThreshold(threshold); err != nil && !errors.Is(err, sampling.ErrInconsistentSampling) {
+			tsp.logger.Warn("tracestate threshold update failed", zap.Error(err))
+		}
+		if err := carrier.reserialize(); err != nil {
+			tsp.logger.Warn("tracestate serialize failed", zap.Error(err))
+		}
+	}
+
+	_ = stats.RecordWithTags(
+		ctx,
+		[]tag.Mutator{tag.Upsert(tagPolicyKey, rnd.policy), tag.Upsert(tagSampledKey, strconv.FormatBool(sampled))},
+		statCountTracesSampled.M(int64(1)),
+	)
+	return sampled
+}
+
 // parseSpanSamplingPriority checks if the span has the "sampling.priority" tag to
 // decide if the span should be sampled or not. The usage of the tag follows the
 // OpenTracing semantic tags:
@@ -19,6 +19,7 @@ import (
 	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/idutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
 )
 
 func TestNewTracesProcessor(t *testing.T) {
@@ -303,6 +304,218 @@ func Test_tracesamplerprocessor_SpanSamplingPriority(t *testing.T) {
 	}
 }
 
+// Test_tracesamplerprocessor_HashSeedRandomness checks that expressing the legacy hash_seed decision
+// as a threshold and randomness yields the same decisions as the original comparison.
+func Test_tracesamplerprocessor_HashSeedRandomness(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, pct := range []float32{0, 0.03, 1, 15.3, 33.33, 50, 99.99, 100} {
+		scaled := uint32(pct * percentageScaleFactor)
+		threshold := hashSeedThreshold(scaled)
+		for i := 0; i < 1000; i++ {
+			tid := idutils.UInt64ToTraceID(r.Uint64(), r.Uint64())
+			seed := r.Uint32()
+			expect := computeHash(tid[:], seed)&bitMaskHashBuckets < scaled
+			require.Equal(t, expect, threshold.ShouldSample(randomnessFromBytes(tid[:], seed)), "pct=%v tid=%v", pct, tid)
+		}
+	}
+}
+
+// Test_tracesamplerprocessor_ConsistentModes checks the tracestate handling of the equalizing and
+// proportional modes.
+func Test_tracesamplerprocessor_ConsistentModes(t *testing.T) {
+	// The randomness of this trace ID is 0x80000000000000, i.e., it is
+	// sampled by thresholds up to and including "8" (50%).
+	tid := pcommon.TraceID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0x80, 0, 0, 0, 0, 0, 0}
+
+	tests := []struct {
+		name       string
+		mode       SamplerMode
+		pct        float32
+		tracestate string
+		tid        pcommon.TraceID
+		priority   string
+		sampled    bool
+		expected   string
+	}{
+		{
+			name:     "equalizing_no_tracestate",
+			mode:     Equalizing,
+			pct:      50,
+			tid:      tid,
+			sampled:  true,
+			expected: "ot=th:8",
+		},
+		{
+			name:    "equalizing_not_sampled",
+			mode:    Equalizing,
+			pct:     25,
+			tid:     tid,
+			sampled: false,
+		},
+		{
+			name:       "equalizing_keeps_lower_probability",
+			mode:       Equalizing,
+			pct:        100,
+			tracestate: "ot=th:8",
+			tid:        tid,
+			sampled:    true,
+			expected:   "ot=th:8",
+		},
+		{
+			name:       "equalizing_lowers_probability",
+			mode:       Equalizing,
+			pct:        50,
+			tracestate: "ot=th:4",
+			tid:        tid,
+			sampled:    true,
+			expected:   "ot=th:8",
+		},
+		{
+			name:       "equalizing_preserves_other_values",
+			mode:       Equalizing,
+			pct:        50,
+			tracestate: "ot=th:4;p:x,vendor=value",
+			tid:        tid,
+			sampled:    true,
+			expected:   "ot=th:8;p:x,vendor=value",
+		},
+		{
+			name:       "proportional_multiplies",
+			mode:       Proportional,
+			pct:        50,
+			tracestate: "ot=th:8",
+			tid:        pcommon.TraceID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0xf0, 0, 0, 0, 0, 0, 0},
+			sampled:    true,
+			expected:   "ot=th:c",
+		},
+		{
+			name:       "proportional_not_sampled",
+			mode:       Proportional,
+			pct:        50,
+			tracestate: "ot=th:8",
+			tid:        tid,
+			sampled:    false,
+		},
+		{
+			name:       "explicit_randomness",
+			mode:       Proportional,
+			pct:        50,
+			tracestate: "ot=rv:c0000000000000",
+			tid:        pcommon.TraceID{},
+			sampled:    true,
+			expected:   "ot=rv:c0000000000000;th:8",
+		},
+		{
+			name:       "inconsistent_arriving_threshold",
+			mode:       Proportional,
+			pct:        50,
+			tracestate: "ot=th:c",
+			tid:        tid,
+			sampled:    true,
+			expected:   "ot=th:8",
+		},
+		{
+			name:    "missing_randomness",
+			mode:    Equalizing,
+			pct:     100,
+			tid:     pcommon.TraceID{},
+			sampled: false,
+		},
+		{
+			name:     "must_sample_priority",
+			mode:     Equalizing,
+			pct:      25,
+			tid:      tid,
+			priority: "1",
+			sampled:  true,
+			expected: "ot=th:0",
+		},
+		{
+			name:     "must_not_sample_priority",
+			mode:     Proportional,
+			pct:      100,
+			tid:      tid,
+			priority: "0",
+			sampled:  false,
+		},
+		{
+			name:       "hash_seed_ignores_tracestate",
+			mode:       HashSeed,
+			pct:        100,
+			tracestate: "ot=th:8",
+			tid:        tid,
+			sampled:    true,
+			expected:   "ot=th:8",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				SamplingPercentage: tt.pct,
+				Mode:               tt.mode,
+				SamplingPrecision:  defaultPrecision,
+			}
+			require.NoError(t, cfg.Validate())
+
+			td := ptrace.NewTraces()
+			span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			span.SetTraceID(tt.tid)
+			span.TraceState().FromRaw(tt.tracestate)
+			if tt.priority != "" {
+				initSpanWithAttribute("sampling.priority", pcommon.NewValueStr(tt.priority), span)
+			}
+
+			sink := new(consumertest.TracesSink)
+			tsp, err := newTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, sink)
+			require.NoError(t, err)
+			require.NoError(t, tsp.ConsumeTraces(context.Background(), td))
+
+			if !tt.sampled {
+				assert.Equal(t, 0, sink.SpanCount())
+				return
+			}
+			require.Equal(t, 1, sink.SpanCount())
+			got := sink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+			assert.Equal(t, tt.expected, got.TraceState().AsRaw())
+		})
+	}
+}
+
+// Test_tracesamplerprocessor_ProportionalRate checks that the proportional mode applied
+// after a 50% equalizing sampler results in the expected combined sampling rate and adjusted count.
+func Test_tracesamplerprocessor_ProportionalRate(t *testing.T) {
+	const numTraces = 1e5
+	sink := new(consumertest.TracesSink)
+	second, err := newTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), &Config{
+		SamplingPercentage: 10,
+		Mode:               Proportional,
+		SamplingPrecision:  defaultPrecision,
+	}, sink)
+	require.NoError(t, err)
+	first, err := newTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), &Config{
+		SamplingPercentage: 50,
+		Mode:               Equalizing,
+		SamplingPrecision:  defaultPrecision,
+	}, second)
+	require.NoError(t, err)
+
+	for _, td := range genRandomTestData(1, numTraces, "test-svc", 1) {
+		require.NoError(t, first.ConsumeTraces(context.Background(), td))
+	}
+
+	adjusted := 0.0
+	for _, td := range sink.AllTraces() {
+		spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+		for i := 0; i < spans.Len(); i++ {
+			w3c, err := sampling.NewW3CTraceState(spans.At(i).TraceState().AsRaw())
+			require.NoError(t, err)
+			adjusted += w3c.OTelValue().AdjustedCount()
+		}
+	}
+	assert.InDelta(t, 0.05, float64(sink.SpanCount())/numTraces, 0.005)
+	assert.InDelta(t, numTraces, adjusted, numTraces*0.05)
+}
+
 // Test_parseSpanSamplingPriority ensures that the function parsing the attributes is taking "sampling.priority"
 // attribute correctly.
 func Test_parseSpanSamplingPriority(t *testing.T) {
@@ -3,6 +3,12 @@
 
 package redactionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor"
 
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
 type Config struct {
 
 	// AllowAllKeys is a flag to allow all span attribute keys. Setting this
@@ -24,6 +30,31 @@ type Config struct {
 	// allowed span attributes. Values that match are masked
 	BlockedValues []string `mapstructure:"blocked_values"`
 
+	// AllowedValues is a list of regular expressions for allowing values of
+	// span attributes that would otherwise be masked. Values that match are
+	// not masked.
+	AllowedValues []string `mapstructure:"allowed_values"`
+
+	// Detectors is a list of built-in detectors for blocking values of allowed
+	// span attributes, in addition to BlockedValues. Possible values are
+	// `credit_card`, `email`, `ipv4`, `ipv6`, `jwt` and `aws_access_key`.
+	Detectors []string `mapstructure:"detectors"`
+
+	// HashFunction masks blocked values with their hash instead of a fixed
+	// string of asterisks, so masked values can still be correlated across
+	// records. Possible values are `sha256`, `hmac-sha256` and `md5`.
+	HashFunction string `mapstructure:"hash_function"`
+
+	// HashKeyFile is the path of the file holding the key of the
+	// `hmac-sha256` hash function.
+	HashKeyFile string `mapstructure:"hash_key_file"`
+
+	// ApplyTo limits the parts of the signals that are redacted. Possible
+	// values are `resource`, `span`, `span_event`, `log`, `log_body` and
+	// `metric`. Everything but `span_event` and `log_body` is redacted if
+	// it's empty.
+	ApplyTo []string `mapstructure:"apply_to"`
+
 	// Summary controls the verbosity level of the diagnostic attributes that
 	// the processor adds to the spans when it redacts or masks other
 	// attributes. In some contexts a list of redacted attributes leaks
@@ -31,3 +62,59 @@ type Config struct {
 	// configuration. Possible values are `debug`, `info`, and `silent`.
 	Summary string `mapstructure:"summary"`
 }
+
+const (
+	hashSHA256     = "sha256"
+	hashHMACSHA256 = "hmac-sha256"
+	hashMD5        = "md5"
+)
+
+const (
+	applyToResource  = "resource"
+	applyToSpan      = "span"
+	applyToSpanEvent = "span_event"
+	applyToLog       = "log"
+	applyToLogBody   = "log_body"
+	applyToMetric    = "metric"
+)
+
+var (
+	errHashKeyFileRequired = errors.New("hash_key_file is required by the hmac-sha256 hash function")
+	errHashKeyFileUnused   = errors.New("hash_key_file is only used by the hmac-sha256 hash function")
+)
+
+func (c *Config) Validate() error {
+	switch c.HashFunction {
+	case "", hashSHA256, hashMD5:
+		if c.HashKeyFile != "" {
+			return errHashKeyFileUnused
+		}
+	case hashHMACSHA256:
+		if c.HashKeyFile == "" {
+			return errHashKeyFileRequired
+		}
+	default:
+		return fmt.Errorf("unknown hash_function %q", c.HashFunction)
+	}
+
+	for _, name := range c.Detectors {
+		if _, ok := detectors[name]; !ok {
+			return fmt.Errorf("unknown detector %q", name)
+		}
+	}
+
+	for _, scope := range c.ApplyTo {
+		switch scope {
+		case applyToResource, applyToSpan, applyToSpanEvent, applyToLog, applyToLogBody, applyToMetric:
+		default:
+			return fmt.Errorf("unknown apply_to value %q", scope)
+		}
+	}
+
+	for _, pattern := range append(append([]string(nil), c.BlockedValues...), c.AllowedValues...) {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid regular expression %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
@@ -36,6 +36,17 @@ func TestLoadConfig(t *testing.T) {
 			id:       component.NewIDWithName(metadata.Type, "empty"),
 			expected: createDefaultConfig(),
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "detectors"),
+			expected: &Config{
+				AllowAllKeys:  true,
+				Detectors:     []string{"credit_card", "email"},
+				AllowedValues: []string{".+@example.com"},
+				HashFunction:  hashHMACSHA256,
+				HashKeyFile:   "/etc/otelcol/redaction.key",
+				ApplyTo:       []string{applyToLog, applyToLogBody},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -55,3 +66,59 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		config *Config
+		err    string
+	}{
+		{
+			name:   "valid",
+			config: &Config{HashFunction: hashSHA256, Detectors: []string{"jwt"}, ApplyTo: []string{applyToSpanEvent}},
+		},
+		{
+			name:   "unknown hash function",
+			config: &Config{HashFunction: "sha1"},
+			err:    `unknown hash_function "sha1"`,
+		},
+		{
+			name:   "hmac without key",
+			config: &Config{HashFunction: hashHMACSHA256},
+			err:    errHashKeyFileRequired.Error(),
+		},
+		{
+			name:   "key without hmac",
+			config: &Config{HashFunction: hashMD5, HashKeyFile: "key"},
+			err:    errHashKeyFileUnused.Error(),
+		},
+		{
+			name:   "unknown detector",
+			config: &Config{Detectors: []string{"phone"}},
+			err:    `unknown detector "phone"`,
+		},
+		{
+			name:   "unknown apply_to",
+			config: &Config{ApplyTo: []string{"trace"}},
+			err:    `unknown apply_to value "trace"`,
+		},
+		{
+			name:   "invalid allowed value",
+			config: &Config{AllowedValues: []string{"("}},
+			err:    `invalid regular expression "("`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := component.ValidateConfig(tt.config)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redactionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor"
+
+import (
+	"net/netip"
+	"regexp"
+)
+
+// blockRule is a compiled blocked value. Matches of the regular expression
+// are only masked if they are valid, when a validation is set.
+type blockRule struct {
+	re    *regexp.Regexp
+	valid func(match string) bool
+}
+
+// detectors are the built-in rules that can be enabled by name
+var detectors = map[string]blockRule{
+	"credit_card": {
+		re:    regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`),
+		valid: luhn,
+	},
+	"email": {
+		re: regexp.MustCompile(`[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`),
+	},
+	"ipv4": {
+		re: regexp.MustCompile(`\b(?:(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\.){3}(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\b`),
+	},
+	"ipv6": {
+		// Candidates are checked by parsing them, since a regular expression
+		// covering every notation of IPv6 addresses is hard to read
+		re: regexp.MustCompile(`[0-9a-fA-F]{0,4}(?::[0-9a-fA-F]{0,4}){2,7}`),
+		valid: func(match string) bool {
+			addr, err := netip.ParseAddr(match)
+			return err == nil && addr.Is6()
+		},
+	},
+	"jwt": {
+		re: regexp.MustCompile(`eyJ[a-zA-Z0-9_-]+\.eyJ[a-zA-Z0-9_-]+\.[a-zA-Z0-9_-]*`),
+	},
+	"aws_access_key": {
+		re: regexp.MustCompile(`\b(?:AKIA|ASIA|AGPA|AIDA|AROA|ANPA|ANVA|AIPA)[A-Z0-9]{16}\b`),
+	},
+}
+
+// luhn validates the check digit of a credit card number, ignoring separators
+func luhn(number string) bool {
+	var (
+		sum    int
+		digits int
+		double bool
+	)
+	for i := len(number) - 1; i >= 0; i-- {
+		c := number[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		digits++
+		double = !double
+	}
+	return digits >= 13 && digits <= 19 && sum%10 == 0
+}
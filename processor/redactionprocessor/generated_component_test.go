@@ -28,6 +28,20 @@ func TestComponentLifecycle(t *testing.T) {
 		createFn func(ctx context.Context, set processor.CreateSettings, cfg component.Config) (component.Component, error)
 	}{
 
+		{
+			name: "logs",
+			createFn: func(ctx context.Context, set processor.CreateSettings, cfg component.Config) (component.Component, error) {
+				return factory.CreateLogsProcessor(ctx, set, cfg, consumertest.NewNop())
+			},
+		},
+
+		{
+			name: "metrics",
+			createFn: func(ctx context.Context, set processor.CreateSettings, cfg component.Config) (component.Component, error) {
+				return factory.CreateMetricsProcessor(ctx, set, cfg, consumertest.NewNop())
+			},
+		},
+
 		{
 			name: "traces",
 			createFn: func(ctx context.Context, set processor.CreateSettings, cfg component.Config) (component.Component, error) {
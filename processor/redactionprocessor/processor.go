@@ -5,12 +5,20 @@ package redactionprocessor // import "github.com/open-telemetry/opentelemetry-co
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/md5" // #nosec
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"hash"
+	"os"
 	"regexp"
 	"sort"
 	"strings"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 )
@@ -23,7 +31,14 @@ type redaction struct {
 	// Attribute keys ignored in a span
 	ignoreList map[string]string
 	// Attribute values blocked in a span
-	blockRegexList map[string]*regexp.Regexp
+	blockRules []blockRule
+	// Attribute values allowed in a span
+	allowRegexList []*regexp.Regexp
+	// Hash function of masked values, masked values are replaced
+	// by asterisks if it's nil
+	hash func() hash.Hash
+	// Parts of the signals that are redacted
+	applyTo map[string]bool
 	// Redaction processor configuration
 	config *Config
 	// Logger
@@ -34,16 +49,27 @@ type redaction struct {
 func newRedaction(ctx context.Context, config *Config, logger *zap.Logger) (*redaction, error) {
 	allowList := makeAllowList(config)
 	ignoreList := makeIgnoreList(config)
-	blockRegexList, err := makeBlockRegexList(ctx, config)
+	blockRules, err := makeBlockRules(ctx, config)
 	if err != nil {
 		// TODO: Placeholder for an error metric in the next PR
 		return nil, fmt.Errorf("failed to process block list: %w", err)
 	}
+	allowRegexList, err := makeAllowRegexList(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process allow list: %w", err)
+	}
+	hashFunc, err := makeHash(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up the hash function: %w", err)
+	}
 
 	return &redaction{
 		allowList:      allowList,
 		ignoreList:     ignoreList,
-		blockRegexList: blockRegexList,
+		blockRules:     blockRules,
+		allowRegexList: allowRegexList,
+		hash:           hashFunc,
+		applyTo:        makeApplyTo(config),
 		config:         config,
 		logger:         logger,
 	}, nil
@@ -62,29 +88,120 @@ func (s *redaction) processTraces(ctx context.Context, batch ptrace.Traces) (ptr
 // processResourceSpan processes the RS and all of its spans and then returns the last
 // view metric context. The context can be used for tests
 func (s *redaction) processResourceSpan(ctx context.Context, rs ptrace.ResourceSpans) {
-	rsAttrs := rs.Resource().Attributes()
-
 	// Attributes can be part of a resource span
-	s.processAttrs(ctx, rsAttrs)
+	if s.applyTo[applyToResource] {
+		s.processAttrs(ctx, rs.Resource().Attributes())
+	}
 
 	for j := 0; j < rs.ScopeSpans().Len(); j++ {
 		ils := rs.ScopeSpans().At(j)
 		for k := 0; k < ils.Spans().Len(); k++ {
 			span := ils.Spans().At(k)
-			spanAttrs := span.Attributes()
 
 			// Attributes can also be part of span
-			s.processAttrs(ctx, spanAttrs)
+			if s.applyTo[applyToSpan] {
+				s.processAttrs(ctx, span.Attributes())
+			}
+
+			// and of the events of a span
+			if s.applyTo[applyToSpanEvent] {
+				for l := 0; l < span.Events().Len(); l++ {
+					s.processAttrs(ctx, span.Events().At(l).Attributes())
+				}
+			}
+		}
+	}
+}
+
+// processLogs implements ProcessLogsFunc. It processes the incoming data
+// and returns the data to be sent to the next component
+func (s *redaction) processLogs(ctx context.Context, logs plog.Logs) (plog.Logs, error) {
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		rl := logs.ResourceLogs().At(i)
+		if s.applyTo[applyToResource] {
+			s.processAttrs(ctx, rl.Resource().Attributes())
+		}
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				record := sl.LogRecords().At(k)
+				if s.applyTo[applyToLog] {
+					s.processAttrs(ctx, record.Attributes())
+				}
+				if s.applyTo[applyToLogBody] {
+					s.maskNestedValue(record.Body())
+				}
+			}
+		}
+	}
+	return logs, nil
+}
+
+// processMetrics implements ProcessMetricsFunc. It processes the incoming data
+// and returns the data to be sent to the next component
+func (s *redaction) processMetrics(ctx context.Context, metrics pmetric.Metrics) (pmetric.Metrics, error) {
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		rm := metrics.ResourceMetrics().At(i)
+		// the summary attributes would change the identity of the time series
+		if s.applyTo[applyToResource] {
+			s.redactAttrs(ctx, rm.Resource().Attributes())
+		}
+		if !s.applyTo[applyToMetric] {
+			continue
+		}
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				s.processMetricAttrs(ctx, sm.Metrics().At(k))
+			}
 		}
 	}
+	return metrics, nil
+}
+
+// processMetricAttrs redacts the attributes of the data points of a metric.
+// The summary attributes are not added to the data points, since they would
+// change the identity of their time series.
+func (s *redaction) processMetricAttrs(ctx context.Context, metric pmetric.Metric) {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		for i := 0; i < metric.Gauge().DataPoints().Len(); i++ {
+			s.redactAttrs(ctx, metric.Gauge().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		for i := 0; i < metric.Sum().DataPoints().Len(); i++ {
+			s.redactAttrs(ctx, metric.Sum().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		for i := 0; i < metric.Histogram().DataPoints().Len(); i++ {
+			s.redactAttrs(ctx, metric.Histogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for i := 0; i < metric.ExponentialHistogram().DataPoints().Len(); i++ {
+			s.redactAttrs(ctx, metric.ExponentialHistogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		for i := 0; i < metric.Summary().DataPoints().Len(); i++ {
+			s.redactAttrs(ctx, metric.Summary().DataPoints().At(i).Attributes())
+		}
+	}
+}
+
+// processAttrs redacts the attributes of a resource span or a span, and adds
+// the summary of the changes to them
+func (s *redaction) processAttrs(ctx context.Context, attributes pcommon.Map) {
+	toDelete, toBlock, ignoring := s.redactAttrs(ctx, attributes)
+
+	// Add diagnostic information to the span
+	s.addMetaAttrs(toDelete, attributes, redactedKeys, redactedKeyCount)
+	s.addMetaAttrs(toBlock, attributes, maskedValues, maskedValueCount)
+	s.addMetaAttrs(ignoring, attributes, "", ignoredKeyCount)
 }
 
-// processAttrs redacts the attributes of a resource span or a span
-func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map) {
+// redactAttrs redacts the attributes, and returns the keys of the deleted,
+// masked and ignored attributes
+func (s *redaction) redactAttrs(_ context.Context, attributes pcommon.Map) (toDelete, toBlock, ignoring []string) {
 	// TODO: Use the context for recording metrics
-	var toDelete []string
-	var toBlock []string
-	var ignoring []string
 
 	// Identify attributes to redact and mask in the following sequence
 	// 1. Make a list of attribute keys to redact
@@ -112,20 +229,12 @@ func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map) {
 		}
 
 		// Mask any blocked values for the other attributes
-		strVal := value.Str()
-		var matched bool
-		for _, compiledRE := range s.blockRegexList {
-			match := compiledRE.MatchString(strVal)
-			if match {
-				if !matched {
-					matched = true
-					toBlock = append(toBlock, k)
-				}
-
-				maskedValue := compiledRE.ReplaceAllString(strVal, "****")
-				value.SetStr(maskedValue)
-				strVal = maskedValue
-			}
+		if value.Type() != pcommon.ValueTypeStr {
+			return true
+		}
+		if maskedValue, matched := s.maskValue(value.Str()); matched {
+			toBlock = append(toBlock, k)
+			value.SetStr(maskedValue)
 		}
 		return true
 	})
@@ -134,10 +243,59 @@ func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map) {
 	for _, k := range toDelete {
 		attributes.Remove(k)
 	}
-	// Add diagnostic information to the span
-	s.addMetaAttrs(toDelete, attributes, redactedKeys, redactedKeyCount)
-	s.addMetaAttrs(toBlock, attributes, maskedValues, maskedValueCount)
-	s.addMetaAttrs(ignoring, attributes, "", ignoredKeyCount)
+	return toDelete, toBlock, ignoring
+}
+
+// maskValue masks the parts of the value that match a blocked value,
+// unless the value matches an allowed value
+func (s *redaction) maskValue(val string) (string, bool) {
+	for _, re := range s.allowRegexList {
+		if re.MatchString(val) {
+			return val, false
+		}
+	}
+	var matched bool
+	for _, rule := range s.blockRules {
+		val = rule.re.ReplaceAllStringFunc(val, func(match string) string {
+			if rule.valid != nil && !rule.valid(match) {
+				return match
+			}
+			matched = true
+			return s.mask(match)
+		})
+	}
+	return val, matched
+}
+
+// mask returns the replacement of a blocked value
+func (s *redaction) mask(val string) string {
+	if s.hash == nil {
+		return "****"
+	}
+	h := s.hash()
+	_, _ = h.Write([]byte(val))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// maskNestedValue masks the blocked values of a string, or of the strings
+// nested in a map or a slice, such as a structured log body.
+// Keys of maps are kept since the allowed keys apply to attributes only.
+func (s *redaction) maskNestedValue(value pcommon.Value) {
+	switch value.Type() {
+	case pcommon.ValueTypeStr:
+		if maskedValue, matched := s.maskValue(value.Str()); matched {
+			value.SetStr(maskedValue)
+		}
+	case pcommon.ValueTypeMap:
+		value.Map().Range(func(_ string, v pcommon.Value) bool {
+			s.maskNestedValue(v)
+			return true
+		})
+	case pcommon.ValueTypeSlice:
+		for i := 0; i < value.Slice().Len(); i++ {
+			s.maskNestedValue(value.Slice().At(i))
+		}
+	}
 }
 
 // addMetaAttrs adds diagnostic information about redacted or masked attribute keys
@@ -207,16 +365,78 @@ func makeIgnoreList(c *Config) map[string]string {
 	return ignoreList
 }
 
-// makeBlockRegexList precompiles all the blocked regex patterns
-func makeBlockRegexList(_ context.Context, config *Config) (map[string]*regexp.Regexp, error) {
-	blockRegexList := make(map[string]*regexp.Regexp, len(config.BlockedValues))
+// makeBlockRules precompiles all the blocked regex patterns,
+// after the enabled detectors
+func makeBlockRules(_ context.Context, config *Config) ([]blockRule, error) {
+	blockRules := make([]blockRule, 0, len(config.Detectors)+len(config.BlockedValues))
+	for _, name := range config.Detectors {
+		rule, ok := detectors[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown detector %q", name)
+		}
+		blockRules = append(blockRules, rule)
+	}
 	for _, pattern := range config.BlockedValues {
 		re, err := regexp.Compile(pattern)
 		if err != nil {
 			// TODO: Placeholder for an error metric in the next PR
 			return nil, fmt.Errorf("error compiling regex in block list: %w", err)
 		}
-		blockRegexList[pattern] = re
+		blockRules = append(blockRules, blockRule{re: re})
+	}
+	return blockRules, nil
+}
+
+// makeAllowRegexList precompiles all the allowed regex patterns
+func makeAllowRegexList(_ context.Context, config *Config) ([]*regexp.Regexp, error) {
+	allowRegexList := make([]*regexp.Regexp, 0, len(config.AllowedValues))
+	for _, pattern := range config.AllowedValues {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("error compiling regex in allow list: %w", err)
+		}
+		allowRegexList = append(allowRegexList, re)
+	}
+	return allowRegexList, nil
+}
+
+// makeHash returns the hash function of masked values,
+// or nil if values are masked with asterisks
+func makeHash(config *Config) (func() hash.Hash, error) {
+	switch config.HashFunction {
+	case "":
+		return nil, nil
+	case hashSHA256:
+		return sha256.New, nil
+	case hashMD5:
+		return md5.New, nil // #nosec
+	case hashHMACSHA256:
+		key, err := os.ReadFile(config.HashKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading hash key file: %w", err)
+		}
+		key = []byte(strings.TrimSpace(string(key)))
+		if len(key) == 0 {
+			return nil, fmt.Errorf("hash key file %q is empty", config.HashKeyFile)
+		}
+		return func() hash.Hash {
+			return hmac.New(sha256.New, key)
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown hash function %q", config.HashFunction)
+}
+
+// makeApplyTo sets up a lookup table of the redacted parts of the signals.
+// The span events and the log bodies are only redacted when they are listed,
+// so that upgrading doesn't change what is removed from the existing pipelines.
+func makeApplyTo(config *Config) map[string]bool {
+	scopes := config.ApplyTo
+	if len(scopes) == 0 {
+		scopes = []string{applyToResource, applyToSpan, applyToLog, applyToMetric}
+	}
+	applyTo := make(map[string]bool, len(scopes))
+	for _, scope := range scopes {
+		applyTo[scope] = true
 	}
-	return blockRegexList, nil
+	return applyTo
 }
@@ -31,6 +31,12 @@ type Config struct {
 	// translated to, allowing older and newer formats
 	// to conform to the target schema identifier.
 	Targets []string `mapstructure:"targets"`
+
+	// SchemaDir is a local directory that schema files are read from
+	// before they are fetched remotely, allowing the processor to be
+	// used without network access. Each schema file is stored under the host
+	// and path of its schema URL. (Optional field)
+	SchemaDir string `mapstructure:"schema_dir"`
 }
 
 func (c *Config) Validate() error {
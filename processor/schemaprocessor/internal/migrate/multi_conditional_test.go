@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestMultiConditionalAttributeSetApply(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		cond   *MultiConditionalAttributeSet
+		check  map[string]string
+		expect pcommon.Map
+	}{
+		{
+			name: "No conditions, applies to all",
+			cond: NewMultiConditionalAttributeSet(
+				map[string]string{"service.version": "application.version"},
+				map[string][]string{"span.name": nil, "event.name": nil},
+			),
+			check: map[string]string{"span.name": "application start", "event.name": "started"},
+			expect: testHelperBuildMap(func(m pcommon.Map) {
+				m.PutStr("application.version", "v0.0.0")
+			}),
+		},
+		{
+			name: "All conditions matched",
+			cond: NewMultiConditionalAttributeSet(
+				map[string]string{"service.version": "application.version"},
+				map[string][]string{"span.name": {"application start"}, "event.name": {"started"}},
+			),
+			check: map[string]string{"span.name": "application start", "event.name": "started"},
+			expect: testHelperBuildMap(func(m pcommon.Map) {
+				m.PutStr("application.version", "v0.0.0")
+			}),
+		},
+		{
+			name: "One condition not matched",
+			cond: NewMultiConditionalAttributeSet(
+				map[string]string{"service.version": "application.version"},
+				map[string][]string{"span.name": {"application start"}, "event.name": {"stopped"}},
+			),
+			check: map[string]string{"span.name": "application start", "event.name": "started"},
+			expect: testHelperBuildMap(func(m pcommon.Map) {
+				m.PutStr("service.version", "v0.0.0")
+			}),
+		},
+		{
+			name: "Condition value not provided",
+			cond: NewMultiConditionalAttributeSet(
+				map[string]string{"service.version": "application.version"},
+				map[string][]string{"event.name": {"started"}},
+			),
+			check: map[string]string{"span.name": "application start"},
+			expect: testHelperBuildMap(func(m pcommon.Map) {
+				m.PutStr("service.version", "v0.0.0")
+			}),
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			attrs := testHelperBuildMap(func(m pcommon.Map) {
+				m.PutStr("service.version", "v0.0.0")
+			})
+			assert.NoError(t, tc.cond.Apply(attrs, tc.check))
+			assert.Equal(t, tc.expect.AsRaw(), attrs.AsRaw(), "Must match the expected attributes")
+
+			// Rolling back must restore the original attributes
+			assert.NoError(t, tc.cond.Rollback(attrs, tc.check))
+			assert.Equal(t, map[string]any{"service.version": "v0.0.0"}, attrs.AsRaw())
+		})
+	}
+}
+
+func TestMultiConditionalAttributeSetSliceOrder(t *testing.T) {
+	t.Parallel()
+
+	slice := NewMultiConditionalAttributeSetSlice(
+		NewMultiConditionalAttributeSet(map[string]string{"a": "b"}, nil),
+		NewMultiConditionalAttributeSet(map[string]string{"b": "c"}, nil),
+	)
+	attrs := testHelperBuildMap(func(m pcommon.Map) {
+		m.PutStr("a", "value")
+	})
+
+	assert.NoError(t, slice.Apply(attrs, nil))
+	assert.Equal(t, map[string]any{"c": "value"}, attrs.AsRaw())
+
+	assert.NoError(t, slice.Rollback(attrs, nil))
+	assert.Equal(t, map[string]any{"a": "value"}, attrs.AsRaw())
+}
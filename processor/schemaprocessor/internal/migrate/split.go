@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package migrate // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/migrate"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/otel/schema/v1.1/ast"
+)
+
+// MetricSplit splits a metric into several metrics,
+// one for each of the values of an attribute of its data points.
+// Rolling back a split merges those metrics back into the original one
+// and restores the attribute on each data point.
+type MetricSplit struct {
+	metric    string
+	attribute string
+	// names maps the value of the attribute to the name of the new metric
+	names map[string]string
+	// values maps the name of the new metric to the value of the attribute
+	values map[string]pcommon.Value
+}
+
+type MetricSplitSlice []*MetricSplit
+
+// NewMetricSplit converts the split definition from a schema into a `MetricSplit`.
+func NewMetricSplit(split ast.SplitMetric) *MetricSplit {
+	ms := &MetricSplit{
+		metric:    string(split.ApplyToMetric),
+		attribute: string(split.ByAttribute),
+		names:     make(map[string]string, len(split.MetricsFromAttributes)),
+		values:    make(map[string]pcommon.Value, len(split.MetricsFromAttributes)),
+	}
+	for name, value := range split.MetricsFromAttributes {
+		v := pcommon.NewValueEmpty()
+		if err := v.FromRaw(value); err != nil {
+			v.SetStr(fmt.Sprint(value))
+		}
+		ms.names[v.AsString()] = string(name)
+		ms.values[string(name)] = v
+	}
+	return ms
+}
+
+func (ms *MetricSplit) Apply(metrics pmetric.MetricSlice) {
+	split := pmetric.NewMetricSlice()
+	metrics.RemoveIf(func(m pmetric.Metric) bool {
+		if m.Name() != ms.metric {
+			return false
+		}
+		created := make(map[string]pmetric.Metric)
+		moveDataPoints(m, func(attrs pcommon.Map) (pmetric.Metric, bool) {
+			v, ok := attrs.Get(ms.attribute)
+			if !ok {
+				return pmetric.Metric{}, false
+			}
+			name, ok := ms.names[v.AsString()]
+			if !ok {
+				return pmetric.Metric{}, false
+			}
+			attrs.Remove(ms.attribute)
+			to, exist := created[name]
+			if !exist {
+				to = split.AppendEmpty()
+				copyMetricDescriptor(m, to)
+				to.SetName(name)
+				created[name] = to
+			}
+			return to, true
+		})
+		return dataPointCount(m) == 0
+	})
+	split.MoveAndAppendTo(metrics)
+}
+
+func (ms *MetricSplit) Rollback(metrics pmetric.MetricSlice) {
+	var (
+		merged  pmetric.Metric
+		exist   bool
+		created bool
+	)
+	for i := 0; i < metrics.Len(); i++ {
+		if m := metrics.At(i); m.Name() == ms.metric {
+			merged, exist = m, true
+			break
+		}
+	}
+	metrics.RemoveIf(func(m pmetric.Metric) bool {
+		value, ok := ms.values[m.Name()]
+		if !ok {
+			return false
+		}
+		if !exist {
+			merged, exist, created = pmetric.NewMetric(), true, true
+			copyMetricDescriptor(m, merged)
+			merged.SetName(ms.metric)
+		} else if merged.Type() != m.Type() {
+			return false
+		}
+		moveDataPoints(m, func(attrs pcommon.Map) (pmetric.Metric, bool) {
+			value.CopyTo(attrs.PutEmpty(ms.attribute))
+			return merged, true
+		})
+		return true
+	})
+	if created {
+		merged.MoveTo(metrics.AppendEmpty())
+	}
+}
+
+func NewMetricSplitSlice(splits ...*MetricSplit) *MetricSplitSlice {
+	values := new(MetricSplitSlice)
+	for _, s := range splits {
+		(*values) = append((*values), s)
+	}
+	return values
+}
+
+func (slice *MetricSplitSlice) Apply(metrics pmetric.MetricSlice) {
+	slice.do(StateSelectorApply, metrics)
+}
+
+func (slice *MetricSplitSlice) Rollback(metrics pmetric.MetricSlice) {
+	slice.do(StateSelectorRollback, metrics)
+}
+
+func (slice *MetricSplitSlice) do(ss StateSelector, metrics pmetric.MetricSlice) {
+	for i := 0; i < len((*slice)); i++ {
+		switch ss {
+		case StateSelectorApply:
+			(*slice)[i].Apply(metrics)
+		case StateSelectorRollback:
+			(*slice)[len((*slice))-i-1].Rollback(metrics)
+		}
+	}
+}
+
+// copyMetricDescriptor copies everything but the data points of a metric.
+func copyMetricDescriptor(from, to pmetric.Metric) {
+	to.SetName(from.Name())
+	to.SetDescription(from.Description())
+	to.SetUnit(from.Unit())
+	switch from.Type() {
+	case pmetric.MetricTypeGauge:
+		to.SetEmptyGauge()
+	case pmetric.MetricTypeSum:
+		to.SetEmptySum().SetAggregationTemporality(from.Sum().AggregationTemporality())
+		to.Sum().SetIsMonotonic(from.Sum().IsMonotonic())
+	case pmetric.MetricTypeHistogram:
+		to.SetEmptyHistogram().SetAggregationTemporality(from.Histogram().AggregationTemporality())
+	case pmetric.MetricTypeExponentialHistogram:
+		to.SetEmptyExponentialHistogram().SetAggregationTemporality(from.ExponentialHistogram().AggregationTemporality())
+	case pmetric.MetricTypeSummary:
+		to.SetEmptySummary()
+	}
+}
+
+// moveDataPoints moves every data point of the metric for which target returns true
+// into the returned metric, which must be of the same type.
+// target may modify the attributes of the data point it is given.
+func moveDataPoints(from pmetric.Metric, target func(attrs pcommon.Map) (pmetric.Metric, bool)) {
+	switch from.Type() {
+	case pmetric.MetricTypeGauge:
+		from.Gauge().DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+			to, ok := target(dp.Attributes())
+			if ok {
+				dp.MoveTo(to.Gauge().DataPoints().AppendEmpty())
+			}
+			return ok
+		})
+	case pmetric.MetricTypeSum:
+		from.Sum().DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+			to, ok := target(dp.Attributes())
+			if ok {
+				dp.MoveTo(to.Sum().DataPoints().AppendEmpty())
+			}
+			return ok
+		})
+	case pmetric.MetricTypeHistogram:
+		from.Histogram().DataPoints().RemoveIf(func(dp pmetric.HistogramDataPoint) bool {
+			to, ok := target(dp.Attributes())
+			if ok {
+				dp.MoveTo(to.Histogram().DataPoints().AppendEmpty())
+			}
+			return ok
+		})
+	case pmetric.MetricTypeExponentialHistogram:
+		from.ExponentialHistogram().DataPoints().RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool {
+			to, ok := target(dp.Attributes())
+			if ok {
+				dp.MoveTo(to.ExponentialHistogram().DataPoints().AppendEmpty())
+			}
+			return ok
+		})
+	case pmetric.MetricTypeSummary:
+		from.Summary().DataPoints().RemoveIf(func(dp pmetric.SummaryDataPoint) bool {
+			to, ok := target(dp.Attributes())
+			if ok {
+				dp.MoveTo(to.Summary().DataPoints().AppendEmpty())
+			}
+			return ok
+		})
+	}
+}
+
+func dataPointCount(m pmetric.Metric) int {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		return m.Gauge().DataPoints().Len()
+	case pmetric.MetricTypeSum:
+		return m.Sum().DataPoints().Len()
+	case pmetric.MetricTypeHistogram:
+		return m.Histogram().DataPoints().Len()
+	case pmetric.MetricTypeExponentialHistogram:
+		return m.ExponentialHistogram().DataPoints().Len()
+	case pmetric.MetricTypeSummary:
+		return m.Summary().DataPoints().Len()
+	}
+	return 0
+}
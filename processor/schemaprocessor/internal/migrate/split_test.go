@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/otel/schema/v1.0/types"
+	"go.opentelemetry.io/otel/schema/v1.1/ast"
+	types11 "go.opentelemetry.io/otel/schema/v1.1/types"
+)
+
+func testHelperPagingMetrics() pmetric.MetricSlice {
+	metrics := pmetric.NewMetricSlice()
+	m := metrics.AppendEmpty()
+	m.SetName("system.paging.operations")
+	m.SetUnit("{operations}")
+	m.SetEmptySum().SetIsMonotonic(true)
+	for _, direction := range []string{"in", "out", "unknown"} {
+		dp := m.Sum().DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("direction", direction)
+		dp.Attributes().PutStr("type", "major")
+		dp.SetIntValue(1)
+	}
+	other := metrics.AppendEmpty()
+	other.SetName("system.cpu.time")
+	other.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(1)
+	return metrics
+}
+
+func TestMetricSplit(t *testing.T) {
+	t.Parallel()
+
+	split := NewMetricSplit(ast.SplitMetric{
+		ApplyToMetric: "system.paging.operations",
+		ByAttribute:   "direction",
+		MetricsFromAttributes: map[types.MetricName]types11.AttributeValue{
+			"system.paging.operations.in":  "in",
+			"system.paging.operations.out": "out",
+		},
+	})
+
+	metrics := testHelperPagingMetrics()
+	split.Apply(metrics)
+
+	names := make(map[string]pmetric.Metric)
+	for i := 0; i < metrics.Len(); i++ {
+		names[metrics.At(i).Name()] = metrics.At(i)
+	}
+	require.Len(t, names, 4)
+
+	// the data points without a matching value stay in the original metric
+	orig := names["system.paging.operations"]
+	require.Equal(t, 1, orig.Sum().DataPoints().Len())
+	assert.Equal(t, map[string]any{"direction": "unknown", "type": "major"}, orig.Sum().DataPoints().At(0).Attributes().AsRaw())
+
+	for _, name := range []string{"system.paging.operations.in", "system.paging.operations.out"} {
+		m, ok := names[name]
+		require.True(t, ok, "Must have created %s", name)
+		assert.Equal(t, "{operations}", m.Unit())
+		assert.True(t, m.Sum().IsMonotonic())
+		require.Equal(t, 1, m.Sum().DataPoints().Len())
+		assert.Equal(t, map[string]any{"type": "major"}, m.Sum().DataPoints().At(0).Attributes().AsRaw())
+	}
+
+	split.Rollback(metrics)
+	require.Equal(t, 2, metrics.Len())
+	merged := metrics.At(0)
+	assert.Equal(t, "system.paging.operations", merged.Name())
+	directions := make(map[string]bool)
+	for i := 0; i < merged.Sum().DataPoints().Len(); i++ {
+		v, ok := merged.Sum().DataPoints().At(i).Attributes().Get("direction")
+		require.True(t, ok)
+		directions[v.Str()] = true
+	}
+	assert.Equal(t, map[string]bool{"in": true, "out": true, "unknown": true}, directions)
+}
+
+func TestMetricSplitRollbackWithoutOriginal(t *testing.T) {
+	t.Parallel()
+
+	split := NewMetricSplitSlice(NewMetricSplit(ast.SplitMetric{
+		ApplyToMetric: "system.paging.operations",
+		ByAttribute:   "direction",
+		MetricsFromAttributes: map[types.MetricName]types11.AttributeValue{
+			"system.paging.operations.in": "in",
+		},
+	}))
+
+	metrics := pmetric.NewMetricSlice()
+	m := metrics.AppendEmpty()
+	m.SetName("system.paging.operations.in")
+	m.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(3)
+
+	split.Rollback(metrics)
+	require.Equal(t, 1, metrics.Len())
+	assert.Equal(t, "system.paging.operations", metrics.At(0).Name())
+	assert.Equal(t, map[string]any{"direction": "in"}, metrics.At(0).Gauge().DataPoints().At(0).Attributes().AsRaw())
+
+	split.Apply(metrics)
+	require.Equal(t, 1, metrics.Len())
+	assert.Equal(t, "system.paging.operations.in", metrics.At(0).Name())
+	assert.Equal(t, 0, metrics.At(0).Gauge().DataPoints().At(0).Attributes().Len())
+}
@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// retryInterval is how long a schema file that could not be
+// loaded is skipped before it is requested again.
+const retryInterval = time.Minute
+
+var errNoProviders = errors.New("no schema providers configured")
+
+// Manager is responsible for loading and caching the translations
+// of the schema families that are targeted.
+type Manager interface {
+	// RequestTranslation will provide either the defined Translation
+	// if it is a known target, or, return a noop variation.
+	// In the event that the schema file of a matched Translation
+	// has not been loaded yet, there is a potential to block during this process.
+	// Otherwise, the translation will allow concurrent reads.
+	RequestTranslation(ctx context.Context, schemaURL string) Translation
+}
+
+type manager struct {
+	log       *zap.Logger
+	providers []Provider
+	// targets maps the schema family to its target version
+	targets map[string]*Version
+
+	mu           sync.Mutex
+	translations map[string]*cacheEntry
+}
+
+type cacheEntry struct {
+	// ready is closed once the other fields are set
+	ready   chan struct{}
+	tn      Translation
+	err     error
+	fetched time.Time
+}
+
+var _ Manager = (*manager)(nil)
+
+// NewManager creates a manager that translates signals to the targets,
+// the schema files are looked up in each of the providers in order.
+func NewManager(targets []string, log *zap.Logger, providers ...Provider) (Manager, error) {
+	m := &manager{
+		log:          log,
+		providers:    providers,
+		targets:      make(map[string]*Version, len(targets)),
+		translations: make(map[string]*cacheEntry),
+	}
+	for _, target := range targets {
+		family, version, err := GetFamilyAndVersion(target)
+		if err != nil {
+			return nil, err
+		}
+		m.targets[family] = version
+	}
+	return m, nil
+}
+
+func (m *manager) RequestTranslation(ctx context.Context, schemaURL string) Translation {
+	family, version, err := GetFamilyAndVersion(schemaURL)
+	if err != nil {
+		m.log.Debug("No valid schema url was provided, using no-op schema",
+			zap.String("schema-url", schemaURL), zap.Error(err),
+		)
+		return nopTranslation{}
+	}
+	target, ok := m.targets[family]
+	if !ok {
+		m.log.Debug("Not a known target, providing no-op schema",
+			zap.String("schema-url", schemaURL),
+		)
+		return nopTranslation{}
+	}
+
+	// The schema file of a version holds the changes of all the versions
+	// before it, so the newest of both versions is needed to translate between them.
+	fileVersion := target
+	if version.GreaterThan(target) {
+		fileVersion = version
+	}
+	tn, err := m.load(ctx, family, fileVersion, target)
+	if err != nil {
+		return nopTranslation{}
+	}
+	if !tn.SupportedVersion(version) {
+		m.log.Debug("Version is not defined in the schema file, using no-op schema",
+			zap.String("schema-url", schemaURL),
+		)
+		return nopTranslation{}
+	}
+	return tn
+}
+
+// load returns the translation to the target from the cache,
+// or reads it from the providers if it isn't cached.
+func (m *manager) load(ctx context.Context, family string, fileVersion, target *Version) (Translation, error) {
+	fileURL := family + "/" + fileVersion.String()
+
+	m.mu.Lock()
+	entry, exist := m.translations[fileURL]
+	if exist {
+		select {
+		case <-entry.ready:
+			if entry.err != nil && time.Since(entry.fetched) > retryInterval {
+				exist = false
+			}
+		default:
+		}
+	}
+	if !exist {
+		entry = &cacheEntry{ready: make(chan struct{})}
+		m.translations[fileURL] = entry
+	}
+	m.mu.Unlock()
+
+	if exist {
+		select {
+		case <-entry.ready:
+			return entry.tn, entry.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	defer close(entry.ready)
+	entry.tn, entry.err = m.fetch(ctx, fileURL, family+"/"+target.String())
+	entry.fetched = time.Now()
+	if entry.err != nil {
+		m.log.Warn("Failed to load schema file, signals of its family are not translated",
+			zap.String("schema-url", fileURL), zap.Error(entry.err),
+		)
+	}
+	return entry.tn, entry.err
+}
+
+func (m *manager) fetch(ctx context.Context, fileURL, targetURL string) (Translation, error) {
+	if len(m.providers) == 0 {
+		return nil, errNoProviders
+	}
+	var (
+		content io.Reader
+		errs    error
+	)
+	for _, p := range m.providers {
+		c, err := p.Lookup(ctx, fileURL)
+		if err == nil {
+			content = c
+			break
+		}
+		errs = multierr.Append(errs, err)
+	}
+	if content == nil {
+		return nil, errs
+	}
+	return newTranslatorFromReader(targetURL, content)
+}
@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package translation
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/fixture"
+)
+
+// testProvider serves the test schema file for any schema url of the
+// test family and records the schema urls that were looked up.
+type testProvider struct {
+	mu      sync.Mutex
+	lookups []string
+	err     error
+}
+
+func (tp *testProvider) Lookup(_ context.Context, schemaURL string) (io.Reader, error) {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	tp.lookups = append(tp.lookups, schemaURL)
+	if tp.err != nil {
+		return nil, tp.err
+	}
+	content, err := os.ReadFile(filepath.Join("testdata", "schema.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	return strings.NewReader(string(content)), nil
+}
+
+func (tp *testProvider) Lookups() []string {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	return append([]string(nil), tp.lookups...)
+}
+
+func TestManagerRequestTranslation(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name      string
+		target    string
+		schemaURL string
+		nop       bool
+		lookups   []string
+	}{
+		{
+			name:      "upgrade loads the target schema file",
+			target:    testSchemaV120,
+			schemaURL: testSchemaV100,
+			lookups:   []string{testSchemaV120},
+		},
+		{
+			name:      "downgrade loads the incoming schema file",
+			target:    testSchemaV100,
+			schemaURL: testSchemaV120,
+			lookups:   []string{testSchemaV120},
+		},
+		{
+			name:      "unknown family",
+			target:    testSchemaV120,
+			schemaURL: "https://opentelemetry.io/schemas/1.9.0",
+			nop:       true,
+		},
+		{
+			name:      "invalid schema url",
+			target:    testSchemaV120,
+			schemaURL: "not a schema url",
+			nop:       true,
+		},
+		{
+			name:      "version not in the schema file",
+			target:    testSchemaV120,
+			schemaURL: testFamily + "/0.1.0",
+			nop:       true,
+			lookups:   []string{testSchemaV120},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := &testProvider{}
+			m, err := NewManager([]string{tc.target}, zaptest.NewLogger(t), p)
+			require.NoError(t, err, "Must not error when creating the manager")
+
+			for i := 0; i < 2; i++ {
+				tn := m.RequestTranslation(context.Background(), tc.schemaURL)
+				if tc.nop {
+					assert.IsType(t, nopTranslation{}, tn)
+				} else {
+					assert.IsType(t, &translator{}, tn)
+				}
+			}
+			assert.Equal(t, tc.lookups, p.Lookups(), "Must only look up each schema file once")
+		})
+	}
+}
+
+func TestManagerProviderFailure(t *testing.T) {
+	t.Parallel()
+
+	failing := &testProvider{err: fmt.Errorf("connection refused")}
+	m, err := NewManager([]string{testSchemaV120}, zaptest.NewLogger(t), failing)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		assert.IsType(t, nopTranslation{}, m.RequestTranslation(context.Background(), testSchemaV100))
+	}
+	assert.Len(t, failing.Lookups(), 1, "Must not look up a failed schema file again before the retry interval")
+
+	fallback := &testProvider{}
+	m, err = NewManager([]string{testSchemaV120}, zaptest.NewLogger(t), failing, fallback)
+	require.NoError(t, err)
+	assert.IsType(t, &translator{}, m.RequestTranslation(context.Background(), testSchemaV100),
+		"Must use the next provider when one fails")
+
+	m, err = NewManager([]string{testSchemaV120}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	assert.IsType(t, nopTranslation{}, m.RequestTranslation(context.Background(), testSchemaV100))
+}
+
+func TestManagerInvalidTarget(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewManager([]string{"example.com/schemas/1.0.0"}, zaptest.NewLogger(t))
+	assert.ErrorIs(t, err, ErrInvalidFamily)
+}
+
+func TestManagerConcurrentRequests(t *testing.T) {
+	t.Parallel()
+
+	p := &testProvider{}
+	m, err := NewManager([]string{testSchemaV120}, zaptest.NewLogger(t), p)
+	require.NoError(t, err)
+
+	fixture.ParallelRaceCompute(t, 10, func() error {
+		if _, ok := m.RequestTranslation(context.Background(), testSchemaV100).(*translator); !ok {
+			return fmt.Errorf("expected a translation for %s", testSchemaV100)
+		}
+		return nil
+	})
+	assert.Len(t, p.Lookups(), 1, "Must only look up the schema file once")
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxSchemaFileSize limits how much of a schema file is read,
+// the published schema files are well below it.
+const maxSchemaFileSize = 10 << 20
+
+var errSchemaNotFound = errors.New("schema not found")
+
+// Provider allows for the content of a schema file to be
+// looked up from different sources.
+type Provider interface {
+	// Lookup will check the underlying provider to see if content exists
+	// for the provided schemaURL, in the event that it doesn't an error is returned.
+	Lookup(ctx context.Context, schemaURL string) (content io.Reader, err error)
+}
+
+type httpProvider struct {
+	client *http.Client
+}
+
+var _ Provider = (*httpProvider)(nil)
+
+// NewHTTPProvider returns a Provider that downloads the schema file
+// from the schema URL using the provided client.
+func NewHTTPProvider(client *http.Client) Provider {
+	return &httpProvider{client: client}
+}
+
+func (hp *httpProvider) Lookup(ctx context.Context, schemaURL string) (io.Reader, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, schemaURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := hp.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%q: %w", schemaURL, errSchemaNotFound)
+	default:
+		return nil, fmt.Errorf("invalid status code returned for %q: %d", schemaURL, resp.StatusCode)
+	}
+
+	content := bytes.NewBuffer(nil)
+	if _, err := io.Copy(content, io.LimitReader(resp.Body, maxSchemaFileSize)); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+type fileProvider struct {
+	dir string
+}
+
+var _ Provider = (*fileProvider)(nil)
+
+// NewFileProvider returns a Provider that reads schema files from the
+// directory, where each file is stored under the host and path of its
+// schema URL. For example, the schema file of https://opentelemetry.io/schemas/1.9.0
+// is read from <dir>/opentelemetry.io/schemas/1.9.0.
+func NewFileProvider(dir string) Provider {
+	return &fileProvider{dir: filepath.Clean(dir)}
+}
+
+func (fp *fileProvider) Lookup(_ context.Context, schemaURL string) (io.Reader, error) {
+	u, err := url.Parse(schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	p := filepath.Join(fp.dir, u.Host, filepath.FromSlash(u.Path))
+	if !strings.HasPrefix(p, fp.dir+string(filepath.Separator)) {
+		return nil, fmt.Errorf("%q is outside of the schema directory: %w", schemaURL, errSchemaNotFound)
+	}
+	content, err := os.ReadFile(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%q: %w", schemaURL, errSchemaNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(content), nil
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package translation
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPProvider(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/schemas/1.0.0":
+			_, _ = w.Write([]byte("file_format: 1.1.0"))
+		case "/schemas/1.1.0":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	p := NewHTTPProvider(server.Client())
+
+	content, err := p.Lookup(context.Background(), server.URL+"/schemas/1.0.0")
+	require.NoError(t, err, "Must not error when the schema exists")
+	b, err := io.ReadAll(content)
+	require.NoError(t, err)
+	assert.Equal(t, "file_format: 1.1.0", string(b))
+
+	_, err = p.Lookup(context.Background(), server.URL+"/schemas/1.1.0")
+	assert.Error(t, err, "Must error on an unexpected status code")
+
+	_, err = p.Lookup(context.Background(), server.URL+"/schemas/1.2.0")
+	assert.ErrorIs(t, err, errSchemaNotFound)
+}
+
+func TestFileProvider(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	dir := filepath.Join(root, "schemas")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "example.com", "schemas"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "example.com", "schemas", "1.0.0"), []byte("file_format: 1.1.0"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "secret"), []byte("secret"), 0o600))
+
+	p := NewFileProvider(dir)
+
+	content, err := p.Lookup(context.Background(), "https://example.com/schemas/1.0.0")
+	require.NoError(t, err, "Must not error when the schema exists")
+	b, err := io.ReadAll(content)
+	require.NoError(t, err)
+	assert.Equal(t, "file_format: 1.1.0", string(b))
+
+	_, err = p.Lookup(context.Background(), "https://example.com/schemas/1.1.0")
+	assert.ErrorIs(t, err, errSchemaNotFound)
+
+	_, err = p.Lookup(context.Background(), "https://example.com/../../secret")
+	assert.ErrorIs(t, err, errSchemaNotFound, "Must not read files outside of the directory")
+}
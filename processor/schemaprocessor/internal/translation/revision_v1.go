@@ -5,6 +5,7 @@ package translation // import "github.com/open-telemetry/opentelemetry-collector
 
 import (
 	"go.opentelemetry.io/otel/schema/v1.0/ast"
+	ast11 "go.opentelemetry.io/otel/schema/v1.1/ast"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/migrate"
 )
@@ -12,33 +13,41 @@ import (
 // RevisionV1 represents all changes that are to be
 // applied to a signal at a given version.
 type RevisionV1 struct {
-	ver              *Version
-	all              *migrate.AttributeChangeSetSlice
-	resource         *migrate.AttributeChangeSetSlice
-	spans            *migrate.ConditionalAttributeSetSlice
-	eventNames       *migrate.SignalNameChangeSlice
-	eventAttrsOnSpan *migrate.ConditionalAttributeSetSlice
-	eventAttrsOnName *migrate.ConditionalAttributeSetSlice
-	metricsAttrs     *migrate.ConditionalAttributeSetSlice
-	metricNames      *migrate.SignalNameChangeSlice
+	ver          *Version
+	all          *migrate.AttributeChangeSetSlice
+	resource     *migrate.AttributeChangeSetSlice
+	spans        *migrate.ConditionalAttributeSetSlice
+	eventNames   *migrate.SignalNameChangeSlice
+	eventAttrs   *migrate.MultiConditionalAttributeSetSlice
+	logs         *migrate.AttributeChangeSetSlice
+	metricsAttrs *migrate.ConditionalAttributeSetSlice
+	metricNames  *migrate.SignalNameChangeSlice
+	metricSplits *migrate.MetricSplitSlice
 }
 
+// The conditions that can be set on the changes of span event attributes.
+const (
+	conditionSpanName  = "span.name"
+	conditionEventName = "event.name"
+)
+
 // NewRevision processes the VersionDef and assigns the version to this revision
 // to allow sorting within a slice.
 // Since VersionDef uses custom types for various definitions, it isn't possible
 // to cast those values into the primitives so each has to be processed together.
 // Generics would be handy here.
-func NewRevision(ver *Version, def ast.VersionDef) *RevisionV1 {
+func NewRevision(ver *Version, def ast11.VersionDef) *RevisionV1 {
 	return &RevisionV1{
-		ver:              ver,
-		all:              newAttributeChangeSetSliceFromChanges(def.All),
-		resource:         newAttributeChangeSetSliceFromChanges(def.Resources),
-		spans:            newSpanConditionalAttributeSlice(def.Spans),
-		eventNames:       newSpanEventSignalSlice(def.SpanEvents),
-		eventAttrsOnSpan: newSpanEventConditionalSpans(def.SpanEvents),
-		eventAttrsOnName: newSpanEventConditionalNames(def.SpanEvents),
-		metricsAttrs:     newMetricConditionalSlice(def.Metrics),
-		metricNames:      newMetricNameSignalSlice(def.Metrics),
+		ver:          ver,
+		all:          newAttributeChangeSetSliceFromChanges(def.All),
+		resource:     newAttributeChangeSetSliceFromChanges(def.Resources),
+		spans:        newSpanConditionalAttributeSlice(def.Spans),
+		eventNames:   newSpanEventSignalSlice(def.SpanEvents),
+		eventAttrs:   newSpanEventConditionalAttributeSlice(def.SpanEvents),
+		logs:         newLogAttributeChangeSetSlice(def.Logs),
+		metricsAttrs: newMetricConditionalSlice(def.Metrics),
+		metricNames:  newMetricNameSignalSlice(def.Metrics),
+		metricSplits: newMetricSplitSlice(def.Metrics),
 	}
 }
 
@@ -75,27 +84,33 @@ func newSpanEventSignalSlice(events ast.SpanEvents) *migrate.SignalNameChangeSli
 	return migrate.NewSignalNameChangeSlice(values...)
 }
 
-func newSpanEventConditionalSpans(events ast.SpanEvents) *migrate.ConditionalAttributeSetSlice {
-	values := make([]*migrate.ConditionalAttributeSet, 0, 10)
+func newSpanEventConditionalAttributeSlice(events ast.SpanEvents) *migrate.MultiConditionalAttributeSetSlice {
+	values := make([]*migrate.MultiConditionalAttributeSet, 0, 10)
 	for _, ch := range events.Changes {
 		if rename := ch.RenameAttributes; rename != nil {
-			values = append(values, migrate.NewConditionalAttributeSet(rename.AttributeMap, rename.ApplyToSpans...))
+			values = append(values, migrate.NewMultiConditionalAttributeSet(
+				rename.AttributeMap,
+				map[string][]string{
+					conditionSpanName:  toStrings(rename.ApplyToSpans),
+					conditionEventName: toStrings(rename.ApplyToEvents),
+				},
+			))
 		}
 	}
-	return migrate.NewConditionalAttributeSetSlice(values...)
+	return migrate.NewMultiConditionalAttributeSetSlice(values...)
 }
 
-func newSpanEventConditionalNames(events ast.SpanEvents) *migrate.ConditionalAttributeSetSlice {
-	values := make([]*migrate.ConditionalAttributeSet, 0, 10)
-	for _, ch := range events.Changes {
-		if rename := ch.RenameAttributes; rename != nil {
-			values = append(values, migrate.NewConditionalAttributeSet(rename.AttributeMap, rename.ApplyToEvents...))
+func newLogAttributeChangeSetSlice(logs ast.Logs) *migrate.AttributeChangeSetSlice {
+	values := make([]*migrate.AttributeChangeSet, 0, 10)
+	for _, ch := range logs.Changes {
+		if renamed := ch.RenameAttributes; renamed != nil {
+			values = append(values, migrate.NewAttributeChangeSet(renamed.AttributeMap))
 		}
 	}
-	return migrate.NewConditionalAttributeSetSlice(values...)
+	return migrate.NewAttributeChangeSetSlice(values...)
 }
 
-func newMetricConditionalSlice(metrics ast.Metrics) *migrate.ConditionalAttributeSetSlice {
+func newMetricConditionalSlice(metrics ast11.Metrics) *migrate.ConditionalAttributeSetSlice {
 	values := make([]*migrate.ConditionalAttributeSet, 0, 10)
 	for _, ch := range metrics.Changes {
 		if rename := ch.RenameAttributes; rename != nil {
@@ -105,10 +120,30 @@ func newMetricConditionalSlice(metrics ast.Metrics) *migrate.ConditionalAttribut
 	return migrate.NewConditionalAttributeSetSlice(values...)
 }
 
-func newMetricNameSignalSlice(metrics ast.Metrics) *migrate.SignalNameChangeSlice {
+func newMetricNameSignalSlice(metrics ast11.Metrics) *migrate.SignalNameChangeSlice {
 	values := make([]*migrate.SignalNameChange, 0, 10)
 	for _, ch := range metrics.Changes {
-		values = append(values, migrate.NewSignalNameChange(ch.RenameMetrics))
+		if len(ch.RenameMetrics) > 0 {
+			values = append(values, migrate.NewSignalNameChange(ch.RenameMetrics))
+		}
 	}
 	return migrate.NewSignalNameChangeSlice(values...)
 }
+
+func newMetricSplitSlice(metrics ast11.Metrics) *migrate.MetricSplitSlice {
+	values := make([]*migrate.MetricSplit, 0, 10)
+	for _, ch := range metrics.Changes {
+		if split := ch.Split; split != nil {
+			values = append(values, migrate.NewMetricSplit(*split))
+		}
+	}
+	return migrate.NewMetricSplitSlice(values...)
+}
+
+func toStrings[S ~string](values []S) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		out = append(out, string(v))
+	}
+	return out
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/otel/schema/v1.0/ast"
 	"go.opentelemetry.io/otel/schema/v1.0/types"
+	ast11 "go.opentelemetry.io/otel/schema/v1.1/ast"
+	types11 "go.opentelemetry.io/otel/schema/v1.1/types"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/migrate"
 )
@@ -18,29 +20,30 @@ func TestNewRevision(t *testing.T) {
 	for _, tc := range []struct {
 		name         string
 		inVersion    *Version
-		inDefinition ast.VersionDef
+		inDefinition ast11.VersionDef
 		expect       *RevisionV1
 	}{
 		{
 			name:         "no definition defined",
 			inVersion:    &Version{1, 1, 1},
-			inDefinition: ast.VersionDef{},
+			inDefinition: ast11.VersionDef{},
 			expect: &RevisionV1{
-				ver:              &Version{1, 1, 1},
-				all:              migrate.NewAttributeChangeSetSlice(),
-				resource:         migrate.NewAttributeChangeSetSlice(),
-				spans:            migrate.NewConditionalAttributeSetSlice(),
-				eventNames:       migrate.NewSignalNameChangeSlice(),
-				eventAttrsOnSpan: migrate.NewConditionalAttributeSetSlice(),
-				eventAttrsOnName: migrate.NewConditionalAttributeSetSlice(),
-				metricsAttrs:     migrate.NewConditionalAttributeSetSlice(),
-				metricNames:      migrate.NewSignalNameChangeSlice(),
+				ver:          &Version{1, 1, 1},
+				all:          migrate.NewAttributeChangeSetSlice(),
+				resource:     migrate.NewAttributeChangeSetSlice(),
+				spans:        migrate.NewConditionalAttributeSetSlice(),
+				eventNames:   migrate.NewSignalNameChangeSlice(),
+				eventAttrs:   migrate.NewMultiConditionalAttributeSetSlice(),
+				logs:         migrate.NewAttributeChangeSetSlice(),
+				metricsAttrs: migrate.NewConditionalAttributeSetSlice(),
+				metricNames:  migrate.NewSignalNameChangeSlice(),
+				metricSplits: migrate.NewMetricSplitSlice(),
 			},
 		},
 		{
 			name:      "complete version definition used",
 			inVersion: &Version{1, 0, 0},
-			inDefinition: ast.VersionDef{
+			inDefinition: ast11.VersionDef{
 				All: ast.Attributes{
 					Changes: []ast.AttributeChange{
 						{
@@ -124,8 +127,8 @@ func TestNewRevision(t *testing.T) {
 						},
 					},
 				},
-				Metrics: ast.Metrics{
-					Changes: []ast.MetricsChange{
+				Metrics: ast11.Metrics{
+					Changes: []ast11.MetricsChange{
 						{
 							RenameMetrics: map[types.MetricName]types.MetricName{
 								"service.computed.uptime": "service.uptime",
@@ -139,6 +142,16 @@ func TestNewRevision(t *testing.T) {
 								},
 							},
 						},
+						{
+							Split: &ast11.SplitMetric{
+								ApplyToMetric: "system.paging.operations",
+								ByAttribute:   "direction",
+								MetricsFromAttributes: map[types.MetricName]types11.AttributeValue{
+									"system.paging.operations.in":  "in",
+									"system.paging.operations.out": "out",
+								},
+							},
+						},
 					},
 				},
 			},
@@ -171,22 +184,22 @@ func TestNewRevision(t *testing.T) {
 						"started": "application started",
 					}),
 				),
-				eventAttrsOnSpan: migrate.NewConditionalAttributeSetSlice(
-					migrate.NewConditionalAttributeSet(
+				eventAttrs: migrate.NewMultiConditionalAttributeSetSlice(
+					migrate.NewMultiConditionalAttributeSet(
 						map[string]string{
 							"service.app.name": "service.name",
 						},
-						"service running",
-					),
-				),
-				eventAttrsOnName: migrate.NewConditionalAttributeSetSlice(
-					migrate.NewConditionalAttributeSet(
-						map[string]string{
-							"service.app.name": "service.name",
+						map[string][]string{
+							"span.name":  {"service running"},
+							"event.name": {"service errored"},
 						},
-						"service errored",
 					),
 				),
+				logs: migrate.NewAttributeChangeSetSlice(
+					migrate.NewAttributeChangeSet(map[string]string{
+						"ERROR": "error",
+					}),
+				),
 				metricsAttrs: migrate.NewConditionalAttributeSetSlice(
 					migrate.NewConditionalAttributeSet(
 						map[string]string{
@@ -200,6 +213,16 @@ func TestNewRevision(t *testing.T) {
 						"service.computed.uptime": "service.uptime",
 					}),
 				),
+				metricSplits: migrate.NewMetricSplitSlice(
+					migrate.NewMetricSplit(ast11.SplitMetric{
+						ApplyToMetric: "system.paging.operations",
+						ByAttribute:   "direction",
+						MetricsFromAttributes: map[types.MetricName]types11.AttributeValue{
+							"system.paging.operations.in":  "in",
+							"system.paging.operations.out": "out",
+						},
+					}),
+				),
 			},
 		},
 	} {
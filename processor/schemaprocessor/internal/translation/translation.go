@@ -0,0 +1,322 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	schema "go.opentelemetry.io/otel/schema/v1.1"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/alias"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/migrate"
+)
+
+// Translation defines the complete abstraction of a schema translation file
+// that is defined as part of the https://opentelemetry.io/docs/specs/otel/schemas/file_format_v1.1.0/
+// and translates signals of its schema family to the target version.
+type Translation interface {
+	// SupportedVersion checks to see if the provided version is defined as part
+	// of this translation since it is useful to know if the translation is missing
+	// updates.
+	SupportedVersion(v *Version) bool
+
+	// ApplyAllResourceChanges will modify the resource part of the incoming signals
+	// and set the schema url of the resource to the target version.
+	ApplyAllResourceChanges(in alias.Resource, inSchemaURL string) error
+
+	// ApplyScopeSpanChanges will modify all spans and span events within the scope
+	// from the version of the inSchemaURL to the target version.
+	ApplyScopeSpanChanges(in ptrace.ScopeSpans, inSchemaURL string) error
+
+	// ApplyScopeLogChanges will modify all logs within the scope
+	// from the version of the inSchemaURL to the target version.
+	ApplyScopeLogChanges(in plog.ScopeLogs, inSchemaURL string) error
+
+	// ApplyScopeMetricChanges will modify all metrics within the scope
+	// from the version of the inSchemaURL to the target version.
+	ApplyScopeMetricChanges(in pmetric.ScopeMetrics, inSchemaURL string) error
+}
+
+type translator struct {
+	targetSchemaURL string
+	target          *Version
+	// revisions are sorted by ascending version
+	revisions []*RevisionV1
+	indexes   map[Version]int
+}
+
+var _ Translation = (*translator)(nil)
+
+// newTranslatorFromReader parses the content of a schema file of the same
+// schema family as targetSchemaURL.
+func newTranslatorFromReader(targetSchemaURL string, content io.Reader) (*translator, error) {
+	family, target, err := GetFamilyAndVersion(targetSchemaURL)
+	if err != nil {
+		return nil, err
+	}
+	def, err := schema.Parse(content)
+	if err != nil {
+		return nil, err
+	}
+	defFamily, _, err := GetFamilyAndVersion(def.SchemaURL)
+	if err != nil {
+		return nil, err
+	}
+	if defFamily != family {
+		return nil, fmt.Errorf("schema file of %q does not match the family %q: %w", def.SchemaURL, family, ErrInvalidFamily)
+	}
+
+	t := &translator{
+		targetSchemaURL: targetSchemaURL,
+		target:          target,
+		revisions:       make([]*RevisionV1, 0, len(def.Versions)),
+		indexes:         make(map[Version]int, len(def.Versions)),
+	}
+	for v, changes := range def.Versions {
+		ver, err := NewVersion(string(v))
+		if err != nil {
+			return nil, err
+		}
+		t.revisions = append(t.revisions, NewRevision(ver, changes))
+	}
+	sort.Slice(t.revisions, func(i, j int) bool {
+		return t.revisions[i].ver.LessThan(t.revisions[j].ver)
+	})
+	for i, rev := range t.revisions {
+		t.indexes[*rev.ver] = i
+	}
+	return t, nil
+}
+
+func (t *translator) SupportedVersion(v *Version) bool {
+	if v.Equal(t.target) {
+		return true
+	}
+	_, ok := t.indexes[*v]
+	return ok
+}
+
+// iterator returns the revisions that are needed to translate signals
+// of the version of the schema url to the target version,
+// in the order they need to be applied.
+func (t *translator) iterator(schemaURL string) (migrate.StateSelector, []*RevisionV1, error) {
+	_, from, err := GetFamilyAndVersion(schemaURL)
+	if err != nil {
+		return 0, nil, err
+	}
+	if !t.SupportedVersion(from) {
+		return 0, nil, fmt.Errorf("unsupported version %s: %w", from, ErrInvalidVersion)
+	}
+	var (
+		ss   = migrate.StateSelectorApply
+		revs []*RevisionV1
+	)
+	switch {
+	case from.LessThan(t.target):
+		for _, rev := range t.revisions {
+			if rev.ver.GreaterThan(from) && !rev.ver.GreaterThan(t.target) {
+				revs = append(revs, rev)
+			}
+		}
+	case from.GreaterThan(t.target):
+		ss = migrate.StateSelectorRollback
+		for i := len(t.revisions) - 1; i >= 0; i-- {
+			if rev := t.revisions[i]; rev.ver.GreaterThan(t.target) && !rev.ver.GreaterThan(from) {
+				revs = append(revs, rev)
+			}
+		}
+	}
+	return ss, revs, nil
+}
+
+func (t *translator) ApplyAllResourceChanges(in alias.Resource, inSchemaURL string) error {
+	ss, revs, err := t.iterator(inSchemaURL)
+	if err != nil {
+		return err
+	}
+	var errs error
+	for _, rev := range revs {
+		attrs := in.Resource().Attributes()
+		switch ss {
+		case migrate.StateSelectorApply:
+			errs = multierr.Append(errs, rev.all.Apply(attrs))
+			errs = multierr.Append(errs, rev.resource.Apply(attrs))
+		case migrate.StateSelectorRollback:
+			errs = multierr.Append(errs, rev.resource.Rollback(attrs))
+			errs = multierr.Append(errs, rev.all.Rollback(attrs))
+		}
+	}
+	in.SetSchemaUrl(t.targetSchemaURL)
+	return errs
+}
+
+func (t *translator) ApplyScopeSpanChanges(in ptrace.ScopeSpans, inSchemaURL string) error {
+	ss, revs, err := t.iterator(inSchemaURL)
+	if err != nil {
+		return err
+	}
+	var errs error
+	for _, rev := range revs {
+		for i := 0; i < in.Spans().Len(); i++ {
+			errs = multierr.Append(errs, applySpanChanges(ss, rev, in.Spans().At(i)))
+		}
+	}
+	if in.SchemaUrl() != "" {
+		in.SetSchemaUrl(t.targetSchemaURL)
+	}
+	return errs
+}
+
+func applySpanChanges(ss migrate.StateSelector, rev *RevisionV1, span ptrace.Span) (errs error) {
+	switch ss {
+	case migrate.StateSelectorApply:
+		errs = multierr.Append(errs, rev.all.Apply(span.Attributes()))
+		errs = multierr.Append(errs, rev.spans.Apply(span.Attributes(), span.Name()))
+	case migrate.StateSelectorRollback:
+		errs = multierr.Append(errs, rev.spans.Rollback(span.Attributes(), span.Name()))
+		errs = multierr.Append(errs, rev.all.Rollback(span.Attributes()))
+	}
+	for i := 0; i < span.Events().Len(); i++ {
+		event := span.Events().At(i)
+		switch ss {
+		case migrate.StateSelectorApply:
+			errs = multierr.Append(errs, rev.all.Apply(event.Attributes()))
+			errs = multierr.Append(errs, rev.eventAttrs.Apply(event.Attributes(), eventConditions(span, event)))
+			rev.eventNames.Apply(event)
+		case migrate.StateSelectorRollback:
+			rev.eventNames.Rollback(event)
+			errs = multierr.Append(errs, rev.eventAttrs.Rollback(event.Attributes(), eventConditions(span, event)))
+			errs = multierr.Append(errs, rev.all.Rollback(event.Attributes()))
+		}
+	}
+	return errs
+}
+
+func eventConditions(span ptrace.Span, event ptrace.SpanEvent) map[string]string {
+	return map[string]string{
+		conditionSpanName:  span.Name(),
+		conditionEventName: event.Name(),
+	}
+}
+
+func (t *translator) ApplyScopeLogChanges(in plog.ScopeLogs, inSchemaURL string) error {
+	ss, revs, err := t.iterator(inSchemaURL)
+	if err != nil {
+		return err
+	}
+	var errs error
+	for _, rev := range revs {
+		for i := 0; i < in.LogRecords().Len(); i++ {
+			attrs := in.LogRecords().At(i).Attributes()
+			switch ss {
+			case migrate.StateSelectorApply:
+				errs = multierr.Append(errs, rev.all.Apply(attrs))
+				errs = multierr.Append(errs, rev.logs.Apply(attrs))
+			case migrate.StateSelectorRollback:
+				errs = multierr.Append(errs, rev.logs.Rollback(attrs))
+				errs = multierr.Append(errs, rev.all.Rollback(attrs))
+			}
+		}
+	}
+	if in.SchemaUrl() != "" {
+		in.SetSchemaUrl(t.targetSchemaURL)
+	}
+	return errs
+}
+
+func (t *translator) ApplyScopeMetricChanges(in pmetric.ScopeMetrics, inSchemaURL string) error {
+	ss, revs, err := t.iterator(inSchemaURL)
+	if err != nil {
+		return err
+	}
+	var errs error
+	for _, rev := range revs {
+		if ss == migrate.StateSelectorRollback {
+			rev.metricSplits.Rollback(in.Metrics())
+		}
+		for i := 0; i < in.Metrics().Len(); i++ {
+			metric := in.Metrics().At(i)
+			switch ss {
+			case migrate.StateSelectorApply:
+				errs = multierr.Append(errs, forEachDataPointAttributes(metric, func(attrs pcommon.Map) (errs error) {
+					errs = multierr.Append(errs, rev.all.Apply(attrs))
+					return multierr.Append(errs, rev.metricsAttrs.Apply(attrs, metric.Name()))
+				}))
+				rev.metricNames.Apply(metric)
+			case migrate.StateSelectorRollback:
+				rev.metricNames.Rollback(metric)
+				errs = multierr.Append(errs, forEachDataPointAttributes(metric, func(attrs pcommon.Map) (errs error) {
+					errs = multierr.Append(errs, rev.metricsAttrs.Rollback(attrs, metric.Name()))
+					return multierr.Append(errs, rev.all.Rollback(attrs))
+				}))
+			}
+		}
+		if ss == migrate.StateSelectorApply {
+			rev.metricSplits.Apply(in.Metrics())
+		}
+	}
+	if in.SchemaUrl() != "" {
+		in.SetSchemaUrl(t.targetSchemaURL)
+	}
+	return errs
+}
+
+func forEachDataPointAttributes(m pmetric.Metric, fn func(attrs pcommon.Map) error) (errs error) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		for i := 0; i < m.Gauge().DataPoints().Len(); i++ {
+			errs = multierr.Append(errs, fn(m.Gauge().DataPoints().At(i).Attributes()))
+		}
+	case pmetric.MetricTypeSum:
+		for i := 0; i < m.Sum().DataPoints().Len(); i++ {
+			errs = multierr.Append(errs, fn(m.Sum().DataPoints().At(i).Attributes()))
+		}
+	case pmetric.MetricTypeHistogram:
+		for i := 0; i < m.Histogram().DataPoints().Len(); i++ {
+			errs = multierr.Append(errs, fn(m.Histogram().DataPoints().At(i).Attributes()))
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for i := 0; i < m.ExponentialHistogram().DataPoints().Len(); i++ {
+			errs = multierr.Append(errs, fn(m.ExponentialHistogram().DataPoints().At(i).Attributes()))
+		}
+	case pmetric.MetricTypeSummary:
+		for i := 0; i < m.Summary().DataPoints().Len(); i++ {
+			errs = multierr.Append(errs, fn(m.Summary().DataPoints().At(i).Attributes()))
+		}
+	}
+	return errs
+}
+
+// nopTranslation is used for signals that are not of a target schema family,
+// or that can not be translated, and leaves them unchanged.
+type nopTranslation struct{}
+
+var _ Translation = (*nopTranslation)(nil)
+
+func (nopTranslation) SupportedVersion(_ *Version) bool {
+	return false
+}
+
+func (nopTranslation) ApplyAllResourceChanges(_ alias.Resource, _ string) error {
+	return nil
+}
+
+func (nopTranslation) ApplyScopeSpanChanges(_ ptrace.ScopeSpans, _ string) error {
+	return nil
+}
+
+func (nopTranslation) ApplyScopeLogChanges(_ plog.ScopeLogs, _ string) error {
+	return nil
+}
+
+func (nopTranslation) ApplyScopeMetricChanges(_ pmetric.ScopeMetrics, _ string) error {
+	return nil
+}
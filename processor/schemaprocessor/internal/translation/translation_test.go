@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package translation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	testFamily     = "https://example.com/schemas"
+	testSchemaV100 = testFamily + "/1.0.0"
+	testSchemaV110 = testFamily + "/1.1.0"
+	testSchemaV120 = testFamily + "/1.2.0"
+)
+
+func newTestTranslator(t *testing.T, target string) *translator {
+	f, err := os.Open(filepath.Join("testdata", "schema.yaml"))
+	require.NoError(t, err, "Must be able to read the test schema")
+	defer f.Close()
+
+	tn, err := newTranslatorFromReader(target, f)
+	require.NoError(t, err, "Must not error when creating the translator")
+	return tn
+}
+
+func testHelperTracesV100() ptrace.Traces {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.SetSchemaUrl(testSchemaV100)
+	rs.Resource().Attributes().PutStr("host.ip", "10.0.0.1")
+	rs.Resource().Attributes().PutInt("container.restart", 1)
+
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.SetSchemaUrl(testSchemaV100)
+	get := ss.Spans().AppendEmpty()
+	get.SetName("GET /")
+	get.Attributes().PutInt("http.status", 200)
+	get.Attributes().PutInt("container.restart", 1)
+	ev := get.Events().AppendEmpty()
+	ev.SetName("exception.raised")
+	ev.Attributes().PutStr("message", "boom")
+
+	post := ss.Spans().AppendEmpty()
+	post.SetName("POST /")
+	post.Attributes().PutInt("http.status", 500)
+	return td
+}
+
+func TestTranslatorTraces(t *testing.T) {
+	t.Parallel()
+
+	td := testHelperTracesV100()
+	rs := td.ResourceSpans().At(0)
+	ss := rs.ScopeSpans().At(0)
+
+	up := newTestTranslator(t, testSchemaV120)
+	require.NoError(t, up.ApplyAllResourceChanges(rs, rs.SchemaUrl()))
+	require.NoError(t, up.ApplyScopeSpanChanges(ss, ss.SchemaUrl()))
+
+	assert.Equal(t, testSchemaV120, rs.SchemaUrl())
+	assert.Equal(t, testSchemaV120, ss.SchemaUrl())
+	assert.Equal(t, map[string]any{
+		"host.address":            "10.0.0.1",
+		"container.restart.count": int64(1),
+	}, rs.Resource().Attributes().AsRaw())
+
+	get, post := ss.Spans().At(0), ss.Spans().At(1)
+	assert.Equal(t, map[string]any{
+		"http.status_code":        int64(200),
+		"container.restart.count": int64(1),
+	}, get.Attributes().AsRaw())
+	assert.Equal(t, "exception", get.Events().At(0).Name())
+	assert.Equal(t, map[string]any{"exception.message": "boom"}, get.Events().At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"http.status": int64(500)}, post.Attributes().AsRaw(), "Must only update the matched spans")
+
+	down := newTestTranslator(t, testSchemaV100)
+	require.NoError(t, down.ApplyAllResourceChanges(rs, rs.SchemaUrl()))
+	require.NoError(t, down.ApplyScopeSpanChanges(ss, ss.SchemaUrl()))
+	assert.Equal(t, testHelperTracesV100(), td, "Must restore the original traces")
+}
+
+func TestTranslatorLogs(t *testing.T) {
+	t.Parallel()
+
+	ld := plog.NewLogs()
+	sl := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	sl.LogRecords().AppendEmpty().Attributes().PutInt("process.exit", 1)
+
+	up := newTestTranslator(t, testSchemaV120)
+	require.NoError(t, up.ApplyScopeLogChanges(sl, testSchemaV110))
+	assert.Equal(t, "", sl.SchemaUrl(), "Must not set the schema url of a scope that had none")
+	assert.Equal(t, map[string]any{"process.exit": int64(1)}, sl.LogRecords().At(0).Attributes().AsRaw(),
+		"Must not apply the changes of the version already in use")
+
+	require.NoError(t, up.ApplyScopeLogChanges(sl, testSchemaV100))
+	assert.Equal(t, map[string]any{"process.exit_code": int64(1)}, sl.LogRecords().At(0).Attributes().AsRaw())
+
+	down := newTestTranslator(t, testSchemaV100)
+	require.NoError(t, down.ApplyScopeLogChanges(sl, testSchemaV120))
+	assert.Equal(t, map[string]any{"process.exit": int64(1)}, sl.LogRecords().At(0).Attributes().AsRaw())
+}
+
+func testHelperMetricsV100() pmetric.ScopeMetrics {
+	sm := pmetric.NewScopeMetrics()
+	sm.SetSchemaUrl(testSchemaV100)
+
+	cpu := sm.Metrics().AppendEmpty()
+	cpu.SetName("system.cpu.utilization")
+	dp := cpu.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("state", "idle")
+	dp.Attributes().PutInt("container.restart", 1)
+	dp.SetDoubleValue(0.5)
+
+	paging := sm.Metrics().AppendEmpty()
+	paging.SetName("system.paging.operations")
+	paging.SetEmptySum().SetIsMonotonic(true)
+	for _, direction := range []string{"in", "out"} {
+		dp := paging.Sum().DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("direction", direction)
+		dp.SetIntValue(10)
+	}
+	return sm
+}
+
+// testHelperMetricNames returns the attributes of the data points by metric name
+func testHelperMetricNames(sm pmetric.ScopeMetrics) map[string][]map[string]any {
+	out := make(map[string][]map[string]any)
+	for i := 0; i < sm.Metrics().Len(); i++ {
+		m := sm.Metrics().At(i)
+		_ = forEachDataPointAttributes(m, func(attrs pcommon.Map) error {
+			out[m.Name()] = append(out[m.Name()], attrs.AsRaw())
+			return nil
+		})
+	}
+	return out
+}
+
+func TestTranslatorMetrics(t *testing.T) {
+	t.Parallel()
+
+	sm := testHelperMetricsV100()
+
+	up := newTestTranslator(t, testSchemaV120)
+	require.NoError(t, up.ApplyScopeMetricChanges(sm, sm.SchemaUrl()))
+	assert.Equal(t, testSchemaV120, sm.SchemaUrl())
+	assert.Equal(t, map[string][]map[string]any{
+		"system.cpu.usage": {
+			{"cpu.state": "idle", "container.restart.count": int64(1)},
+		},
+		"system.paging.operations.in":  {{}},
+		"system.paging.operations.out": {{}},
+	}, testHelperMetricNames(sm))
+
+	down := newTestTranslator(t, testSchemaV100)
+	require.NoError(t, down.ApplyScopeMetricChanges(sm, sm.SchemaUrl()))
+	assert.Equal(t, testSchemaV100, sm.SchemaUrl())
+	assert.Equal(t, testHelperMetricNames(testHelperMetricsV100()), testHelperMetricNames(sm),
+		"Must restore the original metrics")
+}
+
+func TestTranslatorSupportedVersion(t *testing.T) {
+	t.Parallel()
+
+	tn := newTestTranslator(t, testSchemaV110)
+	for _, tc := range []struct {
+		version   *Version
+		supported bool
+	}{
+		{version: &Version{1, 0, 0}, supported: true},
+		{version: &Version{1, 1, 0}, supported: true},
+		{version: &Version{1, 2, 0}, supported: true},
+		{version: &Version{1, 3, 0}, supported: false},
+		{version: &Version{0, 9, 0}, supported: false},
+	} {
+		assert.Equal(t, tc.supported, tn.SupportedVersion(tc.version), "Version %s", tc.version)
+	}
+
+	sl := plog.NewScopeLogs()
+	assert.ErrorIs(t, tn.ApplyScopeLogChanges(sl, testFamily+"/1.3.0"), ErrInvalidVersion)
+}
+
+func TestNewTranslatorErrors(t *testing.T) {
+	t.Parallel()
+
+	content, err := os.ReadFile(filepath.Join("testdata", "schema.yaml"))
+	require.NoError(t, err)
+
+	_, err = newTranslatorFromReader("https://opentelemetry.io/schemas/1.2.0", strings.NewReader(string(content)))
+	assert.ErrorIs(t, err, ErrInvalidFamily, "Must error when the schema file is of another family")
+
+	_, err = newTranslatorFromReader(testSchemaV120, strings.NewReader("file_format: 2.0.0"))
+	assert.Error(t, err, "Must error on unsupported file formats")
+
+	_, err = newTranslatorFromReader("example.com/schemas/1.2.0", strings.NewReader(string(content)))
+	assert.ErrorIs(t, err, ErrInvalidFamily)
+}
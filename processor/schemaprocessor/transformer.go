@@ -12,12 +12,17 @@ import (
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
 )
 
 type transformer struct {
-	targets []string
-	log     *zap.Logger
+	cfg       *Config
+	telemetry component.TelemetrySettings
+	log       *zap.Logger
+	manager   translation.Manager
 }
 
 func newTransformer(
@@ -30,28 +35,109 @@ func newTransformer(
 		return nil, errors.New("invalid configuration provided")
 	}
 	return &transformer{
-		log:     set.Logger,
-		targets: cfg.Targets,
+		cfg:       cfg,
+		telemetry: set.TelemetrySettings,
+		log:       set.Logger,
 	}, nil
 }
 
-func (t transformer) processLogs(_ context.Context, ld plog.Logs) (plog.Logs, error) {
-	return ld, nil
+func (t *transformer) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	var errs error
+	for rl := 0; rl < ld.ResourceLogs().Len(); rl++ {
+		rLogs := ld.ResourceLogs().At(rl)
+		resourceSchemaURL := rLogs.SchemaUrl()
+		if resourceSchemaURL != "" {
+			tn := t.manager.RequestTranslation(ctx, resourceSchemaURL)
+			errs = multierr.Append(errs, tn.ApplyAllResourceChanges(rLogs, resourceSchemaURL))
+		}
+		for sl := 0; sl < rLogs.ScopeLogs().Len(); sl++ {
+			logs := rLogs.ScopeLogs().At(sl)
+			schemaURL := scopeSchemaURL(logs.SchemaUrl(), resourceSchemaURL)
+			if schemaURL == "" {
+				continue
+			}
+			tn := t.manager.RequestTranslation(ctx, schemaURL)
+			errs = multierr.Append(errs, tn.ApplyScopeLogChanges(logs, schemaURL))
+		}
+	}
+	return ld, errs
+}
+
+func (t *transformer) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	var errs error
+	for rm := 0; rm < md.ResourceMetrics().Len(); rm++ {
+		rMetrics := md.ResourceMetrics().At(rm)
+		resourceSchemaURL := rMetrics.SchemaUrl()
+		if resourceSchemaURL != "" {
+			tn := t.manager.RequestTranslation(ctx, resourceSchemaURL)
+			errs = multierr.Append(errs, tn.ApplyAllResourceChanges(rMetrics, resourceSchemaURL))
+		}
+		for sm := 0; sm < rMetrics.ScopeMetrics().Len(); sm++ {
+			metrics := rMetrics.ScopeMetrics().At(sm)
+			schemaURL := scopeSchemaURL(metrics.SchemaUrl(), resourceSchemaURL)
+			if schemaURL == "" {
+				continue
+			}
+			tn := t.manager.RequestTranslation(ctx, schemaURL)
+			errs = multierr.Append(errs, tn.ApplyScopeMetricChanges(metrics, schemaURL))
+		}
+	}
+	return md, errs
 }
 
-func (t transformer) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
-	return md, nil
+func (t *transformer) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	var errs error
+	for rs := 0; rs < td.ResourceSpans().Len(); rs++ {
+		rSpans := td.ResourceSpans().At(rs)
+		resourceSchemaURL := rSpans.SchemaUrl()
+		if resourceSchemaURL != "" {
+			tn := t.manager.RequestTranslation(ctx, resourceSchemaURL)
+			errs = multierr.Append(errs, tn.ApplyAllResourceChanges(rSpans, resourceSchemaURL))
+		}
+		for ss := 0; ss < rSpans.ScopeSpans().Len(); ss++ {
+			spans := rSpans.ScopeSpans().At(ss)
+			schemaURL := scopeSchemaURL(spans.SchemaUrl(), resourceSchemaURL)
+			if schemaURL == "" {
+				continue
+			}
+			tn := t.manager.RequestTranslation(ctx, schemaURL)
+			errs = multierr.Append(errs, tn.ApplyScopeSpanChanges(spans, schemaURL))
+		}
+	}
+	return td, errs
 }
 
-func (t transformer) processTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
-	return td, nil
+// scopeSchemaURL returns the schema url that applies to the signals of a scope,
+// the schema url of the scope takes precedence over the one of its resource.
+func scopeSchemaURL(scope, resource string) string {
+	if scope != "" {
+		return scope
+	}
+	return resource
 }
 
-// start will load the remote file definition if it isn't already cached
-// and resolve the schema translation file
-func (t *transformer) start(_ context.Context, _ component.Host) error {
-	for _, target := range t.targets {
-		t.log.Info("Fetching remote schema url", zap.String("schema-url", target))
+// start creates the schema providers and will load the
+// schema files of the targets and prefetched schema urls
+// so they are cached before signals are processed.
+func (t *transformer) start(ctx context.Context, host component.Host) error {
+	var providers []translation.Provider
+	if t.cfg.SchemaDir != "" {
+		providers = append(providers, translation.NewFileProvider(t.cfg.SchemaDir))
+	}
+	client, err := t.cfg.ClientConfig.ToClient(host, t.telemetry)
+	if err != nil {
+		return err
+	}
+	providers = append(providers, translation.NewHTTPProvider(client))
+
+	t.manager, err = translation.NewManager(t.cfg.Targets, t.log, providers...)
+	if err != nil {
+		return err
+	}
+
+	for _, schemaURL := range append(append([]string(nil), t.cfg.Targets...), t.cfg.Prefetch...) {
+		t.log.Info("Fetching schema url", zap.String("schema-url", schemaURL))
+		t.manager.RequestTranslation(ctx, schemaURL)
 	}
 	return nil
 }
@@ -6,11 +6,17 @@ package schemaprocessor
 import (
 	"context"
 	_ "embed"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -18,27 +24,33 @@ import (
 	"go.uber.org/zap/zaptest"
 )
 
-func newTestTransformer(t *testing.T) *transformer {
-	trans, err := newTransformer(context.Background(), newDefaultConfiguration(), processor.CreateSettings{
+func newTestTransformer(t *testing.T, cfg *Config) *transformer {
+	trans, err := newTransformer(context.Background(), cfg, processor.CreateSettings{
 		TelemetrySettings: component.TelemetrySettings{
 			Logger: zaptest.NewLogger(t),
 		},
 	})
 	require.NoError(t, err, "Must not error when creating default transformer")
+	require.NoError(t, trans.start(context.Background(), componenttest.NewNopHost()), "Must not error when starting the transformer")
 	return trans
 }
 
 func TestTransformerStart(t *testing.T) {
 	t.Parallel()
 
-	trans := newTestTransformer(t)
-	assert.NoError(t, trans.start(context.Background(), nil))
+	trans, err := newTransformer(context.Background(), newDefaultConfiguration(), processor.CreateSettings{
+		TelemetrySettings: component.TelemetrySettings{
+			Logger: zaptest.NewLogger(t),
+		},
+	})
+	require.NoError(t, err)
+	assert.NoError(t, trans.start(context.Background(), componenttest.NewNopHost()))
 }
 
 func TestTransformerProcessing(t *testing.T) {
 	t.Parallel()
 
-	trans := newTestTransformer(t)
+	trans := newTestTransformer(t, newDefaultConfiguration().(*Config))
 	t.Run("metrics", func(t *testing.T) {
 		in := pmetric.NewMetrics()
 		in.ResourceMetrics().AppendEmpty()
@@ -86,3 +98,82 @@ func TestTransformerProcessing(t *testing.T) {
 		assert.Equal(t, in, out, "Must return the same data (subject to change)")
 	})
 }
+
+func TestTransformerSchemaDir(t *testing.T) {
+	t.Parallel()
+
+	cfg := newDefaultConfiguration().(*Config)
+	cfg.Targets = []string{"https://example.com/schemas/1.2.0"}
+	cfg.SchemaDir = filepath.Join("testdata", "schemas")
+	trans := newTestTransformer(t, cfg)
+
+	in := ptrace.NewTraces()
+	rs := in.ResourceSpans().AppendEmpty()
+	rs.SetSchemaUrl("https://example.com/schemas/1.0.0")
+	rs.Resource().Attributes().PutStr("host.ip", "10.0.0.1")
+	s := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	s.SetName("GET /")
+	s.Attributes().PutInt("http.status", 200)
+	ev := s.Events().AppendEmpty()
+	ev.SetName("exception.raised")
+
+	// Signals of other schema families are left unchanged
+	other := in.ResourceSpans().AppendEmpty()
+	other.SetSchemaUrl("https://opentelemetry.io/schemas/1.9.0")
+	other.Resource().Attributes().PutStr("host.ip", "10.0.0.2")
+
+	out, err := trans.processTraces(context.Background(), in)
+	require.NoError(t, err, "Must not error when processing traces")
+
+	rs = out.ResourceSpans().At(0)
+	assert.Equal(t, "https://example.com/schemas/1.2.0", rs.SchemaUrl())
+	assert.Equal(t, map[string]any{"host.address": "10.0.0.1"}, rs.Resource().Attributes().AsRaw())
+	s = rs.ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, map[string]any{"http.status_code": int64(200)}, s.Attributes().AsRaw())
+	assert.Equal(t, "exception", s.Events().At(0).Name())
+
+	other = out.ResourceSpans().At(1)
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.9.0", other.SchemaUrl())
+	assert.Equal(t, map[string]any{"host.ip": "10.0.0.2"}, other.Resource().Attributes().AsRaw())
+}
+
+func TestTransformerHTTP(t *testing.T) {
+	t.Parallel()
+
+	content, err := os.ReadFile(filepath.Join("testdata", "schemas", "example.com", "schemas", "1.2.0"))
+	require.NoError(t, err)
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		if r.URL.Path != "/schemas/1.2.0" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(strings.ReplaceAll(string(content), "https://example.com", "http://"+r.Host)))
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := newDefaultConfiguration().(*Config)
+	cfg.Targets = []string{server.URL + "/schemas/1.0.0"}
+	cfg.Prefetch = []string{server.URL + "/schemas/1.2.0"}
+	trans := newTestTransformer(t, cfg)
+	assert.Equal(t, []string{"/schemas/1.0.0", "/schemas/1.2.0"}, requests, "Must fetch the targets and prefetched schemas on start")
+
+	in := pmetric.NewMetrics()
+	rm := in.ResourceMetrics().AppendEmpty()
+	rm.SetSchemaUrl(server.URL + "/schemas/1.2.0")
+	m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("system.paging.operations.in")
+	m.SetEmptySum().DataPoints().AppendEmpty().SetIntValue(1)
+
+	out, err := trans.processMetrics(context.Background(), in)
+	require.NoError(t, err, "Must not error when processing metrics")
+	assert.Len(t, requests, 2, "Must use the cached schema")
+
+	rm = out.ResourceMetrics().At(0)
+	assert.Equal(t, server.URL+"/schemas/1.0.0", rm.SchemaUrl())
+	m = rm.ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "system.paging.operations", m.Name())
+	assert.Equal(t, map[string]any{"direction": "in"}, m.Sum().DataPoints().At(0).Attributes().AsRaw())
+}
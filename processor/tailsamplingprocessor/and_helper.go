@@ -24,5 +24,10 @@ func getNewAndPolicy(settings component.TelemetrySettings, config *AndCfg) (samp
 
 // Return instance of and sub-policy
 func getAndSubPolicyEvaluator(settings component.TelemetrySettings, cfg *AndSubPolicyCfg) (sampling.PolicyEvaluator, error) {
-	return getSharedPolicyEvaluator(settings, &cfg.sharedPolicyCfg)
+	switch cfg.Type {
+	case Drop:
+		return getNewDropPolicy(settings, &cfg.DropCfg)
+	default:
+		return getSharedPolicyEvaluator(settings, &cfg.sharedPolicyCfg)
+	}
 }
@@ -49,6 +49,8 @@ func getCompositeSubPolicyEvaluator(settings component.TelemetrySettings, cfg *C
 	switch cfg.Type {
 	case And:
 		return getNewAndPolicy(settings, &cfg.AndCfg)
+	case Drop:
+		return getNewDropPolicy(settings, &cfg.DropCfg)
 	default:
 		return getSharedPolicyEvaluator(settings, &cfg.sharedPolicyCfg)
 	}
@@ -6,6 +6,8 @@ package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry
 import (
 	"time"
 
+	"go.opentelemetry.io/collector/component"
+
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
@@ -33,6 +35,9 @@ const (
 	Composite PolicyType = "composite"
 	// And allows defining a And policy, combining the other policies in one
 	And PolicyType = "and"
+	// Drop allows defining a Drop policy, combining the other policies in one. Traces matching
+	// all of them are not sampled, whatever the decisions of the other policies.
+	Drop PolicyType = "drop"
 	// SpanCount sample traces that are have more spans per Trace than a given threshold.
 	SpanCount PolicyType = "span_count"
 	// TraceState sample traces with specified values by the given key
@@ -80,11 +85,21 @@ type CompositeSubPolicyCfg struct {
 
 	// Configs for and policy evaluator.
 	AndCfg AndCfg `mapstructure:"and"`
+	// Configs for drop policy evaluator.
+	DropCfg DropCfg `mapstructure:"drop"`
 }
 
 // AndSubPolicyCfg holds the common configuration to all policies under and policy.
 type AndSubPolicyCfg struct {
 	sharedPolicyCfg `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Configs for drop policy evaluator.
+	DropCfg DropCfg `mapstructure:"drop"`
+}
+
+// DropSubPolicyCfg holds the common configuration to all policies under drop policy.
+type DropSubPolicyCfg struct {
+	sharedPolicyCfg `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
 }
 
 // TraceStateCfg holds the common configuration for trace states.
@@ -100,6 +115,11 @@ type AndCfg struct {
 	SubPolicyCfg []AndSubPolicyCfg `mapstructure:"and_sub_policy"`
 }
 
+// DropCfg holds the common configuration to all drop policies.
+type DropCfg struct {
+	SubPolicyCfg []DropSubPolicyCfg `mapstructure:"drop_sub_policy"`
+}
+
 // CompositeCfg holds the configurable settings to create a composite
 // sampling policy evaluator.
 type CompositeCfg struct {
@@ -123,6 +143,8 @@ type PolicyCfg struct {
 	CompositeCfg CompositeCfg `mapstructure:"composite"`
 	// Configs for defining and policy
 	AndCfg AndCfg `mapstructure:"and"`
+	// Configs for defining drop policy
+	DropCfg DropCfg `mapstructure:"drop"`
 }
 
 // LatencyCfg holds the configurable settings to create a latency filter sampling policy
@@ -220,6 +242,21 @@ type OTTLConditionCfg struct {
 	SpanEventConditions []string       `mapstructure:"spanevent"`
 }
 
+// DecisionCacheConfig holds the configuration of the caches that remember the
+// sampling decisions made for traces after they have been removed from memory.
+type DecisionCacheConfig struct {
+	// SampledCacheSize specifies the size of the cache that holds the sampled trace IDs.
+	// This value will be the maximum amount of trace IDs that the cache can hold before overwriting previous IDs.
+	// For effective use, this value should be at least an order of magnitude higher than Config.NumTraces.
+	// If left as default 0, a no-op DecisionCache will be used.
+	SampledCacheSize int `mapstructure:"sampled_cache_size"`
+	// NonSampledCacheSize specifies the size of the cache that holds the non-sampled trace IDs.
+	// This value will be the maximum amount of trace IDs that the cache can hold before overwriting previous IDs.
+	// For effective use, this value should be at least an order of magnitude higher than Config.NumTraces.
+	// If left as default 0, a no-op DecisionCache will be used.
+	NonSampledCacheSize int `mapstructure:"non_sampled_cache_size"`
+}
+
 // Config holds the configuration for tail-based sampling.
 type Config struct {
 	// DecisionWait is the desired wait time from the arrival of the first span of
@@ -234,4 +271,15 @@ type Config struct {
 	// PolicyCfgs sets the tail-based sampling policy which makes a sampling decision
 	// for a given trace when requested.
 	PolicyCfgs []PolicyCfg `mapstructure:"policies"`
+	// DecisionCache holds the configuration for the decision caches, which allow spans
+	// arriving after their trace was removed from memory to follow the decision made for it.
+	DecisionCache DecisionCacheConfig `mapstructure:"decision_cache"`
+	// StorageID is the optional ID of a storage extension. When set, the spans of
+	// the traces pending a decision and the decision caches are kept in the storage
+	// instead of memory, and are reloaded when the collector restarts.
+	StorageID *component.ID `mapstructure:"storage"`
+	// StorageFlushInterval is the interval at which the list of pending traces and the
+	// decision caches are written to the storage. When zero, they are written on every
+	// evaluation tick.
+	StorageFlushInterval time.Duration `mapstructure:"storage_flush_interval"`
 }
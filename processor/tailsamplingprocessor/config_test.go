@@ -36,6 +36,8 @@ func TestLoadConfig(t *testing.T) {
 			DecisionWait:            10 * time.Second,
 			NumTraces:               100,
 			ExpectedNewTracesPerSec: 10,
+			DecisionCache:           DecisionCacheConfig{SampledCacheSize: 1000, NonSampledCacheSize: 10000},
+			StorageFlushInterval:    10 * time.Second,
 			PolicyCfgs: []PolicyCfg{
 				{
 					sharedPolicyCfg: sharedPolicyCfg{
@@ -183,6 +185,23 @@ func TestLoadConfig(t *testing.T) {
 						},
 					},
 				},
+				{
+					sharedPolicyCfg: sharedPolicyCfg{
+						Name: "drop-policy-1",
+						Type: Drop,
+					},
+					DropCfg: DropCfg{
+						SubPolicyCfg: []DropSubPolicyCfg{
+							{
+								sharedPolicyCfg: sharedPolicyCfg{
+									Name:               "test-drop-policy-1",
+									Type:               StringAttribute,
+									StringAttributeCfg: StringAttributeCfg{Key: "url.path", Values: []string{"/health", "/metrics"}},
+								},
+							},
+						},
+					},
+				},
 			},
 		})
 }
@@ -0,0 +1,24 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cache defines the caches used to remember the sampling
+// decisions of traces after they have been removed from memory.
+package cache // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
+
+import "go.opentelemetry.io/collector/pdata/pcommon"
+
+// Cache is a cache using a pcommon.TraceID as the key and any generic type as the value.
+type Cache[V any] interface {
+	// Get returns the value for the given id, and a boolean to indicate whether the key was found.
+	// If the key is not present, the zero value is returned.
+	Get(id pcommon.TraceID) (V, bool)
+	// Put sets the value for a given id
+	Put(id pcommon.TraceID, v V)
+	// Delete deletes the value for the given id
+	Delete(id pcommon.TraceID)
+	// Snapshot serializes the keys held by the cache, so that they can be
+	// persisted and later restored.
+	Snapshot() []byte
+	// Restore adds the keys of a snapshot to the cache, all with the given value.
+	Restore(data []byte, v V) error
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestLRUDecisionCache(t *testing.T) {
+	c, err := NewLRUDecisionCache[bool](2)
+	require.NoError(t, err)
+
+	id1 := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	id2 := pcommon.TraceID([16]byte{2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17})
+	id3 := pcommon.TraceID([16]byte{3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18})
+
+	c.Put(id1, true)
+	c.Put(id2, true)
+
+	v, ok := c.Get(id1)
+	assert.True(t, v)
+	assert.True(t, ok)
+
+	// id2 is now the least recently used key and is evicted.
+	c.Put(id3, true)
+
+	_, ok = c.Get(id2)
+	assert.False(t, ok)
+	_, ok = c.Get(id1)
+	assert.True(t, ok)
+	_, ok = c.Get(id3)
+	assert.True(t, ok)
+}
+
+func TestInvalidSize(t *testing.T) {
+	_, err := NewLRUDecisionCache[bool](0)
+	assert.Error(t, err)
+}
+
+func TestNopDecisionCache(t *testing.T) {
+	c := NewNopDecisionCache[bool]()
+	id := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	c.Put(id, true)
+	v, ok := c.Get(id)
+	assert.False(t, v)
+	assert.False(t, ok)
+}
+
+func TestLRUDecisionCacheSnapshot(t *testing.T) {
+	c, err := NewLRUDecisionCache[bool](2)
+	require.NoError(t, err)
+
+	id1 := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	id2 := pcommon.TraceID([16]byte{2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17})
+	id3 := pcommon.TraceID([16]byte{3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18})
+
+	c.Put(id1, true)
+	c.Put(id2, true)
+	// id1 becomes the most recently used key.
+	_, _ = c.Get(id1)
+
+	restored, err := NewLRUDecisionCache[bool](2)
+	require.NoError(t, err)
+	require.NoError(t, restored.Restore(c.Snapshot(), true))
+
+	_, ok := restored.Get(id1)
+	assert.True(t, ok)
+	_, ok = restored.Get(id2)
+	assert.True(t, ok)
+
+	// The order of eviction is preserved: id2 is evicted first.
+	restored, err = NewLRUDecisionCache[bool](2)
+	require.NoError(t, err)
+	require.NoError(t, restored.Restore(c.Snapshot(), true))
+	restored.Put(id3, true)
+	_, ok = restored.Get(id2)
+	assert.False(t, ok)
+	_, ok = restored.Get(id1)
+	assert.True(t, ok)
+
+	assert.Error(t, restored.Restore([]byte{1, 2, 3}, true))
+}
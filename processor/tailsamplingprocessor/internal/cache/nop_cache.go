@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cache // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
+
+import "go.opentelemetry.io/collector/pdata/pcommon"
+
+// nopDecisionCache is used when the cache is disabled, it never holds any ID.
+type nopDecisionCache[V any] struct{}
+
+var _ Cache[any] = (*nopDecisionCache[any])(nil)
+
+// NewNopDecisionCache returns a Cache that never holds any ID.
+func NewNopDecisionCache[V any]() Cache[V] {
+	return &nopDecisionCache[V]{}
+}
+
+func (n *nopDecisionCache[V]) Get(_ pcommon.TraceID) (V, bool) {
+	var v V
+	return v, false
+}
+
+func (n *nopDecisionCache[V]) Put(_ pcommon.TraceID, _ V) {}
+
+func (n *nopDecisionCache[V]) Delete(_ pcommon.TraceID) {}
+
+func (n *nopDecisionCache[V]) Snapshot() []byte {
+	return nil
+}
+
+func (n *nopDecisionCache[V]) Restore(_ []byte, _ V) error {
+	return nil
+}
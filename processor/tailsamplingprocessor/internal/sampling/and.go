@@ -5,6 +5,7 @@ package sampling // import "github.com/open-telemetry/opentelemetry-collector-co
 
 import (
 	"context"
+	"sort"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.uber.org/zap"
@@ -21,8 +22,13 @@ func NewAnd(
 	subpolicies []PolicyEvaluator,
 ) PolicyEvaluator {
 
+	ordered := append([]PolicyEvaluator(nil), subpolicies...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return isDrop(ordered[i]) && !isDrop(ordered[j])
+	})
+
 	return &And{
-		subpolicies: subpolicies,
+		subpolicies: ordered,
 		logger:      logger,
 	}
 }
@@ -31,15 +37,28 @@ func NewAnd(
 func (c *And) Evaluate(ctx context.Context, traceID pcommon.TraceID, trace *TraceData) (Decision, error) {
 	// The policy iterates over all sub-policies and returns Sampled if all sub-policies returned a Sampled Decision.
 	// If any subpolicy returns NotSampled, it returns NotSampled Decision.
+	// The drop sub-policies are evaluated first, and any Dropped Decision is returned as is.
+	// A drop sub-policy not matching the trace leaves the decision to the other sub-policies,
+	// and at least one of the other sub-policies must return Sampled.
+	sampled := false
 	for _, sub := range c.subpolicies {
 		decision, err := sub.Evaluate(ctx, traceID, trace)
 		if err != nil {
 			return Unspecified, err
 		}
+		if decision == Dropped {
+			return Dropped, nil
+		}
+		if isDrop(sub) {
+			continue
+		}
 		if decision == NotSampled || decision == InvertNotSampled {
 			return NotSampled, nil
 		}
-
+		sampled = true
+	}
+	if !sampled {
+		return NotSampled, nil
 	}
 	return Sampled, nil
 }
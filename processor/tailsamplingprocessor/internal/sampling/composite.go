@@ -5,6 +5,7 @@ package sampling // import "github.com/open-telemetry/opentelemetry-collector-co
 
 import (
 	"context"
+	"sort"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.uber.org/zap"
@@ -67,6 +68,10 @@ func NewComposite(
 		subpolicies = append(subpolicies, sub)
 	}
 
+	sort.SliceStable(subpolicies, func(i, j int) bool {
+		return isDrop(subpolicies[i].evaluator) && !isDrop(subpolicies[j].evaluator)
+	})
+
 	return &Composite{
 		maxTotalSPS:  maxTotalSpansPerSecond,
 		subpolicies:  subpolicies,
@@ -100,6 +105,11 @@ func (c *Composite) Evaluate(ctx context.Context, traceID pcommon.TraceID, trace
 			return Unspecified, err
 		}
 
+		if decision == Dropped {
+			// A drop subpolicy outranks any other, whatever their rate allocation.
+			return Dropped, nil
+		}
+
 		if decision == Sampled || decision == InvertSampled {
 			// The subpolicy made a decision to Sample. Now we need to make our decision.
 
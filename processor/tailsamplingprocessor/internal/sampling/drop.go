@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+)
+
+type Drop struct {
+	// the subpolicy evaluators
+	subpolicies []PolicyEvaluator
+	logger      *zap.Logger
+}
+
+var _ PolicyEvaluator = (*Drop)(nil)
+
+// NewDrop creates a policy evaluator that drops the traces matching all its subpolicies.
+func NewDrop(
+	logger *zap.Logger,
+	subpolicies []PolicyEvaluator,
+) PolicyEvaluator {
+
+	return &Drop{
+		subpolicies: subpolicies,
+		logger:      logger,
+	}
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+func (d *Drop) Evaluate(ctx context.Context, traceID pcommon.TraceID, trace *TraceData) (Decision, error) {
+	// The policy iterates over all sub-policies and returns Dropped if all sub-policies returned a Sampled Decision.
+	// If any subpolicy returns NotSampled, it returns NotSampled Decision, which leaves the decision to the other policies.
+	// A drop policy without subpolicies matches no trace.
+	if len(d.subpolicies) == 0 {
+		return NotSampled, nil
+	}
+	for _, sub := range d.subpolicies {
+		decision, err := sub.Evaluate(ctx, traceID, trace)
+		if err != nil {
+			return Unspecified, err
+		}
+		if decision == NotSampled || decision == InvertNotSampled {
+			return NotSampled, nil
+		}
+	}
+	return Dropped, nil
+}
+
+// isDrop returns whether the evaluator is a drop policy. The drop subpolicies of
+// the and & composite policies are evaluated first, so that a trace matching a
+// drop policy is dropped regardless of the decisions of the other subpolicies.
+func isDrop(evaluator PolicyEvaluator) bool {
+	_, ok := evaluator.(*Drop)
+	return ok
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sampling
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+func newHealthCheckTrace() *TraceData {
+	trace := createTrace()
+	span := trace.ReceivedBatches.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.Attributes().PutStr("url.path", "/health")
+	span.Status().SetCode(ptrace.StatusCodeError)
+	span.SetTraceID(traceID)
+	span.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	return trace
+}
+
+func newHealthCheckDrop(t *testing.T) PolicyEvaluator {
+	n1 := NewStringAttributeFilter(componenttest.NewNopTelemetrySettings(), "url.path", []string{"/health"}, false, 0, false)
+	n2, err := NewStatusCodeFilter(componenttest.NewNopTelemetrySettings(), []string{"ERROR"})
+	require.NoError(t, err)
+	return NewDrop(zap.NewNop(), []PolicyEvaluator{n1, n2})
+}
+
+func TestDropEvaluatorDropped(t *testing.T) {
+	decision, err := newHealthCheckDrop(t).Evaluate(context.Background(), traceID, newHealthCheckTrace())
+	require.NoError(t, err)
+	assert.Equal(t, Dropped, decision)
+}
+
+func TestDropEvaluatorNotSampled(t *testing.T) {
+	trace := newHealthCheckTrace()
+	trace.ReceivedBatches.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().PutStr("url.path", "/api")
+
+	decision, err := newHealthCheckDrop(t).Evaluate(context.Background(), traceID, trace)
+	require.NoError(t, err)
+	assert.Equal(t, NotSampled, decision)
+}
+
+func TestAndEvaluatorDropped(t *testing.T) {
+	// The drop policy is evaluated first, even though the latency policy does not match.
+	and := NewAnd(zap.NewNop(), []PolicyEvaluator{
+		NewLatency(componenttest.NewNopTelemetrySettings(), 1000, 0),
+		newHealthCheckDrop(t),
+	})
+
+	decision, err := and.Evaluate(context.Background(), traceID, newHealthCheckTrace())
+	require.NoError(t, err)
+	assert.Equal(t, Dropped, decision)
+}
+
+func TestAndEvaluatorDropNotMatching(t *testing.T) {
+	// The drop policy not matching the trace leaves the decision to the other subpolicies.
+	and := NewAnd(zap.NewNop(), []PolicyEvaluator{
+		NewAlwaysSample(componenttest.NewNopTelemetrySettings()),
+		newHealthCheckDrop(t),
+	})
+
+	decision, err := and.Evaluate(context.Background(), traceID, createTrace())
+	require.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+}
+
+func TestAndEvaluatorOnlyDrop(t *testing.T) {
+	// An and policy made of drop policies only drops the matching traces, and samples no other trace.
+	and := NewAnd(zap.NewNop(), []PolicyEvaluator{newHealthCheckDrop(t)})
+
+	decision, err := and.Evaluate(context.Background(), traceID, newHealthCheckTrace())
+	require.NoError(t, err)
+	assert.Equal(t, Dropped, decision)
+
+	decision, err = and.Evaluate(context.Background(), traceID, createTrace())
+	require.NoError(t, err)
+	assert.Equal(t, NotSampled, decision)
+}
+
+func TestDropEvaluatorEmpty(t *testing.T) {
+	decision, err := NewDrop(zap.NewNop(), nil).Evaluate(context.Background(), traceID, newHealthCheckTrace())
+	require.NoError(t, err)
+	assert.Equal(t, NotSampled, decision)
+}
+
+func TestCompositeEvaluatorDropped(t *testing.T) {
+	// The drop policy outranks the always_sample policy placed before it.
+	c := NewComposite(zap.NewNop(), 1000, []SubPolicyEvalParams{
+		{Evaluator: NewAlwaysSample(componenttest.NewNopTelemetrySettings()), MaxSpansPerSecond: 1000},
+		{Evaluator: newHealthCheckDrop(t), MaxSpansPerSecond: 1000},
+	}, FakeTimeProvider{second: 1})
+
+	decision, err := c.Evaluate(context.Background(), traceID, newHealthCheckTrace())
+	require.NoError(t, err)
+	assert.Equal(t, Dropped, decision)
+
+	// Other traces are left to the other subpolicies.
+	decision, err = c.Evaluate(context.Background(), traceID, createTrace())
+	require.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+}
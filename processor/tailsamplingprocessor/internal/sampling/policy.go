@@ -26,6 +26,9 @@ type TraceData struct {
 	SpanCount *atomic.Int64
 	// ReceivedBatches stores all the batches received for the trace.
 	ReceivedBatches ptrace.Traces
+	// StoredBatches is the number of batches of the trace held in the storage
+	// extension, rather than in ReceivedBatches, while the decision is pending.
+	StoredBatches int
 	// FinalDecision.
 	FinalDecision Decision
 }
@@ -44,8 +47,8 @@ const (
 	// NotSampled is used to indicate that the decision was already taken
 	// to not sample the data.
 	NotSampled
-	// Dropped is used when data needs to be purged before the sampling policy
-	// had a chance to evaluate it.
+	// Dropped is used by the drop policy to indicate that the data must not be
+	// sampled, it takes precedence over the decisions of all other policies.
 	Dropped
 	// Error is used to indicate that policy evaluation was not succeeded.
 	Error
@@ -18,6 +18,8 @@ var (
 	tagPolicyKey, _    = tag.NewKey("policy")
 	tagSampledKey, _   = tag.NewKey("sampled")
 	tagSourceFormat, _ = tag.NewKey("source_format")
+	tagCacheKey, _     = tag.NewKey("cache")
+	tagCacheResult, _  = tag.NewKey("result")
 
 	statDecisionLatencyMicroSec  = stats.Int64("sampling_decision_latency", "Latency (in microseconds) of a given sampling policy", "µs")
 	statOverallDecisionLatencyUs = stats.Int64("sampling_decision_timer_latency", "Latency (in microseconds) of each run of the sampling decision timer", "µs")
@@ -30,10 +32,13 @@ var (
 	statCountTracesSampled       = stats.Int64("count_traces_sampled", "Count of traces that were sampled or not per sampling policy", stats.UnitDimensionless)
 	statCountSpansSampled        = stats.Int64("count_spans_sampled", "Count of spans that were sampled or not per sampling policy", stats.UnitDimensionless)
 	statCountGlobalTracesSampled = stats.Int64("global_count_traces_sampled", "Global count of traces that were sampled or not by at least one policy", stats.UnitDimensionless)
+	statCountTracesDropped       = stats.Int64("count_traces_dropped", "Count of traces that were dropped per drop policy", stats.UnitDimensionless)
 
 	statDroppedTooEarlyCount    = stats.Int64("sampling_trace_dropped_too_early", "Count of traces that needed to be dropped before the configured wait time", stats.UnitDimensionless)
 	statNewTraceIDReceivedCount = stats.Int64("new_trace_id_received", "Counts the arrival of new traces", stats.UnitDimensionless)
 	statTracesOnMemoryGauge     = stats.Int64("sampling_traces_on_memory", "Tracks the number of traces current on memory", stats.UnitDimensionless)
+
+	statDecisionCacheLookupCount = stats.Int64("sampling_decision_cache_lookup", "Count of trace ID lookups in the decision caches, per cache and result (hit or miss)", stats.UnitDimensionless)
 )
 
 // samplingProcessorMetricViews return the metrics views according to given telemetry level.
@@ -95,6 +100,13 @@ func samplingProcessorMetricViews(level configtelemetry.Level) []*view.View {
 			TagKeys:     []tag.Key{tagSampledKey},
 			Aggregation: view.Sum(),
 		},
+		&view.View{
+			Name:        processorhelper.BuildCustomMetricName(metadata.Type.String(), statCountTracesDropped.Name()),
+			Measure:     statCountTracesDropped,
+			Description: statCountTracesDropped.Description(),
+			TagKeys:     policyTagKeys,
+			Aggregation: view.Sum(),
+		},
 		&view.View{
 			Name:        processorhelper.BuildCustomMetricName(metadata.Type.String(), statDroppedTooEarlyCount.Name()),
 			Measure:     statDroppedTooEarlyCount,
@@ -112,6 +124,13 @@ func samplingProcessorMetricViews(level configtelemetry.Level) []*view.View {
 			Measure:     statTracesOnMemoryGauge,
 			Description: statTracesOnMemoryGauge.Description(),
 			Aggregation: view.LastValue(),
+		},
+		&view.View{
+			Name:        processorhelper.BuildCustomMetricName(metadata.Type.String(), statDecisionCacheLookupCount.Name()),
+			Measure:     statDecisionCacheLookupCount,
+			Description: statDecisionCacheLookupCount.Description(),
+			TagKeys:     []tag.Key{tagCacheKey, tagCacheResult},
+			Aggregation: view.Sum(),
 		})
 
 	if isMetricStatCountSpansSampledEnabled() {
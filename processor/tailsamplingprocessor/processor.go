@@ -5,6 +5,7 @@ package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"runtime"
@@ -16,12 +17,14 @@ import (
 	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/timeutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/idbatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
 )
@@ -29,6 +32,11 @@ import (
 var (
 	tagUpsertSampled    = tag.Upsert(tagSampledKey, "true")
 	tagUpsertNotSampled = tag.Upsert(tagSampledKey, "false")
+
+	tagsSampledCacheHit     = []tag.Mutator{tag.Upsert(tagCacheKey, "sampled"), tag.Upsert(tagCacheResult, "hit")}
+	tagsSampledCacheMiss    = []tag.Mutator{tag.Upsert(tagCacheKey, "sampled"), tag.Upsert(tagCacheResult, "miss")}
+	tagsNonSampledCacheHit  = []tag.Mutator{tag.Upsert(tagCacheKey, "non_sampled"), tag.Upsert(tagCacheResult, "hit")}
+	tagsNonSampledCacheMiss = []tag.Mutator{tag.Upsert(tagCacheKey, "non_sampled"), tag.Upsert(tagCacheResult, "miss")}
 )
 
 // policy combines a sampling policy evaluator with the destinations to be
@@ -46,6 +54,7 @@ type policy struct {
 // policy to sample traces.
 type tailSamplingSpanProcessor struct {
 	ctx             context.Context
+	id              component.ID
 	nextConsumer    consumer.Traces
 	maxNumTraces    uint64
 	policies        []*policy
@@ -57,6 +66,23 @@ type tailSamplingSpanProcessor struct {
 	deleteChan      chan pcommon.TraceID
 	numTracesOnMap  *atomic.Uint64
 
+	// The decision caches remember the sampling decision of traces that
+	// are no longer in idToTrace. They are no-op caches when disabled.
+	sampledIDCache    cache.Cache[bool]
+	nonSampledIDCache cache.Cache[bool]
+	sampledCacheOn    bool
+	nonSampledCacheOn bool
+
+	// The storage client is set when a storage extension is configured, the
+	// pending spans are then kept in the storage rather than in memory.
+	storageID     *component.ID
+	storageClient storage.Client
+	// The pending traces and the decision caches are written to the storage
+	// on the first tick after storageFlushInterval has elapsed.
+	storageFlushInterval time.Duration
+	lastStorageFlush     time.Time
+	pendingTraces        pendingTraces
+
 	// This is for reusing the slice by each call of `makeDecision`. This
 	// was previously identified to be a bottleneck using profiling.
 	mutatorsBuf []tag.Mutator
@@ -76,7 +102,8 @@ const (
 
 // newTracesProcessor returns a processor.TracesProcessor that will perform tail sampling according to the given
 // configuration.
-func newTracesProcessor(ctx context.Context, settings component.TelemetrySettings, nextConsumer consumer.Traces, cfg Config) (processor.Traces, error) {
+func newTracesProcessor(ctx context.Context, set processor.CreateSettings, nextConsumer consumer.Traces, cfg Config) (processor.Traces, error) {
+	settings := set.TelemetrySettings
 	policyNames := map[string]bool{}
 	policies := make([]*policy, len(cfg.PolicyCfgs))
 	for i := range cfg.PolicyCfgs {
@@ -111,15 +138,32 @@ func newTracesProcessor(ctx context.Context, settings component.TelemetrySetting
 		return nil, err
 	}
 
+	sampledIDCache, err := newDecisionCache(cfg.DecisionCache.SampledCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	nonSampledIDCache, err := newDecisionCache(cfg.DecisionCache.NonSampledCacheSize)
+	if err != nil {
+		return nil, err
+	}
+
 	tsp := &tailSamplingSpanProcessor{
-		ctx:             ctx,
-		nextConsumer:    nextConsumer,
-		maxNumTraces:    cfg.NumTraces,
-		logger:          settings.Logger,
-		decisionBatcher: inBatcher,
-		policies:        policies,
-		tickerFrequency: time.Second,
-		numTracesOnMap:  &atomic.Uint64{},
+		ctx:                  ctx,
+		id:                   set.ID,
+		nextConsumer:         nextConsumer,
+		maxNumTraces:         cfg.NumTraces,
+		logger:               settings.Logger,
+		decisionBatcher:      inBatcher,
+		policies:             policies,
+		tickerFrequency:      time.Second,
+		numTracesOnMap:       &atomic.Uint64{},
+		sampledIDCache:       sampledIDCache,
+		nonSampledIDCache:    nonSampledIDCache,
+		sampledCacheOn:       cfg.DecisionCache.SampledCacheSize > 0,
+		nonSampledCacheOn:    cfg.DecisionCache.NonSampledCacheSize > 0,
+		storageID:            cfg.StorageID,
+		storageFlushInterval: cfg.StorageFlushInterval,
+		pendingTraces:        pendingTraces{traces: map[pcommon.TraceID]time.Time{}},
 
 		// We allocate exactly 1 element, because that's the exact amount
 		// used in any place.
@@ -132,12 +176,23 @@ func newTracesProcessor(ctx context.Context, settings component.TelemetrySetting
 	return tsp, nil
 }
 
+// newDecisionCache returns an LRU decision cache of the given size, or a
+// no-op cache when the size is zero.
+func newDecisionCache(size int) (cache.Cache[bool], error) {
+	if size <= 0 {
+		return cache.NewNopDecisionCache[bool](), nil
+	}
+	return cache.NewLRUDecisionCache[bool](size)
+}
+
 func getPolicyEvaluator(settings component.TelemetrySettings, cfg *PolicyCfg) (sampling.PolicyEvaluator, error) {
 	switch cfg.Type {
 	case Composite:
 		return getNewCompositePolicy(settings, &cfg.CompositeCfg)
 	case And:
 		return getNewAndPolicy(settings, &cfg.AndCfg)
+	case Drop:
+		return getNewDropPolicy(settings, &cfg.DropCfg)
 	default:
 		return getSharedPolicyEvaluator(settings, &cfg.sharedPolicyCfg)
 	}
@@ -186,7 +241,7 @@ func getSharedPolicyEvaluator(settings component.TelemetrySettings, cfg *sharedP
 }
 
 type policyMetrics struct {
-	idNotFoundOnMapCount, evaluateErrorCount, decisionSampled, decisionNotSampled int64
+	idNotFoundOnMapCount, evaluateErrorCount, decisionSampled, decisionNotSampled, decisionDropped int64
 }
 
 func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
@@ -205,17 +260,39 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		trace := d.(*sampling.TraceData)
 		trace.DecisionTime = time.Now()
 
+		if tsp.storageClient != nil {
+			trace.Lock()
+			tsp.loadStoredBatches(id, trace)
+			trace.Unlock()
+		}
+
 		decision, policy := tsp.makeDecision(id, trace, &metrics)
 
 		// Sampled or not, remove the batches
 		trace.Lock()
+		if tsp.storageClient != nil {
+			// Spans stored while the policies were evaluated
+			tsp.loadStoredBatches(id, trace)
+		}
 		allSpans := trace.ReceivedBatches
 		trace.FinalDecision = decision
 		trace.ReceivedBatches = ptrace.NewTraces()
 		trace.Unlock()
 
+		if tsp.storageClient != nil {
+			tsp.forgetPendingTrace(id)
+		}
+
 		if decision == sampling.Sampled {
-			_ = tsp.nextConsumer.ConsumeTraces(policy.ctx, allSpans)
+			tsp.releaseSampledTrace(policy.ctx, id, allSpans)
+		} else {
+			tsp.nonSampledIDCache.Put(id, true)
+		}
+	}
+
+	if tsp.storageClient != nil && time.Since(tsp.lastStorageFlush) >= tsp.storageFlushInterval {
+		if err := tsp.flushStorage(tsp.ctx); err != nil {
+			tsp.logger.Warn("Failed to flush the pending traces and decision caches to storage", zap.Error(err))
 		}
 	}
 
@@ -229,6 +306,7 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		zap.Int("batch.len", batchLen),
 		zap.Int64("sampled", metrics.decisionSampled),
 		zap.Int64("notSampled", metrics.decisionNotSampled),
+		zap.Int64("dropped", metrics.decisionDropped),
 		zap.Int64("droppedPriorToEvaluation", metrics.idNotFoundOnMapCount),
 		zap.Int64("policyEvaluationErrors", metrics.evaluateErrorCount),
 	)
@@ -243,6 +321,7 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sa
 		sampling.NotSampled:       false,
 		sampling.InvertSampled:    false,
 		sampling.InvertNotSampled: false,
+		sampling.Dropped:          false,
 	}
 
 	// Check all policies before making a final decision
@@ -274,12 +353,18 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sa
 			case sampling.InvertNotSampled:
 				samplingDecision[sampling.InvertNotSampled] = true
 				trace.Decisions[i] = sampling.NotSampled
+
+			case sampling.Dropped:
+				samplingDecision[sampling.Dropped] = true
+				trace.Decisions[i] = decision
 			}
 		}
 	}
 
-	// InvertNotSampled takes precedence over any other decision
+	// Dropped takes precedence over any other decision, followed by InvertNotSampled
 	switch {
+	case samplingDecision[sampling.Dropped]:
+		finalDecision = sampling.NotSampled
 	case samplingDecision[sampling.InvertNotSampled]:
 		finalDecision = sampling.NotSampled
 	case samplingDecision[sampling.Sampled]:
@@ -328,6 +413,10 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sa
 				)
 			}
 			metrics.decisionNotSampled++
+
+		case sampling.Dropped:
+			stats.Record(p.ctx, statCountTracesDropped.M(int64(1)))
+			metrics.decisionDropped++
 		}
 	}
 
@@ -385,6 +474,18 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 	idToSpansAndScope := tsp.groupSpansByTraceKey(resourceSpans)
 	var newTraceIDs int64
 	for id, spans := range idToSpansAndScope {
+		// If the trace ID is in the sampled cache, short circuit the decision
+		if tsp.isInDecisionCache(tsp.sampledIDCache, tsp.sampledCacheOn, tagsSampledCacheHit, tagsSampledCacheMiss, id) {
+			traceTd := ptrace.NewTraces()
+			appendToTraces(traceTd, resourceSpans, spans)
+			tsp.releaseSampledTrace(tsp.ctx, id, traceTd)
+			continue
+		}
+		// If the trace ID is in the non-sampled cache, short circuit the decision
+		if tsp.isInDecisionCache(tsp.nonSampledIDCache, tsp.nonSampledCacheOn, tagsNonSampledCacheHit, tagsNonSampledCacheMiss, id) {
+			continue
+		}
+
 		lenSpans := int64(len(spans))
 		lenPolicies := len(tsp.policies)
 		initialDecisions := make([]sampling.Decision, lenPolicies)
@@ -428,7 +529,9 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 
 		if finalDecision == sampling.Unspecified {
 			// If the final decision hasn't been made, add the new spans under the lock.
-			appendToTraces(actualData.ReceivedBatches, resourceSpans, spans)
+			if !tsp.storeBatch(id, actualData, resourceSpans, spans) {
+				appendToTraces(actualData.ReceivedBatches, resourceSpans, spans)
+			}
 			actualData.Unlock()
 		} else {
 			actualData.Unlock()
@@ -455,21 +558,66 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 	stats.Record(tsp.ctx, statNewTraceIDReceivedCount.M(newTraceIDs))
 }
 
+// isInDecisionCache looks up the trace ID in a decision cache and records
+// the result of the lookup when the cache is enabled.
+func (tsp *tailSamplingSpanProcessor) isInDecisionCache(c cache.Cache[bool], enabled bool, hitTags, missTags []tag.Mutator, id pcommon.TraceID) bool {
+	if !enabled {
+		return false
+	}
+	_, ok := c.Get(id)
+	tags := missTags
+	if ok {
+		tags = hitTags
+	}
+	_ = stats.RecordWithTags(tsp.ctx, tags, statDecisionCacheLookupCount.M(int64(1)))
+	return ok
+}
+
+// releaseSampledTrace remembers the trace ID in the sampled cache and
+// forwards the spans to the next consumer.
+func (tsp *tailSamplingSpanProcessor) releaseSampledTrace(ctx context.Context, id pcommon.TraceID, td ptrace.Traces) {
+	tsp.sampledIDCache.Put(id, true)
+	if err := tsp.nextConsumer.ConsumeTraces(ctx, td); err != nil {
+		tsp.logger.Warn(
+			"Error sending spans to destination",
+			zap.Error(err))
+	}
+}
+
 func (tsp *tailSamplingSpanProcessor) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
 }
 
 // Start is invoked during service startup.
-func (tsp *tailSamplingSpanProcessor) Start(context.Context, component.Host) error {
+func (tsp *tailSamplingSpanProcessor) Start(ctx context.Context, host component.Host) error {
+	if tsp.storageID != nil {
+		client, err := getStorageClient(ctx, host, tsp.storageID, tsp.id)
+		if err != nil {
+			return err
+		}
+		tsp.storageClient = client
+		if err = tsp.restoreFromStorage(ctx); err != nil {
+			return fmt.Errorf("failed to restore from storage: %w", err)
+		}
+		tsp.lastStorageFlush = time.Now()
+	}
 	tsp.policyTicker.Start(tsp.tickerFrequency)
 	return nil
 }
 
 // Shutdown is invoked during service shutdown.
-func (tsp *tailSamplingSpanProcessor) Shutdown(context.Context) error {
+func (tsp *tailSamplingSpanProcessor) Shutdown(ctx context.Context) error {
 	tsp.decisionBatcher.Stop()
 	tsp.policyTicker.Stop()
-	return nil
+	if tsp.storageClient == nil {
+		return nil
+	}
+	// The spans of the pending traces are already in the storage, only the
+	// list of pending traces and the decision caches remain to be written.
+	return errors.Join(
+		tsp.flushStorage(ctx),
+		tsp.storageClient.Close(ctx),
+	)
 }
 
 func (tsp *tailSamplingSpanProcessor) dropTrace(traceID pcommon.TraceID, deletionTime time.Time) {
@@ -477,6 +625,13 @@ func (tsp *tailSamplingSpanProcessor) dropTrace(traceID pcommon.TraceID, deletio
 	if d, ok := tsp.idToTrace.Load(traceID); ok {
 		trace = d.(*sampling.TraceData)
 		tsp.idToTrace.Delete(traceID)
+		if tsp.storageClient != nil {
+			trace.Lock()
+			tsp.deleteStoredBatches(traceID, trace.StoredBatches)
+			trace.StoredBatches = 0
+			trace.Unlock()
+			tsp.forgetPendingTrace(traceID)
+		}
 		// Subtract one from numTracesOnMap per https://godoc.org/sync/atomic#AddUint64
 		tsp.numTracesOnMap.Add(^uint64(0))
 	}
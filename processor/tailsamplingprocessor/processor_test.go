@@ -20,10 +20,12 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/timeutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/idbatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
 )
@@ -119,17 +121,19 @@ func TestTraceIntegrity(t *testing.T) {
 	mpe := &mockPolicyEvaluator{}
 	mtt := &manualTTicker{}
 	tsp := &tailSamplingSpanProcessor{
-		ctx:             context.Background(),
-		nextConsumer:    msp,
-		maxNumTraces:    spanCount,
-		logger:          zap.NewNop(),
-		decisionBatcher: newSyncIDBatcher(1),
-		policies:        []*policy{{name: "mock-policy", evaluator: mpe, ctx: context.TODO()}},
-		deleteChan:      make(chan pcommon.TraceID, spanCount),
-		policyTicker:    mtt,
-		tickerFrequency: 100 * time.Millisecond,
-		numTracesOnMap:  &atomic.Uint64{},
-		mutatorsBuf:     make([]tag.Mutator, 1),
+		ctx:               context.Background(),
+		nextConsumer:      msp,
+		maxNumTraces:      spanCount,
+		logger:            zap.NewNop(),
+		decisionBatcher:   newSyncIDBatcher(1),
+		policies:          []*policy{{name: "mock-policy", evaluator: mpe, ctx: context.TODO()}},
+		deleteChan:        make(chan pcommon.TraceID, spanCount),
+		policyTicker:      mtt,
+		tickerFrequency:   100 * time.Millisecond,
+		numTracesOnMap:    &atomic.Uint64{},
+		mutatorsBuf:       make([]tag.Mutator, 1),
+		sampledIDCache:    cache.NewNopDecisionCache[bool](),
+		nonSampledIDCache: cache.NewNopDecisionCache[bool](),
 	}
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
 	defer func() {
@@ -170,7 +174,7 @@ func TestSequentialTraceArrival(t *testing.T) {
 		PolicyCfgs:              testPolicy,
 	}
 
-	sp, _ := newTracesProcessor(context.Background(), componenttest.NewNopTelemetrySettings(), consumertest.NewNop(), cfg)
+	sp, _ := newTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), consumertest.NewNop(), cfg)
 	tsp := sp.(*tailSamplingSpanProcessor)
 	tsp.tickerFrequency = 100 * time.Millisecond
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
@@ -200,7 +204,7 @@ func TestConcurrentTraceArrival(t *testing.T) {
 		ExpectedNewTracesPerSec: 64,
 		PolicyCfgs:              testPolicy,
 	}
-	sp, _ := newTracesProcessor(context.Background(), componenttest.NewNopTelemetrySettings(), consumertest.NewNop(), cfg)
+	sp, _ := newTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), consumertest.NewNop(), cfg)
 	tsp := sp.(*tailSamplingSpanProcessor)
 	tsp.tickerFrequency = 100 * time.Millisecond
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
@@ -251,7 +255,7 @@ func TestConcurrentArrivalAndEvaluation(t *testing.T) {
 		ExpectedNewTracesPerSec: 64,
 		PolicyCfgs:              testLatencyPolicy,
 	}
-	sp, _ := newTracesProcessor(context.Background(), componenttest.NewNopTelemetrySettings(), consumertest.NewNop(), cfg)
+	sp, _ := newTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), consumertest.NewNop(), cfg)
 	tsp := sp.(*tailSamplingSpanProcessor)
 	tsp.tickerFrequency = 1 * time.Millisecond
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
@@ -293,7 +297,7 @@ func TestSequentialTraceMapSize(t *testing.T) {
 		ExpectedNewTracesPerSec: 64,
 		PolicyCfgs:              testPolicy,
 	}
-	sp, _ := newTracesProcessor(context.Background(), componenttest.NewNopTelemetrySettings(), consumertest.NewNop(), cfg)
+	sp, _ := newTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), consumertest.NewNop(), cfg)
 	tsp := sp.(*tailSamplingSpanProcessor)
 	tsp.tickerFrequency = 100 * time.Millisecond
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
@@ -323,7 +327,7 @@ func TestConcurrentTraceMapSize(t *testing.T) {
 		ExpectedNewTracesPerSec: 64,
 		PolicyCfgs:              testPolicy,
 	}
-	sp, _ := newTracesProcessor(context.Background(), componenttest.NewNopTelemetrySettings(), consumertest.NewNop(), cfg)
+	sp, _ := newTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), consumertest.NewNop(), cfg)
 	tsp := sp.(*tailSamplingSpanProcessor)
 	tsp.tickerFrequency = 100 * time.Millisecond
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
@@ -360,17 +364,19 @@ func TestSamplingPolicyTypicalPath(t *testing.T) {
 	mpe := &mockPolicyEvaluator{}
 	mtt := &manualTTicker{}
 	tsp := &tailSamplingSpanProcessor{
-		ctx:             context.Background(),
-		nextConsumer:    msp,
-		maxNumTraces:    maxSize,
-		logger:          zap.NewNop(),
-		decisionBatcher: newSyncIDBatcher(decisionWaitSeconds),
-		policies:        []*policy{{name: "mock-policy", evaluator: mpe, ctx: context.TODO()}},
-		deleteChan:      make(chan pcommon.TraceID, maxSize),
-		policyTicker:    mtt,
-		tickerFrequency: 100 * time.Millisecond,
-		numTracesOnMap:  &atomic.Uint64{},
-		mutatorsBuf:     make([]tag.Mutator, 1),
+		ctx:               context.Background(),
+		nextConsumer:      msp,
+		maxNumTraces:      maxSize,
+		logger:            zap.NewNop(),
+		decisionBatcher:   newSyncIDBatcher(decisionWaitSeconds),
+		policies:          []*policy{{name: "mock-policy", evaluator: mpe, ctx: context.TODO()}},
+		deleteChan:        make(chan pcommon.TraceID, maxSize),
+		policyTicker:      mtt,
+		tickerFrequency:   100 * time.Millisecond,
+		numTracesOnMap:    &atomic.Uint64{},
+		mutatorsBuf:       make([]tag.Mutator, 1),
+		sampledIDCache:    cache.NewNopDecisionCache[bool](),
+		nonSampledIDCache: cache.NewNopDecisionCache[bool](),
 	}
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
 	defer func() {
@@ -422,17 +428,19 @@ func TestSamplingPolicyInvertSampled(t *testing.T) {
 	mpe := &mockPolicyEvaluator{}
 	mtt := &manualTTicker{}
 	tsp := &tailSamplingSpanProcessor{
-		ctx:             context.Background(),
-		nextConsumer:    msp,
-		maxNumTraces:    maxSize,
-		logger:          zap.NewNop(),
-		decisionBatcher: newSyncIDBatcher(decisionWaitSeconds),
-		policies:        []*policy{{name: "mock-policy", evaluator: mpe, ctx: context.TODO()}},
-		deleteChan:      make(chan pcommon.TraceID, maxSize),
-		policyTicker:    mtt,
-		tickerFrequency: 100 * time.Millisecond,
-		numTracesOnMap:  &atomic.Uint64{},
-		mutatorsBuf:     make([]tag.Mutator, 1),
+		ctx:               context.Background(),
+		nextConsumer:      msp,
+		maxNumTraces:      maxSize,
+		logger:            zap.NewNop(),
+		decisionBatcher:   newSyncIDBatcher(decisionWaitSeconds),
+		policies:          []*policy{{name: "mock-policy", evaluator: mpe, ctx: context.TODO()}},
+		deleteChan:        make(chan pcommon.TraceID, maxSize),
+		policyTicker:      mtt,
+		tickerFrequency:   100 * time.Millisecond,
+		numTracesOnMap:    &atomic.Uint64{},
+		mutatorsBuf:       make([]tag.Mutator, 1),
+		sampledIDCache:    cache.NewNopDecisionCache[bool](),
+		nonSampledIDCache: cache.NewNopDecisionCache[bool](),
 	}
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
 	defer func() {
@@ -497,11 +505,13 @@ func TestSamplingMultiplePolicies(t *testing.T) {
 			{
 				name: "policy-2", evaluator: mpe2, ctx: context.TODO(),
 			}},
-		deleteChan:      make(chan pcommon.TraceID, maxSize),
-		policyTicker:    mtt,
-		tickerFrequency: 100 * time.Millisecond,
-		numTracesOnMap:  &atomic.Uint64{},
-		mutatorsBuf:     make([]tag.Mutator, 1),
+		deleteChan:        make(chan pcommon.TraceID, maxSize),
+		policyTicker:      mtt,
+		tickerFrequency:   100 * time.Millisecond,
+		numTracesOnMap:    &atomic.Uint64{},
+		mutatorsBuf:       make([]tag.Mutator, 1),
+		sampledIDCache:    cache.NewNopDecisionCache[bool](),
+		nonSampledIDCache: cache.NewNopDecisionCache[bool](),
 	}
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
 	defer func() {
@@ -555,17 +565,19 @@ func TestSamplingPolicyDecisionNotSampled(t *testing.T) {
 	mpe := &mockPolicyEvaluator{}
 	mtt := &manualTTicker{}
 	tsp := &tailSamplingSpanProcessor{
-		ctx:             context.Background(),
-		nextConsumer:    msp,
-		maxNumTraces:    maxSize,
-		logger:          zap.NewNop(),
-		decisionBatcher: newSyncIDBatcher(decisionWaitSeconds),
-		policies:        []*policy{{name: "mock-policy", evaluator: mpe, ctx: context.TODO()}},
-		deleteChan:      make(chan pcommon.TraceID, maxSize),
-		policyTicker:    mtt,
-		tickerFrequency: 100 * time.Millisecond,
-		numTracesOnMap:  &atomic.Uint64{},
-		mutatorsBuf:     make([]tag.Mutator, 1),
+		ctx:               context.Background(),
+		nextConsumer:      msp,
+		maxNumTraces:      maxSize,
+		logger:            zap.NewNop(),
+		decisionBatcher:   newSyncIDBatcher(decisionWaitSeconds),
+		policies:          []*policy{{name: "mock-policy", evaluator: mpe, ctx: context.TODO()}},
+		deleteChan:        make(chan pcommon.TraceID, maxSize),
+		policyTicker:      mtt,
+		tickerFrequency:   100 * time.Millisecond,
+		numTracesOnMap:    &atomic.Uint64{},
+		mutatorsBuf:       make([]tag.Mutator, 1),
+		sampledIDCache:    cache.NewNopDecisionCache[bool](),
+		nonSampledIDCache: cache.NewNopDecisionCache[bool](),
 	}
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
 	defer func() {
@@ -619,17 +631,19 @@ func TestSamplingPolicyDecisionInvertNotSampled(t *testing.T) {
 	mpe := &mockPolicyEvaluator{}
 	mtt := &manualTTicker{}
 	tsp := &tailSamplingSpanProcessor{
-		ctx:             context.Background(),
-		nextConsumer:    msp,
-		maxNumTraces:    maxSize,
-		logger:          zap.NewNop(),
-		decisionBatcher: newSyncIDBatcher(decisionWaitSeconds),
-		policies:        []*policy{{name: "mock-policy", evaluator: mpe, ctx: context.TODO()}},
-		deleteChan:      make(chan pcommon.TraceID, maxSize),
-		policyTicker:    mtt,
-		tickerFrequency: 100 * time.Millisecond,
-		mutatorsBuf:     make([]tag.Mutator, 1),
-		numTracesOnMap:  &atomic.Uint64{},
+		ctx:               context.Background(),
+		nextConsumer:      msp,
+		maxNumTraces:      maxSize,
+		logger:            zap.NewNop(),
+		decisionBatcher:   newSyncIDBatcher(decisionWaitSeconds),
+		policies:          []*policy{{name: "mock-policy", evaluator: mpe, ctx: context.TODO()}},
+		deleteChan:        make(chan pcommon.TraceID, maxSize),
+		policyTicker:      mtt,
+		tickerFrequency:   100 * time.Millisecond,
+		mutatorsBuf:       make([]tag.Mutator, 1),
+		numTracesOnMap:    &atomic.Uint64{},
+		sampledIDCache:    cache.NewNopDecisionCache[bool](),
+		nonSampledIDCache: cache.NewNopDecisionCache[bool](),
 	}
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
 	defer func() {
@@ -689,11 +703,13 @@ func TestLateArrivingSpansAssignedOriginalDecision(t *testing.T) {
 			{name: "mock-policy-1", evaluator: mpe1, ctx: context.TODO()},
 			{name: "mock-policy-2", evaluator: mpe2, ctx: context.TODO()},
 		},
-		deleteChan:      make(chan pcommon.TraceID, maxSize),
-		policyTicker:    &manualTTicker{},
-		tickerFrequency: 100 * time.Millisecond,
-		numTracesOnMap:  &atomic.Uint64{},
-		mutatorsBuf:     make([]tag.Mutator, 1),
+		deleteChan:        make(chan pcommon.TraceID, maxSize),
+		policyTicker:      &manualTTicker{},
+		tickerFrequency:   100 * time.Millisecond,
+		numTracesOnMap:    &atomic.Uint64{},
+		mutatorsBuf:       make([]tag.Mutator, 1),
+		sampledIDCache:    cache.NewNopDecisionCache[bool](),
+		nonSampledIDCache: cache.NewNopDecisionCache[bool](),
 	}
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
 	defer func() {
@@ -742,6 +758,133 @@ func TestLateArrivingSpansAssignedOriginalDecision(t *testing.T) {
 	require.EqualValues(t, 0, nextConsumer.SpanCount(), "original final decision not honored")
 }
 
+func TestDropPolicyOverridesOtherPolicies(t *testing.T) {
+	nextConsumer := new(consumertest.TracesSink)
+	p, err := newTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), nextConsumer, Config{
+		DecisionWait: time.Second,
+		NumTraces:    100,
+	})
+	require.NoError(t, err)
+
+	sampled := &mockPolicyEvaluator{NextDecision: sampling.Sampled}
+	dropped := &mockPolicyEvaluator{NextDecision: sampling.Dropped}
+	tsp := p.(*tailSamplingSpanProcessor)
+	tsp.decisionBatcher.Stop()
+	tsp.decisionBatcher = newSyncIDBatcher(1)
+	tsp.policyTicker = &manualTTicker{}
+	tsp.policies = []*policy{
+		{name: "sampled-policy", evaluator: sampled, ctx: context.TODO()},
+		{name: "drop-policy", evaluator: dropped, ctx: context.TODO()},
+	}
+	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	traceID := uInt64ToTraceID(1)
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+	require.EqualValues(t, 1, sampled.EvaluationCount)
+	require.EqualValues(t, 1, dropped.EvaluationCount)
+
+	d, ok := tsp.idToTrace.Load(traceID)
+	require.True(t, ok)
+	trace := d.(*sampling.TraceData)
+	assert.Equal(t, sampling.NotSampled, trace.FinalDecision)
+	assert.Equal(t, []sampling.Decision{sampling.Sampled, sampling.Dropped}, trace.Decisions)
+	assert.Equal(t, 0, nextConsumer.SpanCount())
+
+	// Late spans are not sampled either.
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+	assert.Equal(t, 0, nextConsumer.SpanCount())
+}
+
+func TestLateArrivingSpansUseDecisionCache(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		decision sampling.Decision
+		expected int
+	}{
+		{name: "sampled", decision: sampling.Sampled, expected: 2},
+		{name: "not_sampled", decision: sampling.NotSampled, expected: 0},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			nextConsumer := new(consumertest.TracesSink)
+			cfg := Config{
+				DecisionWait: time.Second,
+				NumTraces:    100,
+				DecisionCache: DecisionCacheConfig{
+					SampledCacheSize:    10,
+					NonSampledCacheSize: 10,
+				},
+			}
+			p, err := newTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), nextConsumer, cfg)
+			require.NoError(t, err)
+
+			mpe := &mockPolicyEvaluator{NextDecision: tt.decision}
+			tsp := p.(*tailSamplingSpanProcessor)
+			// Replace the batcher and ticker to control the decisions from the test.
+			tsp.decisionBatcher.Stop()
+			tsp.decisionBatcher = newSyncIDBatcher(1)
+			tsp.policyTicker = &manualTTicker{}
+			tsp.policies = []*policy{{name: "mock-policy", evaluator: mpe, ctx: context.TODO()}}
+			require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() {
+				require.NoError(t, tsp.Shutdown(context.Background()))
+			}()
+
+			traceID := uInt64ToTraceID(1)
+			spanIndexToTraces := func(spanIndex uint64) ptrace.Traces {
+				traces := ptrace.NewTraces()
+				span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+				span.SetTraceID(traceID)
+				span.SetSpanID(uInt64ToSpanID(spanIndex))
+				return traces
+			}
+
+			require.NoError(t, tsp.ConsumeTraces(context.Background(), spanIndexToTraces(1)))
+			tsp.samplingPolicyOnTick()
+			tsp.samplingPolicyOnTick()
+			require.EqualValues(t, 1, mpe.EvaluationCount)
+
+			// The trace is removed from memory, as if it had been pushed out by newer traces.
+			tsp.dropTrace(traceID, time.Now())
+			_, ok := tsp.idToTrace.Load(traceID)
+			require.False(t, ok)
+
+			// The late span follows the cached decision, without a new evaluation.
+			require.NoError(t, tsp.ConsumeTraces(context.Background(), spanIndexToTraces(2)))
+			tsp.samplingPolicyOnTick()
+			tsp.samplingPolicyOnTick()
+			require.EqualValues(t, 1, mpe.EvaluationCount)
+			_, ok = tsp.idToTrace.Load(traceID)
+			require.False(t, ok)
+			require.Equal(t, tt.expected, nextConsumer.SpanCount())
+		})
+	}
+}
+
+func TestDecisionCacheDisabled(t *testing.T) {
+	p, err := newTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), consumertest.NewNop(), Config{
+		DecisionWait: time.Second,
+		NumTraces:    100,
+	})
+	require.NoError(t, err)
+	tsp := p.(*tailSamplingSpanProcessor)
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	id := uInt64ToTraceID(1)
+	tsp.sampledIDCache.Put(id, true)
+	tsp.nonSampledIDCache.Put(id, true)
+	_, ok := tsp.sampledIDCache.Get(id)
+	assert.False(t, ok)
+	_, ok = tsp.nonSampledIDCache.Get(id)
+	assert.False(t, ok)
+}
+
 func TestMultipleBatchesAreCombinedIntoOne(t *testing.T) {
 	const maxSize = 100
 	const decisionWaitSeconds = 1
@@ -751,17 +894,19 @@ func TestMultipleBatchesAreCombinedIntoOne(t *testing.T) {
 	mpe := &mockPolicyEvaluator{}
 	mtt := &manualTTicker{}
 	tsp := &tailSamplingSpanProcessor{
-		ctx:             context.Background(),
-		nextConsumer:    msp,
-		maxNumTraces:    maxSize,
-		logger:          zap.NewNop(),
-		decisionBatcher: newSyncIDBatcher(decisionWaitSeconds),
-		policies:        []*policy{{name: "mock-policy", evaluator: mpe, ctx: context.TODO()}},
-		deleteChan:      make(chan pcommon.TraceID, maxSize),
-		policyTicker:    mtt,
-		tickerFrequency: 100 * time.Millisecond,
-		numTracesOnMap:  &atomic.Uint64{},
-		mutatorsBuf:     make([]tag.Mutator, 1),
+		ctx:               context.Background(),
+		nextConsumer:      msp,
+		maxNumTraces:      maxSize,
+		logger:            zap.NewNop(),
+		decisionBatcher:   newSyncIDBatcher(decisionWaitSeconds),
+		policies:          []*policy{{name: "mock-policy", evaluator: mpe, ctx: context.TODO()}},
+		deleteChan:        make(chan pcommon.TraceID, maxSize),
+		policyTicker:      mtt,
+		tickerFrequency:   100 * time.Millisecond,
+		numTracesOnMap:    &atomic.Uint64{},
+		mutatorsBuf:       make([]tag.Mutator, 1),
+		sampledIDCache:    cache.NewNopDecisionCache[bool](),
+		nonSampledIDCache: cache.NewNopDecisionCache[bool](),
 	}
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
 	defer func() {
@@ -819,7 +964,7 @@ func TestSubSecondDecisionTime(t *testing.T) {
 	// prepare
 	msp := new(consumertest.TracesSink)
 
-	tsp, err := newTracesProcessor(context.Background(), componenttest.NewNopTelemetrySettings(), msp, Config{
+	tsp, err := newTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), msp, Config{
 		DecisionWait: 500 * time.Millisecond,
 		NumTraces:    uint64(50000),
 		PolicyCfgs:   testPolicy,
@@ -870,7 +1015,7 @@ func TestPolicyLoggerAddsPolicyName(t *testing.T) {
 
 func TestDuplicatePolicyName(t *testing.T) {
 	// prepare
-	set := componenttest.NewNopTelemetrySettings()
+	set := processortest.NewNopCreateSettings()
 	msp := new(consumertest.TracesSink)
 
 	alwaysSample := sharedPolicyCfg{
@@ -1040,7 +1185,7 @@ func BenchmarkSampling(b *testing.B) {
 		PolicyCfgs:              testPolicy,
 	}
 
-	sp, _ := newTracesProcessor(context.Background(), componenttest.NewNopTelemetrySettings(), consumertest.NewNop(), cfg)
+	sp, _ := newTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), consumertest.NewNop(), cfg)
 	tsp := sp.(*tailSamplingSpanProcessor)
 	require.NoError(b, tsp.Start(context.Background(), componenttest.NewNopHost()))
 	defer func() {
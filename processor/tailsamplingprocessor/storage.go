@@ -0,0 +1,373 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+const (
+	// pendingTracesKey holds the IDs and arrival times of the traces pending
+	// a sampling decision, as of the last flush.
+	pendingTracesKey = "pending_traces"
+	// journalStartKey holds the sequence number of the first entry of the
+	// journal, which records the traces stored since the last flush.
+	journalStartKey = "pending_journal_start"
+	// sampledIDsKey and nonSampledIDsKey hold the snapshots of the decision caches.
+	sampledIDsKey    = "sampled_ids"
+	nonSampledIDsKey = "non_sampled_ids"
+
+	// pendingTraceEntrySize is the size of an entry of the pending traces:
+	// the trace ID followed by its arrival time in nanoseconds.
+	pendingTraceEntrySize = 16 + 8
+)
+
+var (
+	errInvalidPendingTraces = errors.New("invalid pending traces in storage")
+
+	tracesMarshaler   = &ptrace.ProtoMarshaler{}
+	tracesUnmarshaler = &ptrace.ProtoUnmarshaler{}
+)
+
+func getStorageClient(ctx context.Context, host component.Host, storageID *component.ID, componentID component.ID) (storage.Client, error) {
+	extension, ok := host.GetExtensions()[*storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExtension.GetClient(ctx, component.KindProcessor, componentID, "")
+}
+
+// batchKey returns the storage key of the n-th batch stored for a trace.
+func batchKey(id pcommon.TraceID, n int) string {
+	return fmt.Sprintf("trace_%s_%d", id, n)
+}
+
+// journalKey returns the storage key of an entry of the journal.
+func journalKey(seq uint64) string {
+	return fmt.Sprintf("pending_journal_%d", seq)
+}
+
+func appendPendingTrace(data []byte, id pcommon.TraceID, arrivalTime time.Time) []byte {
+	data = append(data, id[:]...)
+	return binary.LittleEndian.AppendUint64(data, uint64(arrivalTime.UnixNano()))
+}
+
+// pendingTraces tracks the traces with batches in the storage, so that no
+// batch is left behind in the storage after a crash. A trace is written to an
+// entry of the journal along with its first batch, and the journal is folded
+// into the list of pending traces on every flush.
+type pendingTraces struct {
+	sync.Mutex
+	traces map[pcommon.TraceID]time.Time
+	// dirty is set when traces changed since the last flush.
+	dirty bool
+	// The journal holds the entries from journalStart to journalEnd, excluded.
+	journalStart, journalEnd uint64
+}
+
+// storeBatch writes the spans of a pending trace to the storage, it must be
+// called with the trace lock held. It returns false when the spans were not
+// stored, in which case they must be kept in memory.
+func (tsp *tailSamplingSpanProcessor) storeBatch(id pcommon.TraceID, trace *sampling.TraceData, rss ptrace.ResourceSpans, spans []spanAndScope) bool {
+	if tsp.storageClient == nil {
+		return false
+	}
+
+	td := ptrace.NewTraces()
+	appendToTraces(td, rss, spans)
+	data, err := tracesMarshaler.MarshalTraces(td)
+	if err == nil {
+		if trace.StoredBatches == 0 {
+			err = tsp.storeFirstBatch(id, trace.ArrivalTime, data)
+		} else {
+			err = tsp.storageClient.Set(tsp.ctx, batchKey(id, trace.StoredBatches), data)
+		}
+	}
+	if err != nil {
+		tsp.logger.Warn("Failed to store spans, keeping them in memory", zap.Error(err))
+		return false
+	}
+	trace.StoredBatches++
+	return true
+}
+
+// storeFirstBatch writes the first batch of a trace to the storage, along with
+// an entry of the journal when the trace is not tracked yet.
+func (tsp *tailSamplingSpanProcessor) storeFirstBatch(id pcommon.TraceID, arrivalTime time.Time, data []byte) error {
+	pending := &tsp.pendingTraces
+	pending.Lock()
+	defer pending.Unlock()
+
+	if _, ok := pending.traces[id]; ok {
+		// The batches of the trace were loaded for its evaluation.
+		return tsp.storageClient.Set(tsp.ctx, batchKey(id, 0), data)
+	}
+	err := tsp.storageClient.Batch(tsp.ctx,
+		storage.SetOperation(batchKey(id, 0), data),
+		storage.SetOperation(journalKey(pending.journalEnd), appendPendingTrace(nil, id, arrivalTime)),
+	)
+	if err != nil {
+		return err
+	}
+	pending.journalEnd++
+	pending.traces[id] = arrivalTime
+	pending.dirty = true
+	return nil
+}
+
+// forgetPendingTrace stops tracking a trace once its batches were removed
+// from the storage.
+func (tsp *tailSamplingSpanProcessor) forgetPendingTrace(id pcommon.TraceID) {
+	pending := &tsp.pendingTraces
+	pending.Lock()
+	defer pending.Unlock()
+
+	if _, ok := pending.traces[id]; ok {
+		delete(pending.traces, id)
+		pending.dirty = true
+	}
+}
+
+// loadStoredBatches moves the batches of a trace held in the storage into its
+// ReceivedBatches, it must be called with the trace lock held.
+func (tsp *tailSamplingSpanProcessor) loadStoredBatches(id pcommon.TraceID, trace *sampling.TraceData) {
+	if trace.StoredBatches == 0 {
+		return
+	}
+
+	ops := make([]storage.Operation, trace.StoredBatches)
+	for i := range ops {
+		ops[i] = storage.GetOperation(batchKey(id, i))
+	}
+	if err := tsp.storageClient.Batch(tsp.ctx, ops...); err != nil {
+		tsp.logger.Warn("Failed to load spans from storage", zap.Error(err))
+		return
+	}
+	for _, op := range ops {
+		if op.Value == nil {
+			continue
+		}
+		td, err := tracesUnmarshaler.UnmarshalTraces(op.Value)
+		if err != nil {
+			tsp.logger.Warn("Failed to decode spans from storage", zap.Error(err))
+			continue
+		}
+		td.ResourceSpans().MoveAndAppendTo(trace.ReceivedBatches.ResourceSpans())
+	}
+
+	tsp.deleteStoredBatches(id, trace.StoredBatches)
+	trace.StoredBatches = 0
+}
+
+// deleteStoredBatches removes the first n batches of a trace from the storage.
+func (tsp *tailSamplingSpanProcessor) deleteStoredBatches(id pcommon.TraceID, n int) {
+	if n == 0 {
+		return
+	}
+
+	ops := make([]storage.Operation, n)
+	for i := range ops {
+		ops[i] = storage.DeleteOperation(batchKey(id, i))
+	}
+	if err := tsp.storageClient.Batch(tsp.ctx, ops...); err != nil {
+		tsp.logger.Warn("Failed to delete spans from storage", zap.Error(err))
+	}
+}
+
+// storePendingTraces writes the IDs of the traces pending a decision, which
+// allows finding their batches in the storage after a restart, and removes the
+// entries of the journal they replace.
+func (tsp *tailSamplingSpanProcessor) storePendingTraces(ctx context.Context) error {
+	pending := &tsp.pendingTraces
+	pending.Lock()
+	defer pending.Unlock()
+
+	if !pending.dirty && pending.journalStart == pending.journalEnd {
+		return nil
+	}
+
+	data := make([]byte, 0, len(pending.traces)*pendingTraceEntrySize)
+	for id, arrivalTime := range pending.traces {
+		data = appendPendingTrace(data, id, arrivalTime)
+	}
+	ops := []storage.Operation{
+		storage.SetOperation(pendingTracesKey, data),
+		storage.SetOperation(journalStartKey, binary.LittleEndian.AppendUint64(nil, pending.journalEnd)),
+	}
+	for seq := pending.journalStart; seq < pending.journalEnd; seq++ {
+		ops = append(ops, storage.DeleteOperation(journalKey(seq)))
+	}
+	if err := tsp.storageClient.Batch(ctx, ops...); err != nil {
+		return err
+	}
+	pending.journalStart = pending.journalEnd
+	pending.dirty = false
+	return nil
+}
+
+// storeDecisionCaches writes the snapshots of the decision caches.
+func (tsp *tailSamplingSpanProcessor) storeDecisionCaches(ctx context.Context) error {
+	return tsp.storageClient.Batch(ctx,
+		storage.SetOperation(sampledIDsKey, tsp.sampledIDCache.Snapshot()),
+		storage.SetOperation(nonSampledIDsKey, tsp.nonSampledIDCache.Snapshot()),
+	)
+}
+
+// flushStorage writes the pending traces and the decision caches to the storage.
+func (tsp *tailSamplingSpanProcessor) flushStorage(ctx context.Context) error {
+	tsp.lastStorageFlush = time.Now()
+	return errors.Join(
+		tsp.storePendingTraces(ctx),
+		tsp.storeDecisionCaches(ctx),
+	)
+}
+
+// restoreFromStorage reloads the decision caches and the traces that were
+// pending a decision when the processor was last stopped, including those only
+// recorded in the journal after a crash. The traces are evaluated once the
+// decision wait has elapsed again, the batches of the traces which cannot be
+// reloaded are deleted.
+func (tsp *tailSamplingSpanProcessor) restoreFromStorage(ctx context.Context) error {
+	ops := []storage.Operation{
+		storage.GetOperation(pendingTracesKey),
+		storage.GetOperation(journalStartKey),
+		storage.GetOperation(sampledIDsKey),
+		storage.GetOperation(nonSampledIDsKey),
+	}
+	if err := tsp.storageClient.Batch(ctx, ops...); err != nil {
+		return err
+	}
+
+	if err := tsp.sampledIDCache.Restore(ops[2].Value, true); err != nil {
+		return err
+	}
+	if err := tsp.nonSampledIDCache.Restore(ops[3].Value, true); err != nil {
+		return err
+	}
+
+	data := ops[0].Value
+	if len(data)%pendingTraceEntrySize != 0 {
+		return errInvalidPendingTraces
+	}
+	var journalStart uint64
+	if start := ops[1].Value; start != nil {
+		if len(start) != 8 {
+			return errInvalidPendingTraces
+		}
+		journalStart = binary.LittleEndian.Uint64(start)
+	}
+	journalEnd := journalStart
+	for ; ; journalEnd++ {
+		entry, err := tsp.storageClient.Get(ctx, journalKey(journalEnd))
+		if err != nil {
+			return err
+		}
+		if entry == nil {
+			break
+		}
+		if len(entry) != pendingTraceEntrySize {
+			return errInvalidPendingTraces
+		}
+		data = append(data, entry...)
+	}
+
+	pending := &tsp.pendingTraces
+	pending.journalStart, pending.journalEnd = journalStart, journalEnd
+	pending.dirty = true
+	var restored int
+	for i := 0; i < len(data); i += pendingTraceEntrySize {
+		var id pcommon.TraceID
+		copy(id[:], data[i:i+16])
+		arrivalTime := time.Unix(0, int64(binary.LittleEndian.Uint64(data[i+16:])))
+		if _, ok := pending.traces[id]; ok {
+			continue
+		}
+
+		trace, err := tsp.restoreTrace(ctx, id, arrivalTime)
+		if err != nil {
+			return err
+		}
+		if trace == nil {
+			continue
+		}
+
+		select {
+		case tsp.deleteChan <- id:
+		default:
+			// There are more pending traces than num_traces allows.
+			tsp.deleteStoredBatches(id, trace.StoredBatches)
+			continue
+		}
+		tsp.idToTrace.Store(id, trace)
+		tsp.decisionBatcher.AddToCurrentBatch(id)
+		tsp.numTracesOnMap.Add(1)
+		pending.traces[id] = arrivalTime
+		restored++
+	}
+
+	tsp.logger.Debug("Restored pending traces from storage", zap.Int("traces", restored))
+	// Fold the journal into the pending traces, so that the traces which were
+	// not reloaded are no longer referenced.
+	return tsp.storePendingTraces(ctx)
+}
+
+// restoreTrace rebuilds the data of a pending trace from its stored batches,
+// it returns nil when no batch is found.
+func (tsp *tailSamplingSpanProcessor) restoreTrace(ctx context.Context, id pcommon.TraceID, arrivalTime time.Time) (*sampling.TraceData, error) {
+	var spanCount int64
+	var n int
+	for ; ; n++ {
+		data, err := tsp.storageClient.Get(ctx, batchKey(id, n))
+		if err != nil {
+			return nil, err
+		}
+		if data == nil {
+			break
+		}
+		td, err := tracesUnmarshaler.UnmarshalTraces(data)
+		if err != nil {
+			// The batch is skipped when loaded for the decision.
+			tsp.logger.Warn("Failed to decode spans from storage", zap.Error(err))
+			continue
+		}
+		spanCount += int64(td.SpanCount())
+	}
+	if n == 0 {
+		return nil, nil
+	}
+
+	decisions := make([]sampling.Decision, len(tsp.policies))
+	for i := range decisions {
+		decisions[i] = sampling.Pending
+	}
+	count := &atomic.Int64{}
+	count.Store(spanCount)
+	return &sampling.TraceData{
+		Decisions:       decisions,
+		ArrivalTime:     arrivalTime,
+		SpanCount:       count,
+		ReceivedBatches: ptrace.NewTraces(),
+		StoredBatches:   n,
+	}, nil
+}
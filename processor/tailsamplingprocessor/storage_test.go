@@ -0,0 +1,283 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+func newStorageTestProcessor(t *testing.T, set processor.CreateSettings, host component.Host, mpe *mockPolicyEvaluator, nextConsumer *consumertest.TracesSink, opts ...func(*Config)) *tailSamplingSpanProcessor {
+	storageID := storagetest.NewStorageID("test")
+	cfg := Config{
+		DecisionWait: time.Second,
+		NumTraces:    100,
+		DecisionCache: DecisionCacheConfig{
+			SampledCacheSize:    10,
+			NonSampledCacheSize: 10,
+		},
+		StorageID: &storageID,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	p, err := newTracesProcessor(context.Background(), set, nextConsumer, cfg)
+	require.NoError(t, err)
+
+	tsp := p.(*tailSamplingSpanProcessor)
+	// Replace the batcher and ticker to control the decisions from the test.
+	tsp.decisionBatcher.Stop()
+	tsp.decisionBatcher = newSyncIDBatcher(1)
+	tsp.policyTicker = &manualTTicker{}
+	tsp.policies = []*policy{{name: "mock-policy", evaluator: mpe, ctx: context.TODO()}}
+	require.NoError(t, tsp.Start(context.Background(), host))
+	return tsp
+}
+
+func TestStorageHoldsPendingSpans(t *testing.T) {
+	nextConsumer := new(consumertest.TracesSink)
+	mpe := &mockPolicyEvaluator{NextDecision: sampling.Sampled}
+	tsp := newStorageTestProcessor(t, processortest.NewNopCreateSettings(), storagetest.NewStorageHost().WithInMemoryStorageExtension("test"), mpe, nextConsumer)
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	traceID := uInt64ToTraceID(1)
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+
+	d, ok := tsp.idToTrace.Load(traceID)
+	require.True(t, ok)
+	trace := d.(*sampling.TraceData)
+	assert.Equal(t, 2, trace.StoredBatches)
+	assert.Equal(t, 0, trace.ReceivedBatches.SpanCount())
+
+	data, err := tsp.storageClient.Get(context.Background(), batchKey(traceID, 1))
+	require.NoError(t, err)
+	assert.NotNil(t, data)
+
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+	require.EqualValues(t, 1, mpe.EvaluationCount)
+	assert.Equal(t, 2, nextConsumer.SpanCount())
+
+	// The stored batches are removed once the decision is made.
+	assert.Equal(t, 0, trace.StoredBatches)
+	for i := 0; i < 2; i++ {
+		data, err = tsp.storageClient.Get(context.Background(), batchKey(traceID, i))
+		require.NoError(t, err)
+		assert.Nil(t, data)
+	}
+}
+
+func TestStorageDroppedTraceIsDeleted(t *testing.T) {
+	mpe := &mockPolicyEvaluator{NextDecision: sampling.Sampled}
+	tsp := newStorageTestProcessor(t, processortest.NewNopCreateSettings(), storagetest.NewStorageHost().WithInMemoryStorageExtension("test"), mpe, new(consumertest.TracesSink))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	traceID := uInt64ToTraceID(1)
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+	tsp.dropTrace(traceID, time.Now())
+
+	data, err := tsp.storageClient.Get(context.Background(), batchKey(traceID, 0))
+	require.NoError(t, err)
+	assert.Nil(t, data)
+}
+
+func TestStorageRestoresAfterRestart(t *testing.T) {
+	// The storage of a component is identified by its ID, which must not
+	// change across restarts.
+	set := processortest.NewNopCreateSettings()
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	pendingID := uInt64ToTraceID(1)
+	sampledID := uInt64ToTraceID(2)
+
+	nextConsumer := new(consumertest.TracesSink)
+	mpe := &mockPolicyEvaluator{NextDecision: sampling.Sampled}
+	tsp := newStorageTestProcessor(t, set, host, mpe, nextConsumer)
+
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(sampledID)))
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+	require.Equal(t, 1, nextConsumer.SpanCount())
+
+	// The second trace is still pending a decision on shutdown.
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(pendingID)))
+	require.NoError(t, tsp.Shutdown(context.Background()))
+
+	nextConsumer = new(consumertest.TracesSink)
+	mpe = &mockPolicyEvaluator{NextDecision: sampling.Sampled}
+	tsp = newStorageTestProcessor(t, set, host, mpe, nextConsumer)
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	d, ok := tsp.idToTrace.Load(pendingID)
+	require.True(t, ok)
+	assert.EqualValues(t, 1, d.(*sampling.TraceData).SpanCount.Load())
+	assert.EqualValues(t, 1, tsp.numTracesOnMap.Load())
+
+	// The late span of the sampled trace follows the restored decision.
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(sampledID)))
+	require.Equal(t, 1, nextConsumer.SpanCount())
+
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+	require.EqualValues(t, 1, mpe.EvaluationCount)
+	require.Equal(t, 2, nextConsumer.SpanCount())
+	assert.Equal(t, pendingID, nextConsumer.AllTraces()[1].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID())
+}
+
+// crash stops the processor without flushing anything to the storage.
+func crash(t *testing.T, tsp *tailSamplingSpanProcessor) {
+	tsp.decisionBatcher.Stop()
+	tsp.policyTicker.Stop()
+	require.NoError(t, tsp.storageClient.Close(context.Background()))
+}
+
+func TestStorageRestoresAfterCrash(t *testing.T) {
+	set := processortest.NewNopCreateSettings()
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	flushHourly := func(cfg *Config) { cfg.StorageFlushInterval = time.Hour }
+	pendingID := uInt64ToTraceID(1)
+
+	tsp := newStorageTestProcessor(t, set, host, &mockPolicyEvaluator{NextDecision: sampling.Sampled}, new(consumertest.TracesSink), flushHourly)
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(pendingID)))
+	tsp.samplingPolicyOnTick()
+
+	// The trace is only recorded in the journal until the next flush.
+	data, err := tsp.storageClient.Get(context.Background(), pendingTracesKey)
+	require.NoError(t, err)
+	assert.Empty(t, data)
+	data, err = tsp.storageClient.Get(context.Background(), journalKey(0))
+	require.NoError(t, err)
+	assert.NotNil(t, data)
+	crash(t, tsp)
+
+	nextConsumer := new(consumertest.TracesSink)
+	mpe := &mockPolicyEvaluator{NextDecision: sampling.Sampled}
+	tsp = newStorageTestProcessor(t, set, host, mpe, nextConsumer, flushHourly)
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	d, ok := tsp.idToTrace.Load(pendingID)
+	require.True(t, ok)
+	assert.EqualValues(t, 1, d.(*sampling.TraceData).SpanCount.Load())
+
+	// The journal is folded into the pending traces once restored.
+	data, err = tsp.storageClient.Get(context.Background(), journalKey(0))
+	require.NoError(t, err)
+	assert.Nil(t, data)
+	data, err = tsp.storageClient.Get(context.Background(), pendingTracesKey)
+	require.NoError(t, err)
+	assert.Len(t, data, pendingTraceEntrySize)
+
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+	require.EqualValues(t, 1, mpe.EvaluationCount)
+	require.Equal(t, 1, nextConsumer.SpanCount())
+}
+
+func TestStorageDeletesTracesBeyondCapacity(t *testing.T) {
+	set := processortest.NewNopCreateSettings()
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	ids := []pcommon.TraceID{uInt64ToTraceID(1), uInt64ToTraceID(2)}
+
+	tsp := newStorageTestProcessor(t, set, host, &mockPolicyEvaluator{NextDecision: sampling.Sampled}, new(consumertest.TracesSink))
+	for _, id := range ids {
+		require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(id)))
+	}
+	crash(t, tsp)
+
+	tsp = newStorageTestProcessor(t, set, host, &mockPolicyEvaluator{NextDecision: sampling.Sampled}, new(consumertest.TracesSink), func(cfg *Config) {
+		cfg.NumTraces = 1
+	})
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+	assert.EqualValues(t, 1, tsp.numTracesOnMap.Load())
+
+	// The batches of the trace which was not reloaded are deleted.
+	var stored int
+	for _, id := range ids {
+		data, err := tsp.storageClient.Get(context.Background(), batchKey(id, 0))
+		require.NoError(t, err)
+		if data != nil {
+			stored++
+		}
+	}
+	assert.Equal(t, 1, stored)
+	data, err := tsp.storageClient.Get(context.Background(), pendingTracesKey)
+	require.NoError(t, err)
+	assert.Len(t, data, pendingTraceEntrySize)
+}
+
+func TestStorageExtensionErrors(t *testing.T) {
+	storageID := storagetest.NewStorageID("test")
+	nonStorageID := storagetest.NewNonStorageID("test")
+	for _, tt := range []struct {
+		name      string
+		storageID *component.ID
+		host      component.Host
+		err       string
+	}{
+		{
+			name:      "missing",
+			storageID: &storageID,
+			host:      storagetest.NewStorageHost(),
+			err:       "storage extension 'test_storage/test' not found",
+		},
+		{
+			name:      "not_storage",
+			storageID: &nonStorageID,
+			host:      storagetest.NewStorageHost().WithNonStorageExtension("test"),
+			err:       "non-storage extension 'non_storage/test' found",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := newTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), consumertest.NewNop(), Config{
+				DecisionWait: time.Second,
+				NumTraces:    100,
+				StorageID:    tt.storageID,
+			})
+			require.NoError(t, err)
+			assert.EqualError(t, p.Start(context.Background(), tt.host), tt.err)
+			require.NoError(t, p.Shutdown(context.Background()))
+		})
+	}
+}
+
+func TestStorageInvalidPendingTraces(t *testing.T) {
+	dir := t.TempDir()
+	set := processortest.NewNopCreateSettings()
+	client := storagetest.NewFileBackedClient(component.KindProcessor, set.ID, "", dir)
+	require.NoError(t, client.Set(context.Background(), pendingTracesKey, []byte{1, 2, 3}))
+	require.NoError(t, client.Close(context.Background()))
+
+	storageID := storagetest.NewStorageID("test")
+	p, err := newTracesProcessor(context.Background(), set, consumertest.NewNop(), Config{
+		DecisionWait: time.Second,
+		NumTraces:    100,
+		StorageID:    &storageID,
+	})
+	require.NoError(t, err)
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", dir)
+	assert.ErrorIs(t, p.Start(context.Background(), host), errInvalidPendingTraces)
+	require.NoError(t, p.Shutdown(context.Background()))
+}
@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package datadogreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/datadogreceiver"
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	semconv "go.opentelemetry.io/collector/semconv/v1.16.0"
+)
+
+const (
+	// The reserved attributes of the Datadog logs.
+	logFieldMessage   = "message"
+	logFieldStatus    = "status"
+	logFieldTimestamp = "timestamp"
+	logFieldHostname  = "hostname"
+	logFieldService   = "service"
+	logFieldSource    = "ddsource"
+	logFieldTags      = "ddtags"
+
+	// attributeLogSource is the resource attribute holding the source of a log,
+	// i.e. the technology it comes from.
+	attributeLogSource = "datadog.log.source"
+)
+
+// logStatuses maps the Datadog log statuses to the severity numbers.
+var logStatuses = map[string]plog.SeverityNumber{
+	"trace":     plog.SeverityNumberTrace,
+	"debug":     plog.SeverityNumberDebug,
+	"info":      plog.SeverityNumberInfo,
+	"notice":    plog.SeverityNumberInfo2,
+	"ok":        plog.SeverityNumberInfo,
+	"success":   plog.SeverityNumberInfo,
+	"warn":      plog.SeverityNumberWarn,
+	"warning":   plog.SeverityNumberWarn,
+	"error":     plog.SeverityNumberError,
+	"err":       plog.SeverityNumberError,
+	"critical":  plog.SeverityNumberFatal,
+	"alert":     plog.SeverityNumberFatal2,
+	"emergency": plog.SeverityNumberFatal4,
+	"emerg":     plog.SeverityNumberFatal4,
+	"fatal":     plog.SeverityNumberFatal,
+}
+
+// translateLogs translates the payload of the /api/v2/logs endpoint, which
+// is either a JSON array of logs or a single log.
+func translateLogs(req *http.Request) (plog.Logs, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return plog.Logs{}, err
+	}
+
+	var entries []map[string]any
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '{' {
+		entry := map[string]any{}
+		err = json.Unmarshal(body, &entry)
+		entries = append(entries, entry)
+	} else {
+		err = json.Unmarshal(body, &entries)
+	}
+	if err != nil {
+		return plog.Logs{}, err
+	}
+
+	logs := plog.NewLogs()
+	scopes := make(map[string]plog.LogRecordSlice)
+	for _, entry := range entries {
+		resource, record := translateLog(entry)
+		key := resourceKey(resource)
+		records, ok := scopes[key]
+		if !ok {
+			rl := logs.ResourceLogs().AppendEmpty()
+			rl.SetSchemaUrl(semconv.SchemaURL)
+			resource.CopyTo(rl.Resource().Attributes())
+			sl := rl.ScopeLogs().AppendEmpty()
+			sl.Scope().SetName("Datadog")
+			records = sl.LogRecords()
+			scopes[key] = records
+		}
+		record.MoveTo(records.AppendEmpty())
+	}
+	return logs, nil
+}
+
+// translateLog translates a single log into its resource attributes and record.
+func translateLog(entry map[string]any) (pcommon.Map, plog.LogRecord) {
+	var tags []string
+	if ddtags, ok := entry[logFieldTags].(string); ok && ddtags != "" {
+		tags = strings.Split(ddtags, ",")
+	}
+	host, _ := entry[logFieldHostname].(string)
+	resource, attrs := tagsToAttributes(tags, host)
+	if service, ok := entry[logFieldService].(string); ok && service != "" {
+		resource.PutStr(semconv.AttributeServiceName, service)
+	}
+	if source, ok := entry[logFieldSource].(string); ok && source != "" {
+		resource.PutStr(attributeLogSource, source)
+	}
+
+	record := plog.NewLogRecord()
+	now := pcommon.NewTimestampFromTime(time.Now())
+	record.SetObservedTimestamp(now)
+	record.SetTimestamp(now)
+	if ts, ok := entry[logFieldTimestamp].(float64); ok {
+		record.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(int64(ts))))
+	}
+
+	if status, ok := entry[logFieldStatus].(string); ok {
+		record.SetSeverityText(status)
+		record.SetSeverityNumber(logStatuses[strings.ToLower(status)])
+	}
+
+	switch message := entry[logFieldMessage].(type) {
+	case nil:
+	case string:
+		record.Body().SetStr(message)
+	default:
+		_ = record.Body().FromRaw(message)
+	}
+
+	attrs.CopyTo(record.Attributes())
+	for k, v := range entry {
+		switch k {
+		case logFieldMessage, logFieldStatus, logFieldTimestamp, logFieldHostname, logFieldService, logFieldSource, logFieldTags:
+			continue
+		}
+		// The values decoded from JSON are all supported by FromRaw.
+		_ = record.Attributes().PutEmpty(k).FromRaw(v)
+	}
+	return resource, record
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package datadogreceiver
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	semconv "go.opentelemetry.io/collector/semconv/v1.16.0"
+)
+
+func TestTranslateLogs(t *testing.T) {
+	body := `[
+		{"message": "started", "status": "info", "timestamp": 1700000000123, "hostname": "host-1", "service": "api", "ddsource": "go", "ddtags": "env:prod, team:a", "request_id": "abc", "retries": 2},
+		{"message": "failed", "status": "ERROR", "hostname": "host-1", "service": "api", "ddsource": "go", "ddtags": "env:prod,team:b"},
+		{"message": {"nested": true}, "hostname": "host-2"}
+	]`
+	req, err := http.NewRequest(http.MethodPost, "/api/v2/logs", strings.NewReader(body))
+	require.NoError(t, err)
+
+	logs, err := translateLogs(req)
+	require.NoError(t, err)
+	require.Equal(t, 2, logs.ResourceLogs().Len())
+
+	rl := logs.ResourceLogs().At(0)
+	assert.Equal(t, map[string]any{
+		semconv.AttributeHostName:              "host-1",
+		semconv.AttributeServiceName:           "api",
+		semconv.AttributeDeploymentEnvironment: "prod",
+		attributeLogSource:                     "go",
+	}, rl.Resource().Attributes().AsRaw())
+	assert.Equal(t, "Datadog", rl.ScopeLogs().At(0).Scope().Name())
+	records := rl.ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 2, records.Len())
+
+	record := records.At(0)
+	assert.Equal(t, "started", record.Body().Str())
+	assert.Equal(t, "info", record.SeverityText())
+	assert.Equal(t, plog.SeverityNumberInfo, record.SeverityNumber())
+	assert.EqualValues(t, 1700000000123*1e6, record.Timestamp())
+	assert.Equal(t, map[string]any{
+		"team":       "a",
+		"request_id": "abc",
+		"retries":    2.0,
+	}, record.Attributes().AsRaw())
+
+	record = records.At(1)
+	assert.Equal(t, plog.SeverityNumberError, record.SeverityNumber())
+	assert.NotZero(t, record.Timestamp())
+
+	record = logs.ResourceLogs().At(1).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, map[string]any{"nested": true}, record.Body().AsRaw())
+}
+
+func TestTranslateLogsSingle(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "/api/v2/logs", strings.NewReader(` {"message": "hello", "status": "warn"}`))
+	require.NoError(t, err)
+
+	logs, err := translateLogs(req)
+	require.NoError(t, err)
+	require.Equal(t, 1, logs.LogRecordCount())
+	record := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "hello", record.Body().Str())
+	assert.Equal(t, plog.SeverityNumberWarn, record.SeverityNumber())
+}
+
+func TestTranslateLogsInvalid(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "/api/v2/logs", strings.NewReader("[{"))
+	require.NoError(t, err)
+	_, err = translateLogs(req)
+	assert.Error(t, err)
+}
@@ -0,0 +1,324 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package datadogreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/datadogreceiver"
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/DataDog/agent-payload/v5/gogen"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	semconv "go.opentelemetry.io/collector/semconv/v1.16.0"
+)
+
+const (
+	// The Datadog series types, as named by the v1 API.
+	seriesTypeGauge = "gauge"
+	seriesTypeCount = "count"
+	seriesTypeRate  = "rate"
+
+	// The attributes set on the data points of service checks.
+	attributeCheckMessage = "message"
+	// The attribute set on the data points of series with a device.
+	attributeDevice = "device"
+
+	// The sketches are sent by the Datadog agent with the default parameters
+	// of its quantile package: a relative accuracy of 1/128 for values above
+	// a minimum of 1e-9. The value of a sketch bin of key k is
+	// sign(k) * sketchGamma^(|k| - sketchBias).
+	sketchRelativeAccuracy = 1.0 / 128
+	sketchMinValue         = 1e-9
+	sketchMaxKey           = math.MaxInt16
+
+	// exponentialHistogramScale is the scale of the exponential histograms
+	// converted from sketches, its buckets are narrower than the sketch bins.
+	exponentialHistogramScale = 6
+)
+
+var (
+	sketchGammaLn = math.Log1p(2 * sketchRelativeAccuracy)
+	sketchBias    = -int(math.Floor(math.Log(sketchMinValue)/sketchGammaLn)) + 1
+)
+
+// seriesV1Payload is the payload of the /api/v1/series endpoint.
+type seriesV1Payload struct {
+	Series []seriesV1 `json:"series"`
+}
+
+type seriesV1 struct {
+	Metric   string       `json:"metric"`
+	Points   [][2]float64 `json:"points"`
+	Tags     []string     `json:"tags"`
+	Host     string       `json:"host"`
+	Type     string       `json:"type"`
+	Interval int64        `json:"interval"`
+	Device   string       `json:"device"`
+}
+
+// checkRun is a service check sent to the /api/v1/check_run endpoint.
+type checkRun struct {
+	Check     string   `json:"check"`
+	HostName  string   `json:"host_name"`
+	Timestamp int64    `json:"timestamp"`
+	Status    int64    `json:"status"`
+	Message   string   `json:"message"`
+	Tags      []string `json:"tags"`
+}
+
+// metricsBuilder groups the translated metrics per resource.
+type metricsBuilder struct {
+	metrics pmetric.Metrics
+	scopes  map[string]pmetric.MetricSlice
+}
+
+func newMetricsBuilder() *metricsBuilder {
+	return &metricsBuilder{
+		metrics: pmetric.NewMetrics(),
+		scopes:  make(map[string]pmetric.MetricSlice),
+	}
+}
+
+// appendMetric adds a metric to the resource described by the tags and the
+// host, and returns it with the data point attributes found in the tags.
+func (b *metricsBuilder) appendMetric(name string, tags []string, host string) (pmetric.Metric, pcommon.Map) {
+	resource, attrs := tagsToAttributes(tags, host)
+	key := resourceKey(resource)
+	metrics, ok := b.scopes[key]
+	if !ok {
+		rm := b.metrics.ResourceMetrics().AppendEmpty()
+		rm.SetSchemaUrl(semconv.SchemaURL)
+		resource.CopyTo(rm.Resource().Attributes())
+		sm := rm.ScopeMetrics().AppendEmpty()
+		sm.Scope().SetName("Datadog")
+		metrics = sm.Metrics()
+		b.scopes[key] = metrics
+	}
+	metric := metrics.AppendEmpty()
+	metric.SetName(name)
+	return metric, attrs
+}
+
+// appendSeries adds a series of points, whose timestamps are in seconds.
+func (b *metricsBuilder) appendSeries(name, unit, typ string, interval int64, tags []string, host string, points [][2]float64, extraAttrs map[string]string) {
+	metric, attrs := b.appendMetric(name, tags, host)
+	metric.SetUnit(unit)
+	for k, v := range extraAttrs {
+		attrs.PutStr(k, v)
+	}
+
+	var dps pmetric.NumberDataPointSlice
+	// A rate is the count per second over the interval, it is converted back
+	// to the count when the interval is known.
+	scale := 1.0
+	switch {
+	case typ == seriesTypeCount, typ == seriesTypeRate && interval > 0:
+		sum := metric.SetEmptySum()
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		sum.SetIsMonotonic(false)
+		dps = sum.DataPoints()
+		if typ == seriesTypeRate {
+			scale = float64(interval)
+		}
+	default:
+		dps = metric.SetEmptyGauge().DataPoints()
+	}
+
+	for _, point := range points {
+		dp := dps.AppendEmpty()
+		ts := time.Unix(0, int64(point[0]*float64(time.Second)))
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+		if interval > 0 {
+			dp.SetStartTimestamp(pcommon.NewTimestampFromTime(ts.Add(-time.Duration(interval) * time.Second)))
+		}
+		dp.SetDoubleValue(point[1] * scale)
+		attrs.CopyTo(dp.Attributes())
+	}
+}
+
+// appendSketch adds an exponential histogram converted from a sketch.
+func (b *metricsBuilder) appendSketch(sketch *gogen.SketchPayload_Sketch) {
+	metric, attrs := b.appendMetric(sketch.Metric, sketch.Tags, sketch.Host)
+	histogram := metric.SetEmptyExponentialHistogram()
+	histogram.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+
+	for i := range sketch.Dogsketches {
+		dogsketch := &sketch.Dogsketches[i]
+		dp := histogram.DataPoints().AppendEmpty()
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(dogsketch.Ts, 0)))
+		dp.SetCount(uint64(dogsketch.Cnt))
+		dp.SetSum(dogsketch.Sum)
+		if dogsketch.Cnt > 0 {
+			dp.SetMin(dogsketch.Min)
+			dp.SetMax(dogsketch.Max)
+		}
+		dp.SetScale(exponentialHistogramScale)
+		attrs.CopyTo(dp.Attributes())
+
+		positive := map[int32]uint64{}
+		negative := map[int32]uint64{}
+		for j, k := range dogsketch.K {
+			if j >= len(dogsketch.N) {
+				break
+			}
+			n := uint64(dogsketch.N[j])
+			switch {
+			case k == 0:
+				dp.SetZeroCount(dp.ZeroCount() + n)
+			case k > 0 && k < sketchMaxKey:
+				positive[bucketIndex(sketchKeyValue(k))] += n
+			case k < 0 && k > -sketchMaxKey:
+				negative[bucketIndex(sketchKeyValue(-k))] += n
+			}
+			// The keys beyond the maximum stand for infinite values,
+			// they are only accounted for in the count.
+		}
+		setBuckets(dp.Positive(), positive)
+		setBuckets(dp.Negative(), negative)
+	}
+}
+
+// sketchKeyValue returns the value of the sketch bin of positive key k.
+func sketchKeyValue(k int32) float64 {
+	return math.Exp(float64(int(k)-sketchBias) * sketchGammaLn)
+}
+
+// bucketIndex returns the index of the exponential histogram bucket holding
+// the positive value v, i.e. such that base^index < v <= base^(index+1).
+func bucketIndex(v float64) int32 {
+	return int32(math.Ceil(math.Log2(v)*math.Exp2(exponentialHistogramScale))) - 1
+}
+
+func setBuckets(buckets pmetric.ExponentialHistogramDataPointBuckets, counts map[int32]uint64) {
+	if len(counts) == 0 {
+		return
+	}
+	minIndex, maxIndex := int32(math.MaxInt32), int32(math.MinInt32)
+	for index := range counts {
+		minIndex = min(minIndex, index)
+		maxIndex = max(maxIndex, index)
+	}
+	buckets.SetOffset(minIndex)
+	bucketCounts := make([]uint64, maxIndex-minIndex+1)
+	for index, count := range counts {
+		bucketCounts[index-minIndex] = count
+	}
+	buckets.BucketCounts().FromRaw(bucketCounts)
+}
+
+// translateSeriesV1 translates the payload of the /api/v1/series endpoint.
+func translateSeriesV1(req *http.Request) (pmetric.Metrics, error) {
+	var payload seriesV1Payload
+	if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+		return pmetric.Metrics{}, err
+	}
+
+	b := newMetricsBuilder()
+	for i := range payload.Series {
+		series := &payload.Series[i]
+		var extraAttrs map[string]string
+		if series.Device != "" {
+			extraAttrs = map[string]string{attributeDevice: series.Device}
+		}
+		b.appendSeries(series.Metric, "", series.Type, series.Interval, series.Tags, series.Host, series.Points, extraAttrs)
+	}
+	return b.metrics, nil
+}
+
+// translateSeriesV2 translates the payload of the /api/v2/series endpoint,
+// which the Datadog agent encodes in protobuf.
+func translateSeriesV2(req *http.Request) (pmetric.Metrics, error) {
+	var payload gogen.MetricPayload
+	if err := unmarshalPayload(req, &payload); err != nil {
+		return pmetric.Metrics{}, err
+	}
+
+	b := newMetricsBuilder()
+	for _, series := range payload.Series {
+		var host string
+		var extraAttrs map[string]string
+		for _, resource := range series.Resources {
+			switch resource.Type {
+			case "host":
+				host = resource.Name
+			case attributeDevice:
+				extraAttrs = map[string]string{attributeDevice: resource.Name}
+			}
+		}
+
+		var typ string
+		switch series.Type {
+		case gogen.MetricPayload_COUNT:
+			typ = seriesTypeCount
+		case gogen.MetricPayload_RATE:
+			typ = seriesTypeRate
+		default:
+			typ = seriesTypeGauge
+		}
+
+		points := make([][2]float64, len(series.Points))
+		for i, point := range series.Points {
+			points[i] = [2]float64{float64(point.Timestamp), point.Value}
+		}
+		b.appendSeries(series.Metric, series.Unit, typ, series.Interval, series.Tags, host, points, extraAttrs)
+	}
+	return b.metrics, nil
+}
+
+// translateSketches translates the payload of the /api/beta/sketches endpoint.
+func translateSketches(req *http.Request) (pmetric.Metrics, error) {
+	var payload gogen.SketchPayload
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return pmetric.Metrics{}, err
+	}
+	if err = payload.Unmarshal(body); err != nil {
+		return pmetric.Metrics{}, err
+	}
+
+	b := newMetricsBuilder()
+	for i := range payload.Sketches {
+		b.appendSketch(&payload.Sketches[i])
+	}
+	return b.metrics, nil
+}
+
+// translateCheckRuns translates the service checks sent to the /api/v1/check_run
+// endpoint into gauges whose value is the status of the check.
+func translateCheckRuns(req *http.Request) (pmetric.Metrics, error) {
+	var checks []checkRun
+	if err := json.NewDecoder(req.Body).Decode(&checks); err != nil {
+		return pmetric.Metrics{}, err
+	}
+
+	b := newMetricsBuilder()
+	for i := range checks {
+		check := &checks[i]
+		metric, attrs := b.appendMetric(check.Check, check.Tags, check.HostName)
+		dp := metric.SetEmptyGauge().DataPoints().AppendEmpty()
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(check.Timestamp, 0)))
+		dp.SetIntValue(check.Status)
+		attrs.CopyTo(dp.Attributes())
+		if check.Message != "" {
+			dp.Attributes().PutStr(attributeCheckMessage, check.Message)
+		}
+	}
+	return b.metrics, nil
+}
+
+// unmarshalPayload decodes a protobuf payload, or a JSON one depending on the
+// content type of the request.
+func unmarshalPayload(req *http.Request, payload *gogen.MetricPayload) error {
+	if getMediaType(req) == "application/json" {
+		return json.NewDecoder(req.Body).Decode(payload)
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return err
+	}
+	return payload.Unmarshal(body)
+}
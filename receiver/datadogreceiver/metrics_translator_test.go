@@ -0,0 +1,249 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package datadogreceiver
+
+import (
+	"bytes"
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/DataDog/agent-payload/v5/gogen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	semconv "go.opentelemetry.io/collector/semconv/v1.16.0"
+)
+
+func TestTagsToAttributes(t *testing.T) {
+	resource, attrs := tagsToAttributes([]string{"env:prod", "service:api", "team:a", "team:b", "team:c", "flag", ":ignored"}, "host-1")
+	assert.Equal(t, map[string]any{
+		semconv.AttributeHostName:              "host-1",
+		semconv.AttributeDeploymentEnvironment: "prod",
+		semconv.AttributeServiceName:           "api",
+	}, resource.AsRaw())
+	assert.Equal(t, map[string]any{
+		"team": []any{"a", "b", "c"},
+		"flag": "",
+	}, attrs.AsRaw())
+}
+
+func TestTranslateSeriesV1(t *testing.T) {
+	body := `{"series": [
+		{"metric": "system.load.1", "points": [[1700000000, 1.5], [1700000010, 2]], "tags": ["env:prod", "core:1"], "host": "host-1", "type": "gauge"},
+		{"metric": "requests", "points": [[1700000000, 3]], "tags": ["env:prod"], "host": "host-1", "type": "count", "interval": 10},
+		{"metric": "bytes", "points": [[1700000000, 4]], "host": "host-2", "type": "rate", "interval": 10, "device": "sda"},
+		{"metric": "rps", "points": [[1700000000, 5]], "host": "host-2", "type": "rate"}
+	]}`
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/series", strings.NewReader(body))
+	require.NoError(t, err)
+
+	metrics, err := translateSeriesV1(req)
+	require.NoError(t, err)
+	require.Equal(t, 2, metrics.ResourceMetrics().Len())
+
+	rm := metrics.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]any{
+		semconv.AttributeHostName:              "host-1",
+		semconv.AttributeDeploymentEnvironment: "prod",
+	}, rm.Resource().Attributes().AsRaw())
+	assert.Equal(t, "Datadog", rm.ScopeMetrics().At(0).Scope().Name())
+	ms := rm.ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, ms.Len())
+
+	gauge := ms.At(0)
+	assert.Equal(t, "system.load.1", gauge.Name())
+	require.Equal(t, pmetric.MetricTypeGauge, gauge.Type())
+	require.Equal(t, 2, gauge.Gauge().DataPoints().Len())
+	dp := gauge.Gauge().DataPoints().At(1)
+	assert.Equal(t, 2.0, dp.DoubleValue())
+	assert.EqualValues(t, 1700000010*1e9, dp.Timestamp())
+	assert.Equal(t, map[string]any{"core": "1"}, dp.Attributes().AsRaw())
+
+	count := ms.At(1)
+	require.Equal(t, pmetric.MetricTypeSum, count.Type())
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, count.Sum().AggregationTemporality())
+	dp = count.Sum().DataPoints().At(0)
+	assert.Equal(t, 3.0, dp.DoubleValue())
+	assert.EqualValues(t, 1699999990*1e9, dp.StartTimestamp())
+
+	ms = metrics.ResourceMetrics().At(1).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, ms.Len())
+	rate := ms.At(0)
+	require.Equal(t, pmetric.MetricTypeSum, rate.Type())
+	dp = rate.Sum().DataPoints().At(0)
+	assert.Equal(t, 40.0, dp.DoubleValue())
+	assert.Equal(t, map[string]any{attributeDevice: "sda"}, dp.Attributes().AsRaw())
+	// A rate without interval cannot be converted to a count.
+	assert.Equal(t, pmetric.MetricTypeGauge, ms.At(1).Type())
+}
+
+func TestTranslateSeriesV2(t *testing.T) {
+	payload := gogen.MetricPayload{
+		Series: []*gogen.MetricPayload_MetricSeries{
+			{
+				Resources: []*gogen.MetricPayload_Resource{{Type: "host", Name: "host-1"}},
+				Metric:    "requests",
+				Tags:      []string{"service:api", "path:/"},
+				Points:    []*gogen.MetricPayload_MetricPoint{{Value: 2, Timestamp: 1700000000}},
+				Type:      gogen.MetricPayload_COUNT,
+				Unit:      "request",
+				Interval:  15,
+			},
+			{
+				Metric: "temperature",
+				Points: []*gogen.MetricPayload_MetricPoint{{Value: 21.5, Timestamp: 1700000000}},
+				Type:   gogen.MetricPayload_UNSPECIFIED,
+			},
+		},
+	}
+	body, err := payload.Marshal()
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, "/api/v2/series", bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-protobuf")
+
+	metrics, err := translateSeriesV2(req)
+	require.NoError(t, err)
+	require.Equal(t, 2, metrics.ResourceMetrics().Len())
+
+	rm := metrics.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]any{
+		semconv.AttributeHostName:    "host-1",
+		semconv.AttributeServiceName: "api",
+	}, rm.Resource().Attributes().AsRaw())
+	count := rm.ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "request", count.Unit())
+	require.Equal(t, pmetric.MetricTypeSum, count.Type())
+	dp := count.Sum().DataPoints().At(0)
+	assert.Equal(t, 2.0, dp.DoubleValue())
+	assert.EqualValues(t, 1699999985*1e9, dp.StartTimestamp())
+	assert.Equal(t, map[string]any{"path": "/"}, dp.Attributes().AsRaw())
+
+	gauge := metrics.ResourceMetrics().At(1).ScopeMetrics().At(0).Metrics().At(0)
+	require.Equal(t, pmetric.MetricTypeGauge, gauge.Type())
+	assert.Equal(t, 21.5, gauge.Gauge().DataPoints().At(0).DoubleValue())
+}
+
+func TestTranslateSeriesV2JSON(t *testing.T) {
+	body := `{"series": [{"metric": "queue.size", "points": [{"timestamp": 1700000000, "value": 7}], "type": 3}]}`
+	req, err := http.NewRequest(http.MethodPost, "/api/v2/series", strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	metrics, err := translateSeriesV2(req)
+	require.NoError(t, err)
+	gauge := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "queue.size", gauge.Name())
+	require.Equal(t, pmetric.MetricTypeGauge, gauge.Type())
+	assert.Equal(t, 7.0, gauge.Gauge().DataPoints().At(0).DoubleValue())
+}
+
+func TestTranslateSketches(t *testing.T) {
+	// The value of the bin of key bias is 1, keyTwo is the first bin whose
+	// value is above 2.
+	keyOne := int32(sketchBias)
+	keyTwo := int32(sketchBias) + int32(math.Ceil(math.Ln2/sketchGammaLn))
+	payload := gogen.SketchPayload{
+		Sketches: []gogen.SketchPayload_Sketch{
+			{
+				Metric: "latency",
+				Host:   "host-1",
+				Tags:   []string{"env:prod", "route:/"},
+				Dogsketches: []gogen.SketchPayload_Sketch_Dogsketch{
+					{
+						Ts:  1700000000,
+						Cnt: 7,
+						Min: -1,
+						Max: 2,
+						Sum: 5,
+						K:   []int32{-keyOne, 0, keyOne, keyTwo, math.MaxInt16},
+						N:   []uint32{1, 2, 1, 2, 1},
+					},
+				},
+			},
+		},
+	}
+	body, err := payload.Marshal()
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, "/api/beta/sketches", bytes.NewReader(body))
+	require.NoError(t, err)
+
+	metrics, err := translateSketches(req)
+	require.NoError(t, err)
+	rm := metrics.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]any{
+		semconv.AttributeHostName:              "host-1",
+		semconv.AttributeDeploymentEnvironment: "prod",
+	}, rm.Resource().Attributes().AsRaw())
+
+	metric := rm.ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "latency", metric.Name())
+	require.Equal(t, pmetric.MetricTypeExponentialHistogram, metric.Type())
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, metric.ExponentialHistogram().AggregationTemporality())
+
+	dp := metric.ExponentialHistogram().DataPoints().At(0)
+	assert.EqualValues(t, 1700000000*1e9, dp.Timestamp())
+	assert.EqualValues(t, 7, dp.Count())
+	assert.Equal(t, 5.0, dp.Sum())
+	assert.Equal(t, -1.0, dp.Min())
+	assert.Equal(t, 2.0, dp.Max())
+	assert.EqualValues(t, 2, dp.ZeroCount())
+	assert.EqualValues(t, exponentialHistogramScale, dp.Scale())
+	assert.Equal(t, map[string]any{"route": "/"}, dp.Attributes().AsRaw())
+
+	// The value 1 is the upper bound of the bucket -1, and the bucket 63
+	// has an upper bound of 2.
+	assert.EqualValues(t, -1, dp.Positive().Offset())
+	counts := dp.Positive().BucketCounts().AsRaw()
+	require.Len(t, counts, 1<<exponentialHistogramScale+2)
+	assert.EqualValues(t, 1, counts[0])
+	assert.EqualValues(t, 2, counts[len(counts)-1])
+	assert.EqualValues(t, -1, dp.Negative().Offset())
+	assert.Equal(t, []uint64{1}, dp.Negative().BucketCounts().AsRaw())
+}
+
+func TestTranslateCheckRuns(t *testing.T) {
+	body := `[
+		{"check": "app.ok", "host_name": "host-1", "timestamp": 1700000000, "status": 0, "tags": ["env:prod", "check:http"]},
+		{"check": "app.can_connect", "host_name": "host-1", "timestamp": 1700000000, "status": 2, "message": "connection refused", "tags": ["env:prod"]}
+	]`
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/check_run", strings.NewReader(body))
+	require.NoError(t, err)
+
+	metrics, err := translateCheckRuns(req)
+	require.NoError(t, err)
+	require.Equal(t, 1, metrics.ResourceMetrics().Len())
+	ms := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, ms.Len())
+
+	dp := ms.At(0).Gauge().DataPoints().At(0)
+	assert.Equal(t, "app.ok", ms.At(0).Name())
+	assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+	assert.EqualValues(t, 0, dp.IntValue())
+	assert.Equal(t, map[string]any{"check": "http"}, dp.Attributes().AsRaw())
+
+	dp = ms.At(1).Gauge().DataPoints().At(0)
+	assert.EqualValues(t, 2, dp.IntValue())
+	assert.Equal(t, pcommon.NewTimestampFromTime(pcommon.Timestamp(1700000000*1e9).AsTime()), dp.Timestamp())
+	assert.Equal(t, map[string]any{attributeCheckMessage: "connection refused"}, dp.Attributes().AsRaw())
+}
+
+func TestTranslateMetricsInvalid(t *testing.T) {
+	for name, translate := range map[string]func(*http.Request) (pmetric.Metrics, error){
+		"series_v1":  translateSeriesV1,
+		"series_v2":  translateSeriesV2,
+		"sketches":   translateSketches,
+		"check_runs": translateCheckRuns,
+	} {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "/", strings.NewReader("{"))
+			require.NoError(t, err)
+			_, err = translate(req)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -7,25 +7,32 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 
 	pb "github.com/DataDog/datadog-agent/pkg/proto/pbgo/trace"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
 )
 
 type datadogReceiver struct {
-	address      string
-	config       *Config
-	params       receiver.CreateSettings
-	nextConsumer consumer.Traces
-	server       *http.Server
-	tReceiver    *receiverhelper.ObsReport
+	address             string
+	listener            net.Listener
+	config              *Config
+	params              receiver.CreateSettings
+	nextTracesConsumer  consumer.Traces
+	nextMetricsConsumer consumer.Metrics
+	nextLogsConsumer    consumer.Logs
+	server              *http.Server
+	tReceiver           *receiverhelper.ObsReport
 }
 
-func newDataDogReceiver(config *Config, nextConsumer consumer.Traces, params receiver.CreateSettings) (receiver.Traces, error) {
+func newDataDogReceiver(config *Config, params receiver.CreateSettings) (component.Component, error) {
 
 	instance, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{LongLivedCtx: false, ReceiverID: params.ID, Transport: "http", ReceiverCreateSettings: params})
 	if err != nil {
@@ -33,9 +40,8 @@ func newDataDogReceiver(config *Config, nextConsumer consumer.Traces, params rec
 	}
 
 	return &datadogReceiver{
-		params:       params,
-		config:       config,
-		nextConsumer: nextConsumer,
+		params: params,
+		config: config,
 		server: &http.Server{
 			ReadTimeout: config.ReadTimeout,
 		},
@@ -45,11 +51,22 @@ func newDataDogReceiver(config *Config, nextConsumer consumer.Traces, params rec
 
 func (ddr *datadogReceiver) Start(_ context.Context, host component.Host) error {
 	ddmux := http.NewServeMux()
-	ddmux.HandleFunc("/v0.3/traces", ddr.handleTraces)
-	ddmux.HandleFunc("/v0.4/traces", ddr.handleTraces)
-	ddmux.HandleFunc("/v0.5/traces", ddr.handleTraces)
-	ddmux.HandleFunc("/v0.7/traces", ddr.handleTraces)
-	ddmux.HandleFunc("/api/v0.2/traces", ddr.handleTraces)
+	if ddr.nextTracesConsumer != nil {
+		ddmux.HandleFunc("/v0.3/traces", ddr.handleTraces)
+		ddmux.HandleFunc("/v0.4/traces", ddr.handleTraces)
+		ddmux.HandleFunc("/v0.5/traces", ddr.handleTraces)
+		ddmux.HandleFunc("/v0.7/traces", ddr.handleTraces)
+		ddmux.HandleFunc("/api/v0.2/traces", ddr.handleTraces)
+	}
+	if ddr.nextMetricsConsumer != nil {
+		ddmux.HandleFunc("/api/v1/series", ddr.handleMetrics(translateSeriesV1))
+		ddmux.HandleFunc("/api/v2/series", ddr.handleMetrics(translateSeriesV2))
+		ddmux.HandleFunc("/api/beta/sketches", ddr.handleMetrics(translateSketches))
+		ddmux.HandleFunc("/api/v1/check_run", ddr.handleMetrics(translateCheckRuns))
+	}
+	if ddr.nextLogsConsumer != nil {
+		ddmux.HandleFunc("/api/v2/logs", ddr.handleLogs)
+	}
 
 	var err error
 	ddr.server, err = ddr.config.ServerConfig.ToServer(
@@ -66,6 +83,7 @@ func (ddr *datadogReceiver) Start(_ context.Context, host component.Host) error
 	}
 
 	ddr.address = hln.Addr().String()
+	ddr.listener = hln
 
 	go func() {
 		if err := ddr.server.Serve(hln); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -76,7 +94,15 @@ func (ddr *datadogReceiver) Start(_ context.Context, host component.Host) error
 }
 
 func (ddr *datadogReceiver) Shutdown(ctx context.Context) (err error) {
-	return ddr.server.Shutdown(ctx)
+	err = ddr.server.Shutdown(ctx)
+	// The listener is only closed by the server once it is serving, which may
+	// not be the case yet when shutting down right after starting.
+	if ddr.listener != nil {
+		if closeErr := ddr.listener.Close(); closeErr != nil && !errors.Is(closeErr, net.ErrClosed) {
+			err = errors.Join(err, closeErr)
+		}
+	}
+	return err
 }
 
 func (ddr *datadogReceiver) handleTraces(w http.ResponseWriter, req *http.Request) {
@@ -97,7 +123,7 @@ func (ddr *datadogReceiver) handleTraces(w http.ResponseWriter, req *http.Reques
 	for _, ddTrace := range ddTraces {
 		otelTraces := toTraces(ddTrace, req)
 		spanCount = otelTraces.SpanCount()
-		err = ddr.nextConsumer.ConsumeTraces(obsCtx, otelTraces)
+		err = ddr.nextTracesConsumer.ConsumeTraces(obsCtx, otelTraces)
 		if err != nil {
 			http.Error(w, "Trace consumer errored out", http.StatusInternalServerError)
 			ddr.params.Logger.Error("Trace consumer errored out")
@@ -108,3 +134,63 @@ func (ddr *datadogReceiver) handleTraces(w http.ResponseWriter, req *http.Reques
 	_, _ = w.Write([]byte("OK"))
 
 }
+
+// handleMetrics returns the handler of a metrics endpoint, whose payload is
+// translated by the given function.
+func (ddr *datadogReceiver) handleMetrics(translate func(*http.Request) (pmetric.Metrics, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		obsCtx := ddr.tReceiver.StartMetricsOp(req.Context())
+		var err error
+		var metricCount int
+		defer func(metricCount *int) {
+			ddr.tReceiver.EndMetricsOp(obsCtx, "datadog", *metricCount, err)
+		}(&metricCount)
+
+		var metrics pmetric.Metrics
+		metrics, err = translate(req)
+		if err != nil {
+			http.Error(w, "Unable to unmarshal reqs", http.StatusBadRequest)
+			ddr.params.Logger.Error("Unable to unmarshal reqs", zap.Error(err))
+			return
+		}
+		metricCount = metrics.DataPointCount()
+		err = ddr.nextMetricsConsumer.ConsumeMetrics(obsCtx, metrics)
+		if err != nil {
+			http.Error(w, "Metrics consumer errored out", http.StatusInternalServerError)
+			ddr.params.Logger.Error("Metrics consumer errored out", zap.Error(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"errors":[]}`))
+	}
+}
+
+func (ddr *datadogReceiver) handleLogs(w http.ResponseWriter, req *http.Request) {
+	obsCtx := ddr.tReceiver.StartLogsOp(req.Context())
+	var err error
+	var logCount int
+	defer func(logCount *int) {
+		ddr.tReceiver.EndLogsOp(obsCtx, "datadog", *logCount, err)
+	}(&logCount)
+
+	var logs plog.Logs
+	logs, err = translateLogs(req)
+	if err != nil {
+		http.Error(w, "Unable to unmarshal reqs", http.StatusBadRequest)
+		ddr.params.Logger.Error("Unable to unmarshal reqs", zap.Error(err))
+		return
+	}
+	logCount = logs.LogRecordCount()
+	err = ddr.nextLogsConsumer.ConsumeLogs(obsCtx, logs)
+	if err != nil {
+		http.Error(w, "Logs consumer errored out", http.StatusInternalServerError)
+		ddr.params.Logger.Error("Logs consumer errored out", zap.Error(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	_, _ = w.Write([]byte(`{}`))
+}
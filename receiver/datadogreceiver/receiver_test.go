@@ -39,10 +39,10 @@ func TestDatadogServer(t *testing.T) {
 	cfg.Endpoint = "localhost:0" // Using a randomly assigned address
 	dd, err := newDataDogReceiver(
 		cfg,
-		consumertest.NewNop(),
 		receivertest.NewNopCreateSettings(),
 	)
 	require.NoError(t, err, "Must not error when creating receiver")
+	dd.(*datadogReceiver).nextTracesConsumer = consumertest.NewNop()
 
 	ctx, cancel := context.WithCancel(context.Background())
 	t.Cleanup(cancel)
@@ -88,3 +88,50 @@ func TestDatadogServer(t *testing.T) {
 		})
 	}
 }
+
+func TestDatadogMetricsAndLogsServer(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:0" // Using a randomly assigned address
+	dd, err := newDataDogReceiver(
+		cfg,
+		receivertest.NewNopCreateSettings(),
+	)
+	require.NoError(t, err, "Must not error when creating receiver")
+	metricsSink := new(consumertest.MetricsSink)
+	logsSink := new(consumertest.LogsSink)
+	dd.(*datadogReceiver).nextMetricsConsumer = metricsSink
+	dd.(*datadogReceiver).nextLogsConsumer = logsSink
+
+	require.NoError(t, dd.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, dd.Shutdown(context.Background()), "Must not error shutting down")
+	})
+
+	post := func(path, body string) (int, string) {
+		resp, err := http.Post(fmt.Sprintf("http://%s%s", dd.(*datadogReceiver).address, path), "application/json", strings.NewReader(body))
+		require.NoError(t, err, "Must not error performing request")
+		actual, err := io.ReadAll(resp.Body)
+		require.NoError(t, multierr.Combine(err, resp.Body.Close()), "Must not error when reading body")
+		return resp.StatusCode, string(actual)
+	}
+
+	code, content := post("/api/v1/series", `{"series": [{"metric": "m", "points": [[1700000000, 1]], "type": "gauge"}]}`)
+	assert.Equal(t, http.StatusAccepted, code)
+	assert.Equal(t, `{"errors":[]}`, content)
+	code, _ = post("/api/v1/check_run", `[{"check": "c", "status": 1}]`)
+	assert.Equal(t, http.StatusAccepted, code)
+	assert.Equal(t, 2, metricsSink.DataPointCount())
+
+	code, content = post("/api/v2/logs", `[{"message": "hello"}]`)
+	assert.Equal(t, http.StatusAccepted, code)
+	assert.Equal(t, `{}`, content)
+	assert.Equal(t, 1, logsSink.LogRecordCount())
+
+	code, content = post("/api/v1/series", "{")
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "Unable to unmarshal reqs\n", content)
+
+	// The traces endpoints are not registered without a traces consumer.
+	code, _ = post("/v0.4/traces", "[]")
+	assert.Equal(t, http.StatusNotFound, code)
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package datadogreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/datadogreceiver"
+
+import (
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	semconv "go.opentelemetry.io/collector/semconv/v1.16.0"
+)
+
+// resourceTags maps the Datadog tags that describe the source of the
+// data to the equivalent resource attributes.
+var resourceTags = map[string]string{
+	"env":               semconv.AttributeDeploymentEnvironment,
+	"service":           semconv.AttributeServiceName,
+	"version":           semconv.AttributeServiceVersion,
+	"container_id":      semconv.AttributeContainerID,
+	"container_name":    semconv.AttributeContainerName,
+	"image_name":        semconv.AttributeContainerImageName,
+	"image_tag":         semconv.AttributeContainerImageTag,
+	"kube_cluster_name": semconv.AttributeK8SClusterName,
+	"kube_namespace":    semconv.AttributeK8SNamespaceName,
+	"kube_deployment":   semconv.AttributeK8SDeploymentName,
+	"pod_name":          semconv.AttributeK8SPodName,
+	"region":            semconv.AttributeCloudRegion,
+	"availability-zone": semconv.AttributeCloudAvailabilityZone,
+}
+
+// tagsToAttributes splits Datadog tags, formatted as "key:value", into the
+// attributes of the resource and those of the data. The host is set as the
+// host.name resource attribute. A tag without a value is kept with an empty
+// value, and the values of a key repeated in several tags are kept as a slice.
+func tagsToAttributes(tags []string, host string) (resource pcommon.Map, attrs pcommon.Map) {
+	resource = pcommon.NewMap()
+	attrs = pcommon.NewMap()
+	if host != "" {
+		resource.PutStr(semconv.AttributeHostName, host)
+	}
+
+	for _, tag := range tags {
+		key, value, _ := strings.Cut(strings.TrimSpace(tag), ":")
+		if key == "" {
+			continue
+		}
+		if attr, ok := resourceTags[key]; ok {
+			resource.PutStr(attr, value)
+			continue
+		}
+
+		existing, ok := attrs.Get(key)
+		switch {
+		case !ok:
+			attrs.PutStr(key, value)
+		case existing.Type() == pcommon.ValueTypeSlice:
+			existing.Slice().AppendEmpty().SetStr(value)
+		default:
+			values := pcommon.NewValueSlice()
+			existing.CopyTo(values.Slice().AppendEmpty())
+			values.Slice().AppendEmpty().SetStr(value)
+			values.CopyTo(existing)
+		}
+	}
+	return resource, attrs
+}
+
+// resourceKey returns a key identifying the resource with the given attributes,
+// which are all strings.
+func resourceKey(resource pcommon.Map) string {
+	keys := make([]string, 0, resource.Len())
+	resource.Range(func(k string, v pcommon.Value) bool {
+		keys = append(keys, k+"="+v.Str())
+		return true
+	})
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
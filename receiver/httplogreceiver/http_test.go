@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httplogreceiver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	nethttp "net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/http"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/json"
+)
+
+func TestHTTP(t *testing.T) {
+	listenAddress := "127.0.0.1:29020"
+	cfg := testdataConfigYaml(listenAddress)
+
+	f := NewFactory()
+	sink := new(consumertest.LogsSink)
+	rcvr, err := f.CreateLogsReceiver(context.Background(), receivertest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcvr.Start(context.Background(), componenttest.NewNopHost()))
+
+	var body bytes.Buffer
+	gw := gzip.NewWriter(&body)
+	_, err = gw.Write([]byte(`[{"message":"msg 0","level":"info"},{"message":"msg 1","level":"warn"}]`))
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+
+	req, err := nethttp.NewRequest(nethttp.MethodPost, "http://"+listenAddress+"/logs", &body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "gzip")
+	resp, err := nethttp.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, nethttp.StatusOK, resp.StatusCode)
+
+	require.Eventually(t, expectNLogs(sink, 2), 2*time.Second, time.Millisecond)
+	require.NoError(t, rcvr.Shutdown(context.Background()))
+
+	var messages []string
+	for _, logs := range sink.AllLogs() {
+		records := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+		for i := 0; i < records.Len(); i++ {
+			message, ok := records.At(i).Body().Map().Get("message")
+			require.True(t, ok)
+			messages = append(messages, message.Str())
+		}
+	}
+	assert.ElementsMatch(t, []string{"msg 0", "msg 1"}, messages)
+}
+
+func TestHTTPWithOperators(t *testing.T) {
+	listenAddress := "127.0.0.1:29021"
+	cfg := testdataConfigYaml(listenAddress)
+	cfg.Operators = []operator.Config{
+		{
+			Builder: json.NewConfig(),
+		},
+	}
+
+	f := NewFactory()
+	sink := new(consumertest.LogsSink)
+	rcvr, err := f.CreateLogsReceiver(context.Background(), receivertest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcvr.Start(context.Background(), componenttest.NewNopHost()))
+
+	resp, err := nethttp.Post("http://"+listenAddress+"/logs", "text/plain", bytes.NewBufferString("{\"message\":\"msg 0\"}\n"))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, nethttp.StatusOK, resp.StatusCode)
+
+	require.Eventually(t, expectNLogs(sink, 1), 2*time.Second, time.Millisecond)
+	require.NoError(t, rcvr.Shutdown(context.Background()))
+
+	record := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, `{"message":"msg 0"}`, record.Body().Str())
+	message, ok := record.Attributes().Get("message")
+	require.True(t, ok)
+	assert.Equal(t, "msg 0", message.Str())
+}
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	sub, err := cm.Sub("httplog")
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+	assert.NoError(t, component.ValidateConfig(cfg))
+	assert.Equal(t, testdataConfigYaml("127.0.0.1:29020"), cfg)
+}
+
+func testdataConfigYaml(listenAddress string) *HTTPLogConfig {
+	return &HTTPLogConfig{
+		BaseConfig: adapter.BaseConfig{
+			Operators: []operator.Config{},
+		},
+		InputConfig: func() http.Config {
+			c := http.NewConfig()
+			c.ListenAddress = listenAddress
+			c.Path = "/logs"
+			return *c
+		}(),
+	}
+}
+
+func TestDecodeInputConfigFailure(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	factory := NewFactory()
+	badCfg := &HTTPLogConfig{
+		BaseConfig: adapter.BaseConfig{
+			Operators: []operator.Config{},
+		},
+		InputConfig: func() http.Config {
+			c := http.NewConfig()
+			c.ListenAddress = "127.0.0.1:29022"
+			c.Format = "fake"
+			return *c
+		}(),
+	}
+	receiver, err := factory.CreateLogsReceiver(context.Background(), receivertest.NewNopCreateSettings(), badCfg, sink)
+	require.Error(t, err, "receiver creation should fail if input config isn't valid")
+	require.Nil(t, receiver, "receiver creation should fail if input config isn't valid")
+}
+
+func expectNLogs(sink *consumertest.LogsSink, expected int) func() bool {
+	return func() bool {
+		return sink.LogRecordCount() == expected
+	}
+}